@@ -3,4 +3,6 @@ package main
 const (
 	CONFIG_PATH_ENV = "configs"
 	CONFIG_FILE_ENV = "local.yml"
+
+	KEYS_COMMAND = "keys"
 )
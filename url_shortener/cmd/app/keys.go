@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/flew1x/url_shortener_ms/internal/app"
+	"github.com/flew1x/url_shortener_ms/internal/config"
+)
+
+const keysUsage = `usage:
+  keys create -name <name> [-owner <user>] [-workspace <id>] -scopes <scope>[,<scope>...]
+  keys list
+  keys revoke <id>`
+
+// runKeys runs the "keys" command managing API keys.
+//
+// Parameters:
+// - ctx: the context.Context for the command.
+// - cfg: the configuration object.
+// - logger: the logger object.
+// - args: the arguments following "keys".
+//
+// Returns:
+// - error: an error if the arguments are wrong or the command failed.
+func runKeys(ctx context.Context, cfg *config.Config, logger *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(keysUsage)
+	}
+
+	keys, err := app.InitialAPIKeys(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("keys create", flag.ContinueOnError)
+		name := flags.String("name", "", "what the key is used for")
+		owner := flags.String("owner", "", "the user owning the links created with the key")
+		workspace := flags.String("workspace", "", "the workspace the key acts in")
+		scopes := flags.String("scopes", "", "comma-separated scopes")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		key, token, err := keys.Create(ctx, *name, *owner, *workspace, strings.Split(*scopes, ","))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Store the token now, it is not shown again.")
+		return encoder.Encode(map[string]any{"id": key.ID, "name": key.Name, "owner": key.Owner, "workspace_id": key.WorkspaceID, "scopes": key.Scopes, "token": token})
+	case "list":
+		list, err := keys.List(ctx, "")
+		if err != nil {
+			return err
+		}
+
+		return encoder.Encode(list)
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(keysUsage)
+		}
+
+		return keys.Revoke(ctx, "", args[1])
+	default:
+		return errors.New(keysUsage)
+	}
+}
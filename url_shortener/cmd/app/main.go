@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
+	_ "time/tzdata"
 
 	"github.com/flew1x/url_shortener_ms/internal/app"
 	"github.com/flew1x/url_shortener_ms/internal/config"
@@ -16,6 +19,14 @@ func main() {
 
 	logger := logger.InitLogger(cfg.LoggerConfig.GetLogLevel())
 
+	if len(os.Args) > 1 && os.Args[1] == KEYS_COMMAND {
+		if err := runKeys(ctx, cfg, logger, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	server, err := app.InitialServer(ctx, cfg, logger)
 	if err != nil {
 		panic(err)
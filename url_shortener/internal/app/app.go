@@ -2,15 +2,31 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
 
 	"github.com/flew1x/url_shortener_ms/internal/cache"
 	"github.com/flew1x/url_shortener_ms/internal/config"
 	http_v1 "github.com/flew1x/url_shortener_ms/internal/controllers/http/v1"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/events"
 	"github.com/flew1x/url_shortener_ms/internal/repository"
 	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/clientip"
+	"github.com/flew1x/url_shortener_ms/pkg/geoip"
+	"github.com/flew1x/url_shortener_ms/pkg/jwks"
+	"github.com/flew1x/url_shortener_ms/pkg/ratelimit"
+	"github.com/flew1x/url_shortener_ms/pkg/safebrowsing"
+	"github.com/flew1x/url_shortener_ms/pkg/urlpolicy"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -18,9 +34,13 @@ import (
 )
 
 type Server struct {
-	config *config.Config
-	router *gin.Engine
-	logger *slog.Logger
+	config   *config.Config
+	router   *gin.Engine
+	logger   *slog.Logger
+	services *service.Service
+	relays   []*events.Relay
+	clicks   *service.ClickRecorder
+	policy   *urlpolicy.File
 }
 
 // createAddress constructs the address string for a server.
@@ -72,14 +92,95 @@ func InitialServer(ctx context.Context, config *config.Config, logger *slog.Logg
 		return nil, err
 	}
 
+	// Create the indexes the repositories rely on, such as the unique codes of links
+	if err := repository.EnsureIndexes(ctx, mongoDatabase); err != nil {
+		logger.Error("error creating indexes: " + err.Error())
+		return nil, err
+	}
+
 	// Initialize repositories
 	repositories := repository.NewRepository(logger, config, mongoDatabase)
 
+	// Open the GeoIP database used by geo targeting rules
+	locator, err := newLocator(logger, config.GeoConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Load the keys of the identity provider verifying bearer JWTs
+	keys, err := newKeySet(ctx, logger, config.AuthConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Check that the plans of usage quotas exist
+	if err := validateUsagePlans(config.UsageConfig); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Read the policy of the destinations links may lead to
+	policyFile, err := newDestinationPolicy(logger, config.DestinationPolicyConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	var destinationPolicy service.IDestinationPolicy = &urlpolicy.Policy{}
+	if policyFile != nil {
+		destinationPolicy = policyFile
+	}
+
+	// Load the threat lists destinations are checked against
+	threats, err := newThreatList(logger, config.ThreatListConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
 	// Initialize services
-	services := service.NewService(logger, repositories, cache, config)
+	services := service.NewService(logger, repositories, cache, config, locator, keys, destinationPolicy, threats)
+
+	// Initialize the events sink and the outbox relays, one for the sink and
+	// one for webhooks, so that neither holds back the other
+	sink, err := events.NewSink(logger, config.EventsConfig, redisClient)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	relays := []*events.Relay{
+		events.NewRelay(logger, repositories.OutboxRepository, events.OUTBOX_CONSUMER_SINK, sink, config.EventsConfig),
+		events.NewRelay(logger, repositories.OutboxRepository, events.OUTBOX_CONSUMER_WEBHOOKS, services.Webhooks, config.EventsConfig),
+	}
+
+	// Initialize the click recorder, which stores and meters the clicks of redirects in the background
+	clicks := service.NewClickRecorder(logger, services.Clicks, services.Usage, service.CLICK_QUEUE_SIZE, service.CLICK_RECORD_WORKERS)
+
+	trustedProxies, err := clientip.ParseNetworks(config.ServerConfig.GetTrustedProxies())
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Read the files associating the apps of deep links with the service
+	associations, err := readAppAssociations(config.DeepLinkConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Parse the rate limits, shared between instances in Redis
+	rateLimits, err := newRateLimits(logger, config.RateLimitConfig, redisClient)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
 
 	// Initialize handlers
-	handlers := http_v1.NewHandler(logger, services, config, cache)
+	handlers := http_v1.NewHandler(logger, services, config, cache, clientip.NewResolver(trustedProxies), associations, rateLimits, clicks)
 
 	// Initialize router
 	router := handlers.InitRoutes()
@@ -87,7 +188,254 @@ func InitialServer(ctx context.Context, config *config.Config, logger *slog.Logg
 	logger.Info("Starting the application...")
 
 	// Initialize and return App
-	return &Server{config: config, router: router, logger: logger}, nil
+	return &Server{config: config, router: router, logger: logger, services: services, relays: relays, clicks: clicks, policy: policyFile}, nil
+}
+
+// InitialAPIKeys initializes the API key service used by the command line,
+// without starting the server.
+//
+// Parameters:
+// - ctx: the context.Context for the function.
+// - config: the configuration object.
+// - logger: the logger object.
+//
+// Returns:
+// - service.IAPIKeyService: the API key service.
+// - error: an error if the database cannot be reached.
+func InitialAPIKeys(ctx context.Context, config *config.Config, logger *slog.Logger) (service.IAPIKeyService, error) {
+	mongoDatabase, err := mongoDatabase(ctx, logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewAPIKeyService(logger, repository.NewAPIKeyRepository(logger, mongoDatabase)), nil
+}
+
+// newLocator opens the configured GeoIP database.
+//
+// Parameters:
+// - logger: the logger object.
+// - geoConfig: the geo targeting configuration.
+//
+// Returns:
+// - geoip.ILocator: the locator, locating nothing if no database is configured.
+// - error: an error if the database cannot be opened.
+func newLocator(logger *slog.Logger, geoConfig config.IGeoConfig) (geoip.ILocator, error) {
+	path := geoConfig.GetDatabasePath()
+	if path == "" {
+		logger.Info("No GeoIP database configured, geo targeting rules never match")
+		return geoip.NewNoopLocator(), nil
+	}
+
+	return geoip.NewMMDBLocator(path)
+}
+
+// newKeySet loads the configured JWKS.
+//
+// The keys are read once at startup so that a wrong source shows in the
+// logs, an unreachable identity provider being retried on use.
+//
+// Parameters:
+// - ctx: the context.Context for the function.
+// - logger: the logger object.
+// - authConfig: the authentication configuration.
+//
+// Returns:
+// - *jwks.KeySet: the keys, nil if no JWKS is configured.
+// - error: an error if the issuer, audience, algorithms or default scopes are missing or invalid.
+func newKeySet(ctx context.Context, logger *slog.Logger, authConfig config.IAuthConfig) (*jwks.KeySet, error) {
+	source := authConfig.GetJWKS()
+	if source == "" {
+		logger.Info("No JWKS configured, bearer JWTs are refused")
+		return nil, nil
+	}
+
+	if authConfig.GetJWTIssuer() == "" || authConfig.GetJWTAudience() == "" || len(authConfig.GetJWTAlgorithms()) == 0 {
+		return nil, errors.New("auth_jwt_issuer, auth_jwt_audience and auth_jwt_algorithms are required with auth_jwt_jwks")
+	}
+
+	for _, scope := range authConfig.GetJWTDefaultScopes() {
+		if !slices.Contains(entity.Scopes, scope) {
+			return nil, fmt.Errorf("unknown scope %q in auth_jwt_default_scopes", scope)
+		}
+	}
+
+	keys := jwks.NewKeySet(source, authConfig.GetJWKSRefreshInterval())
+	if err := keys.Refresh(ctx); err != nil {
+		logger.Error("error loading JWKS", slog.String("source", source), slog.String("err", err.Error()))
+	}
+
+	return keys, nil
+}
+
+// newDestinationPolicy reads the configured destination policy file.
+//
+// Parameters:
+// - logger: the logger object.
+// - policyConfig: the destination policy configuration.
+//
+// Returns:
+// - *urlpolicy.File: the policy, nil if no file is configured and every destination is allowed.
+// - error: an error if the file cannot be read or is invalid.
+func newDestinationPolicy(logger *slog.Logger, policyConfig config.IDestinationPolicyConfig) (*urlpolicy.File, error) {
+	path := policyConfig.GetPath()
+	if path == "" {
+		logger.Info("No destination policy configured, every destination is allowed")
+		return nil, nil
+	}
+
+	return urlpolicy.NewFile(path)
+}
+
+// newThreatList loads the threat lists kept in the state file, then
+// applies the configured update file.
+//
+// Parameters:
+// - logger: the logger object.
+// - threatConfig: the threat list configuration.
+//
+// Returns:
+// - *safebrowsing.Database: the threat lists, nil if no update file is configured.
+// - error: an error if the state or update file cannot be read or applied.
+func newThreatList(logger *slog.Logger, threatConfig config.IThreatListConfig) (*safebrowsing.Database, error) {
+	updatePath := threatConfig.GetUpdatePath()
+	if updatePath == "" {
+		logger.Info("No threat list configured, destinations are not checked for threats")
+		return nil, nil
+	}
+
+	database := safebrowsing.NewDatabase()
+
+	statePath := threatConfig.GetStatePath()
+	if statePath != "" {
+		if err := database.Load(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if _, err := database.ApplyFile(updatePath); err != nil {
+		return nil, err
+	}
+	if statePath != "" {
+		if err := database.Save(statePath); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("Threat lists loaded", slog.Int("prefixes", database.Len()))
+
+	return database, nil
+}
+
+// validateUsagePlans checks that the default plan and the plans of the
+// accounts are configured, and that their limits are not negative.
+//
+// Parameters:
+// - usageConfig: the usage configuration.
+//
+// Returns:
+// - error: an error naming the first unknown plan or negative limit.
+func validateUsagePlans(usageConfig config.IUsageConfig) error {
+	if !usageConfig.GetEnabled() {
+		return nil
+	}
+
+	plans := usageConfig.GetPlans()
+	for name, plan := range plans {
+		if plan.DailyLinks < 0 || plan.MonthlyLinks < 0 || plan.DailyRedirects < 0 || plan.MonthlyRedirects < 0 {
+			return fmt.Errorf("negative limit in %s plan %q", config.USAGE_PLANS, name)
+		}
+	}
+
+	if _, ok := plans[usageConfig.GetDefaultPlan()]; !ok {
+		return fmt.Errorf("unknown plan %q in %s", usageConfig.GetDefaultPlan(), config.USAGE_DEFAULT_PLAN)
+	}
+
+	for _, account := range usageConfig.GetAccountPlans() {
+		if _, ok := plans[account.Plan]; !ok || account.Account == "" {
+			return fmt.Errorf("unknown plan %q or missing account in %s", account.Plan, config.USAGE_ACCOUNT_PLANS)
+		}
+	}
+
+	return nil
+}
+
+// newRateLimits parses the configured rate limits.
+//
+// Parameters:
+// - logger: the logger object.
+// - rateLimitConfig: the rate limiting configuration.
+// - redisClient: the Redis client sharing the counts between instances.
+//
+// Returns:
+// - http_v1.RateLimits: the limits, without limiter if rate limiting is disabled.
+// - error: an error if a limit is malformed.
+func newRateLimits(logger *slog.Logger, rateLimitConfig config.IRateLimitConfig, redisClient *redis.Client) (http_v1.RateLimits, error) {
+	if !rateLimitConfig.GetEnabled() {
+		logger.Info("Rate limiting disabled")
+		return http_v1.RateLimits{}, nil
+	}
+
+	rateLimits := http_v1.RateLimits{Limiter: ratelimit.NewLimiter(redisClient)}
+
+	limits := []struct {
+		name  string
+		value string
+		limit *ratelimit.Limit
+	}{
+		{config.RATE_LIMIT_REDIRECT, rateLimitConfig.GetRedirectLimit(), &rateLimits.Redirect},
+		{config.RATE_LIMIT_SHORTEN, rateLimitConfig.GetShortenLimit(), &rateLimits.Shorten},
+		{config.RATE_LIMIT_ADMIN, rateLimitConfig.GetAdminLimit(), &rateLimits.Admin},
+		{config.RATE_LIMIT_API, rateLimitConfig.GetAPILimit(), &rateLimits.API},
+		{config.RATE_LIMIT_AUTH, rateLimitConfig.GetAuthLimit(), &rateLimits.Auth},
+	}
+
+	for _, limit := range limits {
+		parsed, err := ratelimit.ParseLimit(limit.value)
+		if err != nil {
+			return http_v1.RateLimits{}, fmt.Errorf("%s: %w", limit.name, err)
+		}
+		*limit.limit = parsed
+	}
+
+	return rateLimits, nil
+}
+
+// readAppAssociations reads the configured app association files.
+//
+// Parameters:
+// - deepLinkConfig: the deep link configuration.
+//
+// Returns:
+// - http_v1.AppAssociations: the files, nil for those not configured.
+// - error: an error if a file cannot be read or is not valid JSON.
+func readAppAssociations(deepLinkConfig config.IDeepLinkConfig) (http_v1.AppAssociations, error) {
+	var associations http_v1.AppAssociations
+
+	files := []struct {
+		path    string
+		content *[]byte
+	}{
+		{deepLinkConfig.GetAppleAppSiteAssociationPath(), &associations.AppleAppSiteAssociation},
+		{deepLinkConfig.GetAssetLinksPath(), &associations.AssetLinks},
+	}
+
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			return http_v1.AppAssociations{}, err
+		}
+		if !json.Valid(data) {
+			return http_v1.AppAssociations{}, fmt.Errorf("app association file %s is not valid JSON", file.path)
+		}
+		*file.content = data
+	}
+
+	return associations, nil
 }
 
 // mongoDatabase initializes a new MongoDB database connection.
@@ -124,20 +472,203 @@ func mongoDatabase(ctx context.Context, logger *slog.Logger, config *config.Conf
 
 // Run runs the Server.
 //
-// It starts the HTTP server with the provided context.
+// It starts the background jobs and the HTTP server, until SIGINT or
+// SIGTERM. The HTTP server is then shut down first, and the clicks of its
+// last redirects recorded before Run returns.
 func (a *Server) Run() {
-	a.StartHTTP(context.Background())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The click recorder outlives the HTTP server, which queues its clicks
+	clicksCtx, stopClicks := context.WithCancel(context.WithoutCancel(ctx))
+	clicksDone := make(chan struct{})
+	go func() {
+		a.clicks.Run(clicksCtx)
+		close(clicksDone)
+	}()
+
+	go a.StartClickRetention(ctx)
+	for _, relay := range a.relays {
+		go relay.Run(ctx)
+	}
+	go a.StartWebhookDelivery(ctx)
+	go a.StartUsageFlush(ctx)
+	go a.WatchDestinationPolicy(ctx)
+	go a.StartThreatScan(ctx)
+	go a.StartLinkMonitor(ctx)
+
+	a.StartHTTP(ctx)
+
+	stopClicks()
+	<-clicksDone
+	a.logger.Info("Server stopped")
 }
 
-// StartHTTP starts the HTTP server.
+// StartClickRetention periodically aggregates and deletes raw clicks
+// older than the configured retention period.
+//
+// ctx context.Context
+func (a *Server) StartClickRetention(ctx context.Context) {
+	ticker := time.NewTicker(a.config.PrivacyConfig.GetRetentionInterval())
+	defer ticker.Stop()
+
+	for {
+		if err := a.services.Clicks.ApplyRetention(ctx); err != nil {
+			a.logger.Error("Click retention failed", slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartWebhookDelivery periodically attempts due webhook deliveries.
+//
+// ctx context.Context
+func (a *Server) StartWebhookDelivery(ctx context.Context) {
+	ticker := time.NewTicker(a.config.WebhookConfig.GetDispatchInterval())
+	defer ticker.Stop()
+
+	for {
+		if err := a.services.Webhooks.DeliverDue(ctx); err != nil {
+			a.logger.Error("Webhook delivery failed", slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartUsageFlush periodically saves the usage counted in Redis to MongoDB.
+//
+// ctx context.Context
+func (a *Server) StartUsageFlush(ctx context.Context) {
+	if !a.config.UsageConfig.GetEnabled() {
+		return
+	}
+
+	ticker := time.NewTicker(a.config.UsageConfig.GetFlushInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := a.services.Usage.Flush(ctx); err != nil {
+			a.logger.Error("Usage flush failed", slog.String("err", err.Error()))
+		}
+	}
+}
+
+// WatchDestinationPolicy reloads the destination policy when its file
+// changes. An invalid file is logged and the previous policy kept.
+//
+// ctx context.Context
+func (a *Server) WatchDestinationPolicy(ctx context.Context) {
+	if a.policy == nil {
+		return
+	}
+
+	err := a.policy.Watch(ctx, func(err error) {
+		if err != nil {
+			a.logger.Error("Destination policy not reloaded, previous policy kept", slog.String("err", err.Error()))
+			return
+		}
+		a.logger.Info("Destination policy reloaded")
+	})
+	if err != nil {
+		a.logger.Error("Destination policy not watched, changes need a restart", slog.String("err", err.Error()))
+	}
+}
+
+// StartThreatScan periodically applies the threat list update file and
+// disables the links whose destinations became listed. Links are rescanned
+// at start, the lists having been loaded then, and after every update
+// changing the lists.
+//
+// ctx context.Context
+func (a *Server) StartThreatScan(ctx context.Context) {
+	if a.config.ThreatListConfig.GetUpdatePath() == "" {
+		return
+	}
+
+	ticker := time.NewTicker(a.config.ThreatListConfig.GetRescanInterval())
+	defer ticker.Stop()
+
+	for rescan := true; ; rescan = false {
+		changed, err := a.services.Threats.Update(ctx)
+		if err != nil {
+			a.logger.Error("Threat list update failed, previous lists kept", slog.String("err", err.Error()))
+		}
+		if rescan || changed {
+			if err := a.services.Threats.Rescan(ctx); err != nil {
+				a.logger.Error("Threat rescan failed", slog.String("err", err.Error()))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartLinkMonitor periodically publishes the expiration of links and the
+// health changes of their destinations.
+//
+// ctx context.Context
+func (a *Server) StartLinkMonitor(ctx context.Context) {
+	ticker := time.NewTicker(a.config.WebhookConfig.GetMonitorInterval())
+	defer ticker.Stop()
+
+	for {
+		if err := a.services.Monitor.Scan(ctx); err != nil {
+			a.logger.Error("Link monitor failed", slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartHTTP starts the HTTP server and shuts it down when the context is
+// canceled, waiting up to SHUTDOWN_TIMEOUT for the requests in progress.
 //
 // ctx context.Context
 func (a *Server) StartHTTP(ctx context.Context) {
 	address := createAddress(a.config.ServerConfig.GetBindIP(), a.config.ServerConfig.GetPort())
-	err := a.router.Run(address)
-	if err != nil {
+	server := &http.Server{Addr: address, Handler: a.router}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	a.logger.Info("Server started", slog.String("address", address))
+
+	select {
+	case err := <-errs:
 		panic(err)
+	case <-ctx.Done():
 	}
 
-	a.logger.Info("Server started", slog.String("address", address))
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), SHUTDOWN_TIMEOUT)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		a.logger.Error("HTTP server not shut down gracefully", slog.String("err", err.Error()))
+	}
 }
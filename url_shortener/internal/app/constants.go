@@ -1,5 +1,10 @@
 package app
 
+import "time"
+
 const (
 	POSTGRES_ADDRESS_TEMPLATE = "mongodb://%s:%s@%s:%s"
 )
+
+// SHUTDOWN_TIMEOUT is how long the requests in progress may take to complete on shutdown.
+const SHUTDOWN_TIMEOUT = 10 * time.Second
@@ -15,6 +15,14 @@ type Cache struct {
 	// UrlCache is an IUrlCache implementation that is used to cache
 	// URLs and their corresponding short URLs.
 	UrlCache IUrlCache
+
+	// UsageCache is an IUsageCache implementation that is used to count
+	// the links and redirects of every account.
+	UsageCache IUsageCache
+
+	// TitleCache is an ITitleCache implementation that is used to keep
+	// the titles of previewed pages.
+	TitleCache ITitleCache
 }
 
 // NewCache creates a new instance of the Cache struct.
@@ -28,6 +36,8 @@ type Cache struct {
 // - *Cache: a pointer to the Cache struct.
 func NewCache(logger *slog.Logger, config config.IRedisConfig, urlConfig config.IURLConfig, redisClient *redis.Client) *Cache {
 	return &Cache{
-		UrlCache: NewUrlCache(logger, config, urlConfig, redisClient),
+		UrlCache:   NewUrlCache(logger, config, urlConfig, redisClient),
+		UsageCache: NewUsageCache(logger, redisClient),
+		TitleCache: NewTitleCache(logger, redisClient),
 	}
 }
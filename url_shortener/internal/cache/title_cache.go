@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TITLE_KEY_PREFIX starts the keys of the page titles, followed by the
+// hex SHA-256 of the page URL, so that long URLs make short keys.
+const TITLE_KEY_PREFIX = "title:"
+
+type ITitleCache interface {
+	// Get returns the cached title of a page, redis.Nil if it is not cached.
+	Get(ctx context.Context, pageURL string) (string, error)
+
+	// Set caches the title of a page, empty for pages whose title cannot be read.
+	Set(ctx context.Context, pageURL, title string, ttl time.Duration) error
+}
+
+// redisTitleCache is an implementation of ITitleCache that keeps the
+// titles in Redis, shared by every instance of the service.
+type redisTitleCache struct {
+	// logger is used for logging.
+	logger *slog.Logger
+
+	// client is a Redis client.
+	client *redis.Client
+}
+
+func NewTitleCache(logger *slog.Logger, client *redis.Client) ITitleCache {
+	return &redisTitleCache{logger: logger, client: client}
+}
+
+// Get returns the cached title of a page.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - pageURL: the URL of the page.
+//
+// Returns:
+// - string: the title, empty for pages whose title could not be read.
+// - error: redis.Nil if the title is not cached, or an error if the operation failed.
+func (c *redisTitleCache) Get(ctx context.Context, pageURL string) (string, error) {
+	title, err := c.client.Get(ctx, titleKey(pageURL)).Result()
+	if err != nil {
+		c.logger.Debug("Failed to get title from cache", slog.String("err", err.Error()))
+		return "", err
+	}
+
+	return title, nil
+}
+
+// Set caches the title of a page.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - pageURL: the URL of the page.
+// - title: the title, empty for pages whose title could not be read.
+// - ttl: how long the title is kept.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisTitleCache) Set(ctx context.Context, pageURL, title string, ttl time.Duration) error {
+	if err := c.client.Set(ctx, titleKey(pageURL), title, ttl).Err(); err != nil {
+		c.logger.Debug("Failed to set title in cache", slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// titleKey returns the cache key of the title of a page.
+func titleKey(pageURL string) string {
+	sum := sha256.Sum256([]byte(pageURL))
+	return TITLE_KEY_PREFIX + hex.EncodeToString(sum[:])
+}
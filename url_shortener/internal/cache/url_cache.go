@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 
 	"github.com/flew1x/url_shortener_ms/internal/config"
@@ -9,6 +10,17 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// MISSING_VALUE is cached for short URLs known not to exist. Saving the URL
+// overwrites it.
+const MISSING_VALUE = "-"
+
+// OWNER_KEY_PREFIX and OWNER_KEY_SEPARATOR frame the owner in the cache keys
+// of long URLs, which cannot start with "owner:" since they are http(s) URLs.
+const (
+	OWNER_KEY_PREFIX    = "owner:"
+	OWNER_KEY_SEPARATOR = " "
+)
+
 type IUrlCache interface {
 	// Get retrieves a URL from the cache using its long URL.
 	GetByShortUrl(ctx context.Context, shortUrl string) (entity.IURL, error)
@@ -16,11 +28,23 @@ type IUrlCache interface {
 	// Set saves a URL in the cache using its short URL.
 	SetByShortUrl(ctx context.Context, url entity.IURL) error
 
-	// Get retrieves a URL from the cache using its short URL.
-	GetByLongUrl(ctx context.Context, longUrl string) (entity.IURL, error)
+	// Get retrieves a URL of the owner from the cache using its long URL.
+	GetByLongUrl(ctx context.Context, ownerID, longUrl string) (entity.IURL, error)
 
-	// Set saves a URL in the cache using its long URL.
+	// Set saves a URL in the cache using its owner and long URL.
 	SetByLongUrl(ctx context.Context, url entity.IURL) error
+
+	// GetByShortUrls retrieves the URLs of several short URLs at once.
+	GetByShortUrls(ctx context.Context, shortUrls []string) (map[string]entity.IURL, error)
+
+	// SetMissing remembers that the short URLs do not exist.
+	SetMissing(ctx context.Context, shortUrls []string) error
+
+	// DeleteByShortUrl removes a URL from the cache using its short URL.
+	DeleteByShortUrl(ctx context.Context, shortUrl string) error
+
+	// DeleteByLongUrl removes a URL of the owner from the cache using its long URL.
+	DeleteByLongUrl(ctx context.Context, ownerID, longUrl string) error
 }
 
 // redisUserTokenCache is an implementation of IUrlCache interface
@@ -61,16 +85,96 @@ func (c *redisUserTokenCache) GetByShortUrl(ctx context.Context, shortURL string
 
 	c.logger.Debug("Retrieved URL from cache", slog.String("key", shortURL), slog.String("value", value))
 
-	url := entity.NewURL(
-		shortURL,
-		value,
-	)
+	if value == MISSING_VALUE {
+		return nil, redis.Nil
+	}
 
-	return url, nil
+	return decodeURL(shortURL, value), nil
+}
+
+// GetByShortUrls retrieves the URLs of several short URLs in one round trip.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - shortUrls: the short URLs to retrieve from the cache.
+//
+// Returns:
+// - map[string]entity.IURL: the cached URLs by short URL. Short URLs known
+// not to exist map to nil, short URLs absent from the cache are left out.
+// - error: an error if the operation failed.
+func (c *redisUserTokenCache) GetByShortUrls(ctx context.Context, shortUrls []string) (map[string]entity.IURL, error) {
+	values, err := c.client.MGet(ctx, shortUrls...).Result()
+	if err != nil {
+		c.logger.Debug("Failed to get URLs from cache", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	urls := make(map[string]entity.IURL, len(values))
+	for i, value := range values {
+		value, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		if value == MISSING_VALUE {
+			urls[shortUrls[i]] = nil
+		} else {
+			urls[shortUrls[i]] = decodeURL(shortUrls[i], value)
+		}
+	}
+
+	return urls, nil
+}
+
+// SetMissing remembers that the short URLs do not exist, so that looking
+// them up again does not reach the database.
+//
+// Misses are only kept for the missing cache TTL, much shorter than links,
+// so that a short URL created meanwhile is not answered as missing for long.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - shortUrls: the short URLs that do not exist.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisUserTokenCache) SetMissing(ctx context.Context, shortUrls []string) error {
+	pipe := c.client.Pipeline()
+	for _, shortUrl := range shortUrls {
+		pipe.SetNX(ctx, shortUrl, MISSING_VALUE, c.urlConfig.GetMissingCacheTTL())
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Debug("Failed to save missing URLs to cache", slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// decodeURL decodes a cached URL.
+//
+// Parameters:
+// - shortURL: the short URL the value is cached under.
+// - value: the cached value.
+//
+// Returns:
+// - entity.IURL: the decoded URL.
+func decodeURL(shortURL, value string) entity.IURL {
+	var url entity.URL
+	if err := json.Unmarshal([]byte(value), &url); err != nil {
+		// Entries written before links were cached as JSON hold only the origin.
+		return entity.NewURL(shortURL, value)
+	}
+
+	return &url
 }
 
 // SetByShortUrl saves a URL in the cache using its short URL.
 //
+// The whole URL is stored as JSON, so that its settings are available
+// without a database round trip.
+//
 // Parameters:
 // - ctx: the context.Context for the operation.
 // - url: the URL to save in the cache.
@@ -78,42 +182,51 @@ func (c *redisUserTokenCache) GetByShortUrl(ctx context.Context, shortURL string
 // Returns:
 // - error: an error if the operation failed.
 func (c *redisUserTokenCache) SetByShortUrl(ctx context.Context, shortUrl entity.IURL) error {
-	if err := c.client.Set(ctx, shortUrl.GetShort(), shortUrl.GetOrigin(), c.urlConfig.LiveCaheExpiration()).Err(); err != nil {
+	value, err := json.Marshal(shortUrl)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.Set(ctx, shortUrl.GetShort(), value, c.urlConfig.LiveCaheExpiration()).Err(); err != nil {
 		c.logger.Debug("Failed to save URL to cache", slog.String("err", err.Error()))
 		return err
 	}
 
-	c.logger.Debug("Saved URL to cache", slog.String("key", shortUrl.GetShort()), slog.String("value", shortUrl.GetOrigin()))
+	c.logger.Debug("Saved URL to cache", slog.String("key", shortUrl.GetShort()), slog.String("value", string(value)))
 	return nil
 }
 
-// GetByLongUrl retrieves a URL from the cache using its long URL.
+// GetByLongUrl retrieves a URL of the owner from the cache using its long URL.
 //
 // Parameters:
 // - ctx: the context.Context for the operation.
+// - ownerID: the owner of the URL, empty for URLs created without authentication.
 // - longUrl: the long URL to retrieve from the cache.
 //
 // Returns:
 // - entity.URL: the URL retrieved from the cache.
 // - error: an error if the operation failed.
-func (c *redisUserTokenCache) GetByLongUrl(ctx context.Context, longUrl string) (entity.IURL, error) {
-	value, err := c.client.Get(ctx, longUrl).Result()
+func (c *redisUserTokenCache) GetByLongUrl(ctx context.Context, ownerID, longUrl string) (entity.IURL, error) {
+	key := longUrlKey(ownerID, longUrl)
+
+	value, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		c.logger.Debug("Failed to get URL from cache", slog.String("err", err.Error()))
 		return nil, err
 	}
 
-	c.logger.Debug("Retrieved URL from cache", slog.String("key", longUrl), slog.String("value", value))
+	c.logger.Debug("Retrieved URL from cache", slog.String("key", key), slog.String("value", value))
 
-	url := entity.NewURL(
+	url := entity.CopyURL(entity.NewURL(
 		value,
 		longUrl,
-	)
+	))
+	url.OwnerID = ownerID
 
 	return url, nil
 }
 
-// SetByLongUrl saves a URL in the cache using its long URL.
+// SetByLongUrl saves a URL in the cache using its owner and long URL.
 //
 // Parameters:
 // - ctx: the context.Context for the operation.
@@ -122,11 +235,71 @@ func (c *redisUserTokenCache) GetByLongUrl(ctx context.Context, longUrl string)
 // Returns:
 // - error: an error if the operation failed.
 func (c *redisUserTokenCache) SetByLongUrl(ctx context.Context, longUrl entity.IURL) error {
-	if err := c.client.Set(ctx, longUrl.GetOrigin(), longUrl.GetShort(), c.urlConfig.LiveCaheExpiration()).Err(); err != nil {
+	key := longUrlKey(longUrl.GetOwnerID(), longUrl.GetOrigin())
+
+	if err := c.client.Set(ctx, key, longUrl.GetShort(), c.urlConfig.LiveCaheExpiration()).Err(); err != nil {
 		c.logger.Debug("Failed to save URL to cache", slog.String("err", err.Error()))
 		return err
 	}
 
-	c.logger.Debug("Saved URL to cache", slog.String("key", longUrl.GetOrigin()), slog.String("value", longUrl.GetShort()))
+	c.logger.Debug("Saved URL to cache", slog.String("key", key), slog.String("value", longUrl.GetShort()))
+	return nil
+}
+
+// DeleteByShortUrl removes a URL from the cache using its short URL.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - shortUrl: the short URL to remove from the cache.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisUserTokenCache) DeleteByShortUrl(ctx context.Context, shortUrl string) error {
+	if err := c.client.Del(ctx, shortUrl).Err(); err != nil {
+		c.logger.Debug("Failed to delete URL from cache", slog.String("err", err.Error()))
+		return err
+	}
+
+	c.logger.Debug("Deleted URL from cache", slog.String("key", shortUrl))
 	return nil
 }
+
+// DeleteByLongUrl removes a URL of the owner from the cache using its long URL.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ownerID: the owner of the URL, empty for URLs created without authentication.
+// - longUrl: the long URL to remove from the cache.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisUserTokenCache) DeleteByLongUrl(ctx context.Context, ownerID, longUrl string) error {
+	key := longUrlKey(ownerID, longUrl)
+
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		c.logger.Debug("Failed to delete URL from cache", slog.String("err", err.Error()))
+		return err
+	}
+
+	c.logger.Debug("Deleted URL from cache", slog.String("key", key))
+	return nil
+}
+
+// longUrlKey returns the cache key of a long URL of an owner.
+//
+// Links are only shared between requests of the same owner. Keys of links
+// without owner are the long URL itself, as before links had owners.
+//
+// Parameters:
+// - ownerID: the owner of the URL, empty for URLs created without authentication.
+// - longUrl: the long URL.
+//
+// Returns:
+// - string: the cache key.
+func longUrlKey(ownerID, longUrl string) string {
+	if ownerID == "" {
+		return longUrl
+	}
+
+	return OWNER_KEY_PREFIX + ownerID + OWNER_KEY_SEPARATOR + longUrl
+}
@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+// USAGE_KEY_PREFIX starts the keys of the usage counts, followed by the
+// period and the account, "usage:<period>:<account>". Periods never
+// contain ":", accounts may.
+const USAGE_KEY_PREFIX = "usage:"
+
+// USAGE_DIRTY_KEY is the set of the usage keys changed since they were
+// last saved to MongoDB.
+const USAGE_DIRTY_KEY = "usage:dirty"
+
+// USAGE_KEY_TTL keeps the counts of a month until it is over, the counts
+// being saved to MongoDB long before.
+const USAGE_KEY_TTL = 32 * 24 * time.Hour
+
+// USAGE_SEEDED_FIELD marks the counts raised to the counts saved in
+// MongoDB, so that they are loaded once per key, e.g. after Redis lost
+// them or at the start of a period.
+const USAGE_SEEDED_FIELD = "seeded"
+
+// seedUsage raises the counts of a usage to the saved counts, keeping the
+// live counts when they are higher, and marks them as seeded.
+//
+// KEYS[1]: the usage key.
+// ARGV[1]: the saved links.
+// ARGV[2]: the saved redirects.
+// ARGV[3]: the TTL of the key, in seconds.
+var seedUsage = redis.NewScript(`
+local function raise(field, count)
+	local live = tonumber(redis.call("HGET", KEYS[1], field) or "0")
+	if live < tonumber(count) then
+		redis.call("HSET", KEYS[1], field, count)
+	end
+end
+
+raise("links", ARGV[1])
+raise("redirects", ARGV[2])
+redis.call("HSET", KEYS[1], "seeded", 1)
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+
+return 1
+`)
+
+type IUsageCache interface {
+	// Increment adds one use of a metric by an account to the counts of the periods.
+	Increment(ctx context.Context, account, metric string, periods []string) error
+
+	// Get returns the usage of an account during the periods.
+	Get(ctx context.Context, account string, periods []string) ([]*entity.Usage, error)
+
+	// Unseeded returns the periods whose counts were not raised to the saved counts yet.
+	Unseeded(ctx context.Context, account string, periods []string) ([]string, error)
+
+	// Seed raises the counts of usages to their saved counts.
+	Seed(ctx context.Context, usages []*entity.Usage) error
+
+	// PopDirty returns and forgets up to count usages changed since they were last saved.
+	PopDirty(ctx context.Context, count int64) ([]*entity.Usage, error)
+
+	// MarkDirty remembers usages that could not be saved, to save them again.
+	MarkDirty(ctx context.Context, usages []*entity.Usage) error
+}
+
+// redisUsageCache is an implementation of IUsageCache that counts usage
+// in Redis hashes shared by every instance of the service.
+type redisUsageCache struct {
+	// logger is used for logging.
+	logger *slog.Logger
+
+	// client is a Redis client.
+	client *redis.Client
+}
+
+func NewUsageCache(logger *slog.Logger, client *redis.Client) IUsageCache {
+	return &redisUsageCache{logger: logger, client: client}
+}
+
+// Increment adds one use of a metric by an account to the counts of the
+// periods, and marks them as changed.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account.
+// - metric: entity.USAGE_LINKS or entity.USAGE_REDIRECTS.
+// - periods: the day and month of the use.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisUsageCache) Increment(ctx context.Context, account, metric string, periods []string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, period := range periods {
+			key := usageKey(account, period)
+			pipe.HIncrBy(ctx, key, metric, 1)
+			pipe.Expire(ctx, key, USAGE_KEY_TTL)
+			pipe.SAdd(ctx, USAGE_DIRTY_KEY, key)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Debug("Failed to count usage", slog.String("account", account), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the usage of an account during the periods.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account.
+// - periods: the periods.
+//
+// Returns:
+// - []*entity.Usage: the usage of each period, in order, with zero counts if nothing was used.
+// - error: an error if the operation failed.
+func (c *redisUsageCache) Get(ctx context.Context, account string, periods []string) ([]*entity.Usage, error) {
+	keys := make([]string, len(periods))
+	for i, period := range periods {
+		keys[i] = usageKey(account, period)
+	}
+
+	return c.read(ctx, keys)
+}
+
+// Unseeded returns the periods whose counts were not raised to the counts
+// saved in MongoDB yet.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account.
+// - periods: the periods.
+//
+// Returns:
+// - []string: the periods to seed, in order, empty when every period is seeded.
+// - error: an error if the operation failed.
+func (c *redisUsageCache) Unseeded(ctx context.Context, account string, periods []string) ([]string, error) {
+	pipe := c.client.Pipeline()
+	commands := make([]*redis.BoolCmd, len(periods))
+	for i, period := range periods {
+		commands[i] = pipe.HExists(ctx, usageKey(account, period), USAGE_SEEDED_FIELD)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	unseeded := []string{}
+	for i, period := range periods {
+		if !commands[i].Val() {
+			unseeded = append(unseeded, period)
+		}
+	}
+
+	return unseeded, nil
+}
+
+// Seed raises the counts of usages to their counts saved in MongoDB,
+// keeping the live counts when they are higher, and marks them as seeded.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - usages: the saved usages, with zero counts for periods never saved.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisUsageCache) Seed(ctx context.Context, usages []*entity.Usage) error {
+	ttl := int64(USAGE_KEY_TTL / time.Second)
+	for _, usage := range usages {
+		key := usageKey(usage.Account, usage.Period)
+		if err := seedUsage.Run(ctx, c.client, []string{key}, usage.Links, usage.Redirects, ttl).Err(); err != nil {
+			c.logger.Debug("Failed to seed usage", slog.String("account", usage.Account), slog.String("err", err.Error()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PopDirty returns and forgets up to count usages changed since they were
+// last saved.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - count: the maximum number of usages.
+//
+// Returns:
+// - []*entity.Usage: the usages, empty when every usage is saved.
+// - error: an error if the operation failed.
+func (c *redisUsageCache) PopDirty(ctx context.Context, count int64) ([]*entity.Usage, error) {
+	keys, err := c.client.SPopN(ctx, USAGE_DIRTY_KEY, count).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.read(ctx, keys)
+}
+
+// MarkDirty remembers usages that could not be saved, to save them again.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - usages: the usages.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (c *redisUsageCache) MarkDirty(ctx context.Context, usages []*entity.Usage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	keys := make([]interface{}, len(usages))
+	for i, usage := range usages {
+		keys[i] = usageKey(usage.Account, usage.Period)
+	}
+
+	return c.client.SAdd(ctx, USAGE_DIRTY_KEY, keys...).Err()
+}
+
+// read returns the usages counted at the given keys.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - keys: the usage keys.
+//
+// Returns:
+// - []*entity.Usage: the usage of each key, in order, with zero counts for missing keys.
+// - error: an error if the operation failed.
+func (c *redisUsageCache) read(ctx context.Context, keys []string) ([]*entity.Usage, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pipe := c.client.Pipeline()
+	commands := make([]*redis.MapStringStringCmd, len(keys))
+	for i, key := range keys {
+		commands[i] = pipe.HGetAll(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	usages := make([]*entity.Usage, 0, len(keys))
+	for i, key := range keys {
+		period, account, ok := strings.Cut(strings.TrimPrefix(key, USAGE_KEY_PREFIX), ":")
+		if !ok {
+			continue
+		}
+
+		usage := entity.NewUsage(account, period)
+		counts := commands[i].Val()
+		usage.Links, _ = strconv.ParseInt(counts[entity.USAGE_LINKS], 10, 64)
+		usage.Redirects, _ = strconv.ParseInt(counts[entity.USAGE_REDIRECTS], 10, 64)
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// usageKey returns the key of the usage of an account during a period.
+func usageKey(account, period string) string {
+	return USAGE_KEY_PREFIX + period + ":" + account
+}
@@ -0,0 +1,141 @@
+package config
+
+import "time"
+
+const (
+	AUTH_ENABLED = "auth_enabled"
+
+	AUTH_JWT_JWKS             = "auth_jwt_jwks"
+	AUTH_JWT_REFRESH_INTERVAL = "auth_jwt_refresh_interval"
+	AUTH_JWT_ISSUER           = "auth_jwt_issuer"
+	AUTH_JWT_AUDIENCE         = "auth_jwt_audience"
+	AUTH_JWT_ALGORITHMS       = "auth_jwt_algorithms"
+	AUTH_JWT_LEEWAY           = "auth_jwt_leeway"
+	AUTH_JWT_SUBJECT_CLAIM    = "auth_jwt_subject_claim"
+	AUTH_JWT_SCOPES_CLAIM     = "auth_jwt_scopes_claim"
+	AUTH_JWT_DEFAULT_SCOPES   = "auth_jwt_default_scopes"
+)
+
+type IAuthConfig interface {
+	// GetEnabled reports whether API requests must be authenticated.
+	GetEnabled() bool
+
+	// GetJWKS returns the path or URL of the JWKS bearer JWTs are verified with, or empty if JWTs are refused.
+	GetJWKS() string
+
+	// GetJWKSRefreshInterval returns how long the keys of the JWKS are used before they are read again.
+	GetJWKSRefreshInterval() time.Duration
+
+	// GetJWTIssuer returns the required "iss" claim of JWTs.
+	GetJWTIssuer() string
+
+	// GetJWTAudience returns the required "aud" claim of JWTs.
+	GetJWTAudience() string
+
+	// GetJWTAlgorithms returns the accepted signing algorithms of JWTs.
+	GetJWTAlgorithms() []string
+
+	// GetJWTLeeway returns the clock skew tolerated when checking the validity of JWTs.
+	GetJWTLeeway() time.Duration
+
+	// GetJWTSubjectClaim returns the claim identifying the user of a JWT.
+	GetJWTSubjectClaim() string
+
+	// GetJWTScopesClaim returns the claim holding the scopes of a JWT.
+	GetJWTScopesClaim() string
+
+	// GetJWTDefaultScopes returns the scopes granted to every valid JWT.
+	GetJWTDefaultScopes() []string
+}
+
+type AuthConfig struct{}
+
+func NewAuthConfig() *AuthConfig {
+	return &AuthConfig{}
+}
+
+// GetEnabled reports whether API requests must be authenticated.
+//
+// Returns:
+// - bool: true if requests to /api/v1 need an API key with the required
+// scope, false to leave the API open, e.g. for local development.
+func (a *AuthConfig) GetEnabled() bool {
+	return mustBool(AUTH_ENABLED)
+}
+
+// GetJWKS returns the JWKS bearer JWTs are verified with.
+//
+// Returns:
+// - string: the path of a JWKS file or the http(s) URL of the JWKS of the
+// identity provider, or empty if JWTs are refused.
+func (a *AuthConfig) GetJWKS() string {
+	return optionalString(AUTH_JWT_JWKS)
+}
+
+// GetJWKSRefreshInterval returns how long the keys of the JWKS are used
+// before they are read again.
+//
+// Returns:
+// - time.Duration: the refresh interval.
+func (a *AuthConfig) GetJWKSRefreshInterval() time.Duration {
+	return mustDuration(AUTH_JWT_REFRESH_INTERVAL)
+}
+
+// GetJWTIssuer returns the required "iss" claim of JWTs.
+//
+// Returns:
+// - string: the issuer, e.g. "https://sso.example.com/realms/main".
+func (a *AuthConfig) GetJWTIssuer() string {
+	return optionalString(AUTH_JWT_ISSUER)
+}
+
+// GetJWTAudience returns the required "aud" claim of JWTs.
+//
+// Returns:
+// - string: the audience, the client ID of the service at the identity provider.
+func (a *AuthConfig) GetJWTAudience() string {
+	return optionalString(AUTH_JWT_AUDIENCE)
+}
+
+// GetJWTAlgorithms returns the accepted signing algorithms of JWTs.
+//
+// Returns:
+// - []string: the algorithms, e.g. "RS256" or "ES256".
+func (a *AuthConfig) GetJWTAlgorithms() []string {
+	return optionalStrings(AUTH_JWT_ALGORITHMS)
+}
+
+// GetJWTLeeway returns the clock skew tolerated when checking the validity
+// of JWTs.
+//
+// Returns:
+// - time.Duration: the leeway.
+func (a *AuthConfig) GetJWTLeeway() time.Duration {
+	return mustDuration(AUTH_JWT_LEEWAY)
+}
+
+// GetJWTSubjectClaim returns the claim identifying the user of a JWT.
+//
+// Returns:
+// - string: the name of the claim, e.g. "sub" or "email".
+func (a *AuthConfig) GetJWTSubjectClaim() string {
+	return mustString(AUTH_JWT_SUBJECT_CLAIM)
+}
+
+// GetJWTScopesClaim returns the claim holding the scopes of a JWT.
+//
+// Returns:
+// - string: the name of the claim, holding a space-separated string or a
+// list, e.g. "scope" or "roles".
+func (a *AuthConfig) GetJWTScopesClaim() string {
+	return mustString(AUTH_JWT_SCOPES_CLAIM)
+}
+
+// GetJWTDefaultScopes returns the scopes granted to every valid JWT, in
+// addition to those of its scopes claim.
+//
+// Returns:
+// - []string: the scopes.
+func (a *AuthConfig) GetJWTDefaultScopes() []string {
+	return optionalStrings(AUTH_JWT_DEFAULT_SCOPES)
+}
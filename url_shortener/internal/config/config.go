@@ -29,6 +29,36 @@ type Config struct {
 
 	// - MongoConfig: the configuration for the MongoDB database.
 	MongoConfig IMongoConfig `koanf:"mongo"`
+
+	// - PrivacyConfig: the configuration for visitor data handling.
+	PrivacyConfig IPrivacyConfig `koanf:"privacy"`
+
+	// - EventsConfig: the configuration for link and click events.
+	EventsConfig IEventsConfig `koanf:"events"`
+
+	// - WebhookConfig: the configuration for outgoing webhooks.
+	WebhookConfig IWebhookConfig `koanf:"webhook"`
+
+	// - GeoConfig: the configuration for geo targeting.
+	GeoConfig IGeoConfig `koanf:"geo"`
+
+	// - DeepLinkConfig: the configuration for mobile app deep links.
+	DeepLinkConfig IDeepLinkConfig `koanf:"deeplink"`
+
+	// - AuthConfig: the configuration for API authentication.
+	AuthConfig IAuthConfig `koanf:"auth"`
+
+	// - RateLimitConfig: the configuration for rate limiting.
+	RateLimitConfig IRateLimitConfig `koanf:"rate_limit"`
+
+	// - UsageConfig: the configuration for usage counting and quotas.
+	UsageConfig IUsageConfig `koanf:"usage"`
+
+	// - DestinationPolicyConfig: the configuration for blocked and allowed destinations.
+	DestinationPolicyConfig IDestinationPolicyConfig `koanf:"destination_policy"`
+
+	// - ThreatListConfig: the configuration for the threat lists destinations are checked against.
+	ThreatListConfig IThreatListConfig `koanf:"threat_list"`
 }
 
 // NewConfig returns a new instance of Config with the UrlConfig field initialized
@@ -38,11 +68,21 @@ type Config struct {
 // - *Config: a new instance of Config.
 func NewConfig() *Config {
 	return &Config{
-		URLConfig:    NewURLConfig(),
-		RedisConfig:  NewRedisConfig(),
-		ServerConfig: NewServerConfig(),
-		LoggerConfig: NewLoggerConfig(),
-		MongoConfig:  NewMongoConfig(),
+		URLConfig:               NewURLConfig(),
+		RedisConfig:             NewRedisConfig(),
+		ServerConfig:            NewServerConfig(),
+		LoggerConfig:            NewLoggerConfig(),
+		MongoConfig:             NewMongoConfig(),
+		PrivacyConfig:           NewPrivacyConfig(),
+		EventsConfig:            NewEventsConfig(),
+		WebhookConfig:           NewWebhookConfig(),
+		GeoConfig:               NewGeoConfig(),
+		DeepLinkConfig:          NewDeepLinkConfig(),
+		AuthConfig:              NewAuthConfig(),
+		RateLimitConfig:         NewRateLimitConfig(),
+		UsageConfig:             NewUsageConfig(),
+		DestinationPolicyConfig: NewDestinationPolicyConfig(),
+		ThreatListConfig:        NewThreatListConfig(),
 	}
 }
 
@@ -70,6 +110,18 @@ func mustString(field string) string {
 	return cfg.MustString(field)
 }
 
+// optionalString returns a string value for the given field from the global config,
+// or an empty string if the field is not set.
+//
+// Parameters:
+// - field: the field to retrieve the value for.
+//
+// Returns:
+// - string: the value of the field.
+func optionalString(field string) string {
+	return cfg.String(field)
+}
+
 // MustInt returns an int value for the given field from the global config.
 // It panics if the field is not found or if the value is not an int.
 //
@@ -110,3 +162,48 @@ func mustStringFromEnv(field string) string {
 func mustDuration(field string) time.Duration {
 	return cfg.MustDuration(field)
 }
+
+// mustBool returns a bool value for the given field from the global config.
+// It panics if the field is not found.
+//
+// Parameters:
+// - field: the field to retrieve the value for.
+//
+// Returns:
+// - bool: the value of the field.
+func mustBool(field string) bool {
+	if !cfg.Exists(field) {
+		panic(fmt.Sprintf("missing bool value for config: %s", field))
+	}
+
+	return cfg.Bool(field)
+}
+
+// optionalStrings returns a string list for the given field from the global config,
+// or nil if the field is not set.
+//
+// Parameters:
+// - field: the field to retrieve the value for.
+//
+// Returns:
+// - []string: the value of the field.
+func optionalStrings(field string) []string {
+	return cfg.Strings(field)
+}
+
+// optionalUnmarshal decodes the value of the given field from the global
+// config into out, which is left unchanged if the field is not set.
+// It panics if the value does not match the type of out.
+//
+// Parameters:
+// - field: the field to retrieve the value for.
+// - out: a pointer to the value to decode into, read with "koanf" tags.
+func optionalUnmarshal(field string, out interface{}) {
+	if !cfg.Exists(field) {
+		return
+	}
+
+	if err := cfg.Unmarshal(field, out); err != nil {
+		panic(fmt.Sprintf("invalid value for config %s: %s", field, err))
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import "time"
+
+const (
+	DEEPLINK_APPLE_APP_SITE_ASSOCIATION = "deeplink_apple_app_site_association"
+	DEEPLINK_ASSET_LINKS                = "deeplink_asset_links"
+	DEEPLINK_FALLBACK_DELAY             = "deeplink_fallback_delay"
+)
+
+type IDeepLinkConfig interface {
+	// GetAppleAppSiteAssociationPath returns the path of the apple-app-site-association file, or empty if none is served.
+	GetAppleAppSiteAssociationPath() string
+
+	// GetAssetLinksPath returns the path of the assetlinks.json file, or empty if none is served.
+	GetAssetLinksPath() string
+
+	// GetFallbackDelay returns how long the app is given to open before the fallback is loaded.
+	GetFallbackDelay() time.Duration
+}
+
+type DeepLinkConfig struct{}
+
+func NewDeepLinkConfig() *DeepLinkConfig {
+	return &DeepLinkConfig{}
+}
+
+// GetAppleAppSiteAssociationPath returns the path of the apple-app-site-association file.
+//
+// Returns:
+// - string: the path of the JSON file served at
+// /.well-known/apple-app-site-association, or empty if none is served.
+func (d *DeepLinkConfig) GetAppleAppSiteAssociationPath() string {
+	return optionalString(DEEPLINK_APPLE_APP_SITE_ASSOCIATION)
+}
+
+// GetAssetLinksPath returns the path of the assetlinks.json file.
+//
+// Returns:
+// - string: the path of the JSON file served at /.well-known/assetlinks.json,
+// or empty if none is served.
+func (d *DeepLinkConfig) GetAssetLinksPath() string {
+	return optionalString(DEEPLINK_ASSET_LINKS)
+}
+
+// GetFallbackDelay returns how long the app is given to open before the fallback is loaded.
+//
+// Returns:
+// - time.Duration: the delay before the store or web page is opened.
+func (d *DeepLinkConfig) GetFallbackDelay() time.Duration {
+	return mustDuration(DEEPLINK_FALLBACK_DELAY)
+}
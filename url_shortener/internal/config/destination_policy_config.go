@@ -0,0 +1,26 @@
+package config
+
+const (
+	DESTINATION_POLICY_PATH = "destination_policy_path"
+)
+
+type IDestinationPolicyConfig interface {
+	// GetPath returns the path of the destination policy file, or empty if every destination is allowed.
+	GetPath() string
+}
+
+type DestinationPolicyConfig struct{}
+
+func NewDestinationPolicyConfig() *DestinationPolicyConfig {
+	return &DestinationPolicyConfig{}
+}
+
+// GetPath returns the path of the destination policy file.
+//
+// Returns:
+// - string: the path of a YAML file of blocked domains, patterns and
+// schemes and of allowed domains, reloaded when it changes, or empty if
+// every destination is allowed.
+func (d *DestinationPolicyConfig) GetPath() string {
+	return optionalString(DESTINATION_POLICY_PATH)
+}
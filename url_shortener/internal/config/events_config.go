@@ -0,0 +1,172 @@
+package config
+
+import "time"
+
+const (
+	EVENTS_SINK                 = "events_sink"
+	EVENTS_REDIS_STREAM         = "events_redis_stream"
+	EVENTS_REDIS_STREAM_MAX_LEN = "events_redis_stream_max_len"
+	EVENTS_FILE_PATH            = "events_file_path"
+	EVENTS_HTTP_URL             = "events_http_url"
+	EVENTS_HTTP_TIMEOUT         = "events_http_timeout"
+	EVENTS_BATCH_SIZE           = "events_batch_size"
+	EVENTS_RELAY_INTERVAL       = "events_relay_interval"
+	EVENTS_LEASE                = "events_lease"
+	EVENTS_MAX_ATTEMPTS         = "events_max_attempts"
+	EVENTS_INITIAL_BACKOFF      = "events_initial_backoff"
+	EVENTS_MAX_BACKOFF          = "events_max_backoff"
+)
+
+const (
+	// EVENTS_SINK_NONE discards events.
+	EVENTS_SINK_NONE = "none"
+
+	// EVENTS_SINK_REDIS_STREAM appends events to a Redis stream.
+	EVENTS_SINK_REDIS_STREAM = "redis_stream"
+
+	// EVENTS_SINK_FILE appends events to an NDJSON file.
+	EVENTS_SINK_FILE = "file"
+
+	// EVENTS_SINK_HTTP posts batches of events to an HTTP endpoint.
+	EVENTS_SINK_HTTP = "http"
+)
+
+type IEventsConfig interface {
+	// GetSink returns the kind of sink events are delivered to.
+	GetSink() string
+
+	// GetRedisStream returns the name of the Redis stream events are appended to.
+	GetRedisStream() string
+
+	// GetRedisStreamMaxLen returns the approximate maximum length of the Redis stream.
+	GetRedisStreamMaxLen() int
+
+	// GetFilePath returns the path of the NDJSON file events are appended to.
+	GetFilePath() string
+
+	// GetHTTPURL returns the URL batches of events are posted to.
+	GetHTTPURL() string
+
+	// GetHTTPTimeout returns the timeout of a single batch post.
+	GetHTTPTimeout() time.Duration
+
+	// GetBatchSize returns the maximum number of events delivered at once.
+	GetBatchSize() int
+
+	// GetRelayInterval returns how often the outbox is checked for pending events.
+	GetRelayInterval() time.Duration
+
+	// GetLease returns how long a relay keeps the events it claimed from other relays.
+	GetLease() time.Duration
+
+	// GetMaxAttempts returns the number of attempts before an event is dead-lettered.
+	GetMaxAttempts() int
+
+	// GetInitialBackoff returns the delay before the first retry of an event.
+	GetInitialBackoff() time.Duration
+
+	// GetMaxBackoff returns the upper bound of the delay between retries of an event.
+	GetMaxBackoff() time.Duration
+}
+
+type EventsConfig struct{}
+
+func NewEventsConfig() *EventsConfig {
+	return &EventsConfig{}
+}
+
+// GetSink returns the kind of sink events are delivered to.
+//
+// Returns:
+// - string: one of "none", "redis_stream", "file" or "http".
+func (e *EventsConfig) GetSink() string {
+	return mustString(EVENTS_SINK)
+}
+
+// GetRedisStream returns the name of the Redis stream events are appended to.
+//
+// Returns:
+// - string: the name of the stream.
+func (e *EventsConfig) GetRedisStream() string {
+	return mustString(EVENTS_REDIS_STREAM)
+}
+
+// GetRedisStreamMaxLen returns the approximate maximum length of the Redis stream.
+//
+// Returns:
+// - int: the maximum length of the stream.
+func (e *EventsConfig) GetRedisStreamMaxLen() int {
+	return mustInt(EVENTS_REDIS_STREAM_MAX_LEN)
+}
+
+// GetFilePath returns the path of the NDJSON file events are appended to.
+//
+// Returns:
+// - string: the path of the file.
+func (e *EventsConfig) GetFilePath() string {
+	return mustString(EVENTS_FILE_PATH)
+}
+
+// GetHTTPURL returns the URL batches of events are posted to.
+//
+// Returns:
+// - string: the URL of the HTTP endpoint.
+func (e *EventsConfig) GetHTTPURL() string {
+	return mustString(EVENTS_HTTP_URL)
+}
+
+// GetHTTPTimeout returns the timeout of a single batch post.
+//
+// Returns:
+// - time.Duration: the timeout of a post.
+func (e *EventsConfig) GetHTTPTimeout() time.Duration {
+	return mustDuration(EVENTS_HTTP_TIMEOUT)
+}
+
+// GetBatchSize returns the maximum number of events delivered at once.
+//
+// Returns:
+// - int: the batch size.
+func (e *EventsConfig) GetBatchSize() int {
+	return mustInt(EVENTS_BATCH_SIZE)
+}
+
+// GetRelayInterval returns how often the outbox is checked for pending events.
+//
+// Returns:
+// - time.Duration: the relay interval.
+func (e *EventsConfig) GetRelayInterval() time.Duration {
+	return mustDuration(EVENTS_RELAY_INTERVAL)
+}
+
+// GetLease returns how long a relay keeps the events it claimed from other relays.
+//
+// Returns:
+// - time.Duration: the lease of claimed events.
+func (e *EventsConfig) GetLease() time.Duration {
+	return mustDuration(EVENTS_LEASE)
+}
+
+// GetMaxAttempts returns the number of attempts before an event is dead-lettered.
+//
+// Returns:
+// - int: the maximum number of attempts.
+func (e *EventsConfig) GetMaxAttempts() int {
+	return mustInt(EVENTS_MAX_ATTEMPTS)
+}
+
+// GetInitialBackoff returns the delay before the first retry of an event.
+//
+// Returns:
+// - time.Duration: the initial backoff.
+func (e *EventsConfig) GetInitialBackoff() time.Duration {
+	return mustDuration(EVENTS_INITIAL_BACKOFF)
+}
+
+// GetMaxBackoff returns the upper bound of the delay between retries of an event.
+//
+// Returns:
+// - time.Duration: the maximum backoff.
+func (e *EventsConfig) GetMaxBackoff() time.Duration {
+	return mustDuration(EVENTS_MAX_BACKOFF)
+}
@@ -0,0 +1,25 @@
+package config
+
+const (
+	GEO_DATABASE_PATH = "geo_database_path"
+)
+
+type IGeoConfig interface {
+	// GetDatabasePath returns the path of the GeoIP database, or empty if geo targeting is disabled.
+	GetDatabasePath() string
+}
+
+type GeoConfig struct{}
+
+func NewGeoConfig() *GeoConfig {
+	return &GeoConfig{}
+}
+
+// GetDatabasePath returns the path of the GeoIP database.
+//
+// Returns:
+// - string: the path of a GeoIP2 or GeoLite2 Country or City .mmdb file,
+// or empty if geo targeting is disabled.
+func (g *GeoConfig) GetDatabasePath() string {
+	return optionalString(GEO_DATABASE_PATH)
+}
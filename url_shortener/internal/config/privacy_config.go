@@ -0,0 +1,121 @@
+package config
+
+import "time"
+
+const (
+	IP_ANONYMIZATION_MODE = "privacy_ip_anonymization"
+	IPV4_PREFIX_LENGTH    = "privacy_ipv4_prefix_length"
+	IPV6_PREFIX_LENGTH    = "privacy_ipv6_prefix_length"
+	IP_HASH_SECRET        = "IP_HASH_SECRET"
+	IP_HASH_SALT_ROTATION = "privacy_ip_hash_salt_rotation"
+	RESPECT_DO_NOT_TRACK  = "privacy_respect_do_not_track"
+	CLICK_RETENTION       = "privacy_click_retention"
+	RETENTION_INTERVAL    = "privacy_retention_check_interval"
+)
+
+const (
+	// IP_ANONYMIZATION_NONE stores visitor IP addresses as received.
+	IP_ANONYMIZATION_NONE = "none"
+
+	// IP_ANONYMIZATION_TRUNCATE zeroes the host part of visitor IP addresses.
+	IP_ANONYMIZATION_TRUNCATE = "truncate"
+
+	// IP_ANONYMIZATION_HASH replaces visitor IP addresses with a keyed hash.
+	IP_ANONYMIZATION_HASH = "hash"
+)
+
+type IPrivacyConfig interface {
+	// GetIPAnonymizationMode returns how visitor IP addresses are anonymized.
+	GetIPAnonymizationMode() string
+
+	// GetIPv4PrefixLength returns the number of IPv4 bits kept on truncation.
+	GetIPv4PrefixLength() int
+
+	// GetIPv6PrefixLength returns the number of IPv6 bits kept on truncation.
+	GetIPv6PrefixLength() int
+
+	// GetIPHashSecret returns the secret key used to hash visitor IP addresses.
+	GetIPHashSecret() string
+
+	// GetIPHashSaltRotation returns how often the IP hashing salt rotates.
+	GetIPHashSaltRotation() time.Duration
+
+	// RespectDoNotTrack reports whether DNT and Sec-GPC headers are honored.
+	RespectDoNotTrack() bool
+
+	// GetClickRetention returns how long raw clicks are kept before aggregation.
+	GetClickRetention() time.Duration
+
+	// GetRetentionInterval returns how often the retention job runs.
+	GetRetentionInterval() time.Duration
+}
+
+type PrivacyConfig struct{}
+
+func NewPrivacyConfig() *PrivacyConfig {
+	return &PrivacyConfig{}
+}
+
+// GetIPAnonymizationMode returns how visitor IP addresses are anonymized.
+//
+// Returns:
+// - string: one of "none", "truncate" or "hash".
+func (p *PrivacyConfig) GetIPAnonymizationMode() string {
+	return mustString(IP_ANONYMIZATION_MODE)
+}
+
+// GetIPv4PrefixLength returns the number of IPv4 bits kept on truncation.
+//
+// Returns:
+// - int: the IPv4 prefix length.
+func (p *PrivacyConfig) GetIPv4PrefixLength() int {
+	return mustInt(IPV4_PREFIX_LENGTH)
+}
+
+// GetIPv6PrefixLength returns the number of IPv6 bits kept on truncation.
+//
+// Returns:
+// - int: the IPv6 prefix length.
+func (p *PrivacyConfig) GetIPv6PrefixLength() int {
+	return mustInt(IPV6_PREFIX_LENGTH)
+}
+
+// GetIPHashSecret returns the secret key used to hash visitor IP addresses.
+//
+// Returns:
+// - string: the secret key.
+func (p *PrivacyConfig) GetIPHashSecret() string {
+	return mustStringFromEnv(IP_HASH_SECRET)
+}
+
+// GetIPHashSaltRotation returns how often the IP hashing salt rotates.
+//
+// Returns:
+// - time.Duration: the salt rotation period.
+func (p *PrivacyConfig) GetIPHashSaltRotation() time.Duration {
+	return mustDuration(IP_HASH_SALT_ROTATION)
+}
+
+// RespectDoNotTrack reports whether DNT and Sec-GPC headers are honored.
+//
+// Returns:
+// - bool: true if visitor data is dropped for opted-out visitors.
+func (p *PrivacyConfig) RespectDoNotTrack() bool {
+	return mustBool(RESPECT_DO_NOT_TRACK)
+}
+
+// GetClickRetention returns how long raw clicks are kept before aggregation.
+//
+// Returns:
+// - time.Duration: the retention period of raw clicks.
+func (p *PrivacyConfig) GetClickRetention() time.Duration {
+	return mustDuration(CLICK_RETENTION)
+}
+
+// GetRetentionInterval returns how often the retention job runs.
+//
+// Returns:
+// - time.Duration: the interval between retention runs.
+func (p *PrivacyConfig) GetRetentionInterval() time.Duration {
+	return mustDuration(RETENTION_INTERVAL)
+}
@@ -0,0 +1,91 @@
+package config
+
+const (
+	RATE_LIMIT_ENABLED  = "rate_limit_enabled"
+	RATE_LIMIT_REDIRECT = "rate_limit_redirect"
+	RATE_LIMIT_SHORTEN  = "rate_limit_shorten"
+	RATE_LIMIT_ADMIN    = "rate_limit_admin"
+	RATE_LIMIT_API      = "rate_limit_api"
+	RATE_LIMIT_AUTH     = "rate_limit_auth"
+)
+
+type IRateLimitConfig interface {
+	// GetEnabled reports whether requests are rate limited.
+	GetEnabled() bool
+
+	// GetRedirectLimit returns the limit of the redirects of a client.
+	GetRedirectLimit() string
+
+	// GetShortenLimit returns the limit of the link creations and changes of a client.
+	GetShortenLimit() string
+
+	// GetAdminLimit returns the limit of the API key, workspace and webhook operations of a client.
+	GetAdminLimit() string
+
+	// GetAPILimit returns the limit of the other API requests of a client.
+	GetAPILimit() string
+
+	// GetAuthLimit returns the limit of the API requests of an IP address, counted before authentication.
+	GetAuthLimit() string
+}
+
+type RateLimitConfig struct{}
+
+func NewRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{}
+}
+
+// GetEnabled reports whether requests are rate limited.
+//
+// Returns:
+// - bool: true to limit requests, their counts being shared between the
+// instances of the service in Redis.
+func (r *RateLimitConfig) GetEnabled() bool {
+	return mustBool(RATE_LIMIT_ENABLED)
+}
+
+// GetRedirectLimit returns the limit of the redirects of a client,
+// identified by its IP address.
+//
+// Returns:
+// - string: the limit, "<count>/<period>", e.g. "50/1s".
+func (r *RateLimitConfig) GetRedirectLimit() string {
+	return mustString(RATE_LIMIT_REDIRECT)
+}
+
+// GetShortenLimit returns the limit of the link creations and changes of a
+// client, identified by its API key, its user or its IP address.
+//
+// Returns:
+// - string: the limit, "<count>/<period>", e.g. "30/1m".
+func (r *RateLimitConfig) GetShortenLimit() string {
+	return mustString(RATE_LIMIT_SHORTEN)
+}
+
+// GetAdminLimit returns the limit of the API key, workspace and webhook
+// operations of a client, identified by its API key, its user or its IP address.
+//
+// Returns:
+// - string: the limit, "<count>/<period>", e.g. "60/1m".
+func (r *RateLimitConfig) GetAdminLimit() string {
+	return mustString(RATE_LIMIT_ADMIN)
+}
+
+// GetAPILimit returns the limit of the other API requests of a client,
+// identified by its API key, its user or its IP address.
+//
+// Returns:
+// - string: the limit, "<count>/<period>", e.g. "600/1m".
+func (r *RateLimitConfig) GetAPILimit() string {
+	return mustString(RATE_LIMIT_API)
+}
+
+// GetAuthLimit returns the limit of the API requests of an IP address,
+// counted before authentication, so that requests failing authentication
+// are limited too.
+//
+// Returns:
+// - string: the limit, "<count>/<period>", e.g. "1200/1m".
+func (r *RateLimitConfig) GetAuthLimit() string {
+	return mustString(RATE_LIMIT_AUTH)
+}
@@ -5,7 +5,9 @@ const (
 	SERVER_BIND_IP = "server_bind_ip"
 	SERVER_SCHEME  = "server_scheme"
 
-	LIMIT_PER_SECOND = "rate_limit_per_second"
+	TRUSTED_PROXIES = "server_trusted_proxies"
+
+	BRAND_NAME = "server_brand_name"
 )
 
 type IServerConfig interface {
@@ -15,11 +17,14 @@ type IServerConfig interface {
 	// GetBindIP returns the bind IP address of the HTTP server.
 	GetBindIP() string
 
-	// GetLimitPerSecond returns the limit per second of the HTTP server.
-	GetLimitPerSecond() int
-
 	// GetScheme returns the scheme of the HTTP server.
 	GetScheme() string
+
+	// GetTrustedProxies returns the networks of the proxies allowed to set X-Forwarded-For.
+	GetTrustedProxies() []string
+
+	// GetBrandName returns the name shown on the HTML pages of the service.
+	GetBrandName() string
 }
 
 type ServerConfig struct{}
@@ -44,14 +49,6 @@ func (s *ServerConfig) GetBindIP() string {
 	return mustString(SERVER_BIND_IP)
 }
 
-// GetLimitPerSecond returns the limit per second of the HTTP server.
-//
-// Returns:
-// - int: the limit per second of the HTTP server.
-func (s *ServerConfig) GetLimitPerSecond() int {
-	return mustInt(LIMIT_PER_SECOND)
-}
-
 // GetScheme returns the scheme of the HTTP server.
 //
 // Returns:
@@ -61,3 +58,19 @@ func (s *ServerConfig) GetLimitPerSecond() int {
 func (s *ServerConfig) GetScheme() string {
 	return mustString(SERVER_SCHEME)
 }
+
+// GetTrustedProxies returns the networks of the proxies allowed to set X-Forwarded-For.
+//
+// Returns:
+// - []string: CIDR ranges or single addresses, none meaning the header is ignored.
+func (s *ServerConfig) GetTrustedProxies() []string {
+	return optionalStrings(TRUSTED_PROXIES)
+}
+
+// GetBrandName returns the name shown on the HTML pages of the service.
+//
+// Returns:
+// - string: the name of the service.
+func (s *ServerConfig) GetBrandName() string {
+	return mustString(BRAND_NAME)
+}
@@ -0,0 +1,55 @@
+package config
+
+import "time"
+
+const (
+	THREAT_LIST_UPDATE_PATH     = "threat_list_update_path"
+	THREAT_LIST_STATE_PATH      = "threat_list_state_path"
+	THREAT_LIST_RESCAN_INTERVAL = "threat_list_rescan_interval"
+)
+
+type IThreatListConfig interface {
+	// GetUpdatePath returns the path of the threat list update file, or empty if destinations are not checked.
+	GetUpdatePath() string
+
+	// GetStatePath returns the path where the applied threat lists are kept across restarts, or empty.
+	GetStatePath() string
+
+	// GetRescanInterval returns how often the update file is read again and the existing links checked.
+	GetRescanInterval() time.Duration
+}
+
+type ThreatListConfig struct{}
+
+func NewThreatListConfig() *ThreatListConfig {
+	return &ThreatListConfig{}
+}
+
+// GetUpdatePath returns the path of the threat list update file.
+//
+// Returns:
+// - string: the path of a JSON file in the Safe Browsing Update API
+// threatListUpdates:fetch response format, replaced by whatever fetches
+// the updates, or empty if destinations are not checked.
+func (t *ThreatListConfig) GetUpdatePath() string {
+	return optionalString(THREAT_LIST_UPDATE_PATH)
+}
+
+// GetStatePath returns the path where the applied threat lists are kept.
+//
+// Returns:
+// - string: the path of the JSON file the lists are saved to after every
+// update and loaded from at startup, so that partial updates keep
+// applying, or empty to start from the update file alone.
+func (t *ThreatListConfig) GetStatePath() string {
+	return optionalString(THREAT_LIST_STATE_PATH)
+}
+
+// GetRescanInterval returns how often the update file is read again and
+// the existing links checked against the lists.
+//
+// Returns:
+// - time.Duration: the interval, e.g. 1h.
+func (t *ThreatListConfig) GetRescanInterval() time.Duration {
+	return mustDuration(THREAT_LIST_RESCAN_INTERVAL)
+}
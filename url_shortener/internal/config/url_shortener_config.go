@@ -5,6 +5,20 @@ import "time"
 const (
 	LENGTH_SHORT_URL      = "length_short_url"
 	LIVE_CACHE_EXPIRATION = "live_cache_expiration"
+	MISSING_CACHE_TTL     = "missing_cache_ttl"
+
+	REDIRECT_STATUS_CODE       = "redirect_status_code"
+	PERMANENT_REDIRECT_MAX_AGE = "permanent_redirect_max_age"
+
+	PREFIX_MAX_DEPTH = "prefix_max_depth"
+
+	SPLIT_COOKIE_MAX_AGE = "split_cookie_max_age"
+
+	SCHEDULE_TIMEZONE        = "schedule_timezone"
+	SCHEDULE_PENDING_MESSAGE = "schedule_pending_message"
+
+	PREVIEW_TITLE_TIMEOUT   = "preview_title_timeout"
+	PREVIEW_TITLE_CACHE_TTL = "preview_title_cache_ttl"
 )
 
 type IURLConfig interface {
@@ -13,6 +27,33 @@ type IURLConfig interface {
 
 	// LiveCaheExpiration returns the expiration time of the live cache.
 	LiveCaheExpiration() time.Duration
+
+	// GetMissingCacheTTL returns how long short URLs known not to exist are cached.
+	GetMissingCacheTTL() time.Duration
+
+	// GetRedirectStatusCode returns the HTTP status used for links without their own.
+	GetRedirectStatusCode() int
+
+	// GetPermanentRedirectMaxAge returns how long browsers may cache permanent redirects.
+	GetPermanentRedirectMaxAge() time.Duration
+
+	// GetPrefixMaxDepth returns the maximum number of path segments of a code.
+	GetPrefixMaxDepth() int
+
+	// GetSplitCookieMaxAge returns how long visitors keep their sticky split destination.
+	GetSplitCookieMaxAge() time.Duration
+
+	// GetScheduleTimezone returns the timezone of schedules without their own.
+	GetScheduleTimezone() string
+
+	// GetSchedulePendingMessage returns the message shown for links that are not yet available.
+	GetSchedulePendingMessage() string
+
+	// GetPreviewTitleTimeout returns the time allowed to read the title of a previewed page.
+	GetPreviewTitleTimeout() time.Duration
+
+	// GetPreviewTitleCacheTTL returns how long the title of a previewed page is kept.
+	GetPreviewTitleCacheTTL() time.Duration
 }
 
 type URLConfig struct{}
@@ -43,3 +84,80 @@ func (u *URLConfig) LengthShortURL() int {
 func (u *URLConfig) LiveCaheExpiration() time.Duration {
 	return mustDuration(LIVE_CACHE_EXPIRATION)
 }
+
+// GetMissingCacheTTL returns how long short URLs known not to exist are cached.
+//
+// It is kept short, so that a link created on another instance, or through
+// a write that could not update the cache, is found soon after.
+//
+// Returns:
+// - time.Duration: the time a missing short URL is cached.
+func (u *URLConfig) GetMissingCacheTTL() time.Duration {
+	return mustDuration(MISSING_CACHE_TTL)
+}
+
+// GetRedirectStatusCode returns the HTTP status used for links without their own.
+//
+// Returns:
+// - int: one of 301, 302, 307 or 308.
+func (u *URLConfig) GetRedirectStatusCode() int {
+	return mustInt(REDIRECT_STATUS_CODE)
+}
+
+// GetPermanentRedirectMaxAge returns how long browsers may cache permanent redirects.
+//
+// Returns:
+// - time.Duration: the max-age of 301 and 308 redirects.
+func (u *URLConfig) GetPermanentRedirectMaxAge() time.Duration {
+	return mustDuration(PERMANENT_REDIRECT_MAX_AGE)
+}
+
+// GetPrefixMaxDepth returns the maximum number of path segments of a code.
+//
+// It bounds the lookups made to resolve a visited path to a prefix link.
+//
+// Returns:
+// - int: the maximum number of segments.
+func (u *URLConfig) GetPrefixMaxDepth() int {
+	return mustInt(PREFIX_MAX_DEPTH)
+}
+
+// GetSplitCookieMaxAge returns how long visitors keep their sticky split destination.
+//
+// Returns:
+// - time.Duration: the max-age of the split cookie.
+func (u *URLConfig) GetSplitCookieMaxAge() time.Duration {
+	return mustDuration(SPLIT_COOKIE_MAX_AGE)
+}
+
+// GetScheduleTimezone returns the timezone of schedules without their own.
+//
+// Returns:
+// - string: the IANA name of the timezone, e.g. "UTC".
+func (u *URLConfig) GetScheduleTimezone() string {
+	return mustString(SCHEDULE_TIMEZONE)
+}
+
+// GetSchedulePendingMessage returns the message shown for links that are not yet available.
+//
+// Returns:
+// - string: the message of the "not yet available" response.
+func (u *URLConfig) GetSchedulePendingMessage() string {
+	return mustString(SCHEDULE_PENDING_MESSAGE)
+}
+
+// GetPreviewTitleTimeout returns the time allowed to read the title of a previewed page.
+//
+// Returns:
+// - time.Duration: the timeout of the title request.
+func (u *URLConfig) GetPreviewTitleTimeout() time.Duration {
+	return mustDuration(PREVIEW_TITLE_TIMEOUT)
+}
+
+// GetPreviewTitleCacheTTL returns how long the title of a previewed page is kept.
+//
+// Returns:
+// - time.Duration: the time the title is cached, read again afterwards.
+func (u *URLConfig) GetPreviewTitleCacheTTL() time.Duration {
+	return mustDuration(PREVIEW_TITLE_CACHE_TTL)
+}
@@ -0,0 +1,102 @@
+package config
+
+import "time"
+
+const (
+	USAGE_ENABLED        = "usage_enabled"
+	USAGE_FLUSH_INTERVAL = "usage_flush_interval"
+	USAGE_DEFAULT_PLAN   = "usage_default_plan"
+	USAGE_PLANS          = "usage_plans"
+	USAGE_ACCOUNT_PLANS  = "usage_account_plans"
+)
+
+// UsagePlan limits what the accounts of a plan may use per UTC day and
+// month, zero meaning unlimited.
+type UsagePlan struct {
+	DailyLinks       int64 `koanf:"daily_links"`
+	MonthlyLinks     int64 `koanf:"monthly_links"`
+	DailyRedirects   int64 `koanf:"daily_redirects"`
+	MonthlyRedirects int64 `koanf:"monthly_redirects"`
+}
+
+// AccountPlan assigns a plan to an account.
+//
+// Fields:
+// - Account: the owner ID of the API key, user or workspace, e.g. "workspace:<id>".
+// - Plan: the name of the plan.
+type AccountPlan struct {
+	Account string `koanf:"account"`
+	Plan    string `koanf:"plan"`
+}
+
+type IUsageConfig interface {
+	// GetEnabled reports whether usage is counted and quotas enforced.
+	GetEnabled() bool
+
+	// GetFlushInterval returns how often the counts kept in Redis are saved to MongoDB.
+	GetFlushInterval() time.Duration
+
+	// GetDefaultPlan returns the plan of the accounts without a plan of their own.
+	GetDefaultPlan() string
+
+	// GetPlans returns the plans by name.
+	GetPlans() map[string]UsagePlan
+
+	// GetAccountPlans returns the plans of the accounts that have one of their own.
+	GetAccountPlans() []AccountPlan
+}
+
+type UsageConfig struct{}
+
+func NewUsageConfig() *UsageConfig {
+	return &UsageConfig{}
+}
+
+// GetEnabled reports whether usage is counted and quotas enforced.
+//
+// Returns:
+// - bool: true to count the links and redirects of every account.
+func (u *UsageConfig) GetEnabled() bool {
+	return mustBool(USAGE_ENABLED)
+}
+
+// GetFlushInterval returns how often the counts kept in Redis are saved
+// to MongoDB.
+//
+// Returns:
+// - time.Duration: the interval, e.g. 1m.
+func (u *UsageConfig) GetFlushInterval() time.Duration {
+	return mustDuration(USAGE_FLUSH_INTERVAL)
+}
+
+// GetDefaultPlan returns the plan of the accounts without a plan of their own.
+//
+// Returns:
+// - string: the name of the plan, one of the usage plans.
+func (u *UsageConfig) GetDefaultPlan() string {
+	return mustString(USAGE_DEFAULT_PLAN)
+}
+
+// GetPlans returns the plans by name.
+//
+// Returns:
+// - map[string]UsagePlan: the plans, empty if none is configured.
+func (u *UsageConfig) GetPlans() map[string]UsagePlan {
+	plans := map[string]UsagePlan{}
+	optionalUnmarshal(USAGE_PLANS, &plans)
+
+	return plans
+}
+
+// GetAccountPlans returns the plans of the accounts that have one of their
+// own. Accounts are listed rather than used as keys since they may contain
+// dots, e.g. the email of a user.
+//
+// Returns:
+// - []AccountPlan: the accounts and their plans, nil if none is configured.
+func (u *UsageConfig) GetAccountPlans() []AccountPlan {
+	var plans []AccountPlan
+	optionalUnmarshal(USAGE_ACCOUNT_PLANS, &plans)
+
+	return plans
+}
@@ -0,0 +1,101 @@
+package config
+
+import "time"
+
+const (
+	WEBHOOK_TIMEOUT           = "webhook_timeout"
+	WEBHOOK_MAX_ATTEMPTS      = "webhook_max_attempts"
+	WEBHOOK_INITIAL_BACKOFF   = "webhook_initial_backoff"
+	WEBHOOK_MAX_BACKOFF       = "webhook_max_backoff"
+	WEBHOOK_DISPATCH_INTERVAL = "webhook_dispatch_interval"
+	WEBHOOK_BATCH_SIZE        = "webhook_batch_size"
+	WEBHOOK_MONITOR_INTERVAL  = "webhook_monitor_interval"
+)
+
+type IWebhookConfig interface {
+	// GetTimeout returns the timeout of a single delivery attempt.
+	GetTimeout() time.Duration
+
+	// GetMaxAttempts returns the number of attempts before a delivery fails.
+	GetMaxAttempts() int
+
+	// GetInitialBackoff returns the delay before the first retry.
+	GetInitialBackoff() time.Duration
+
+	// GetMaxBackoff returns the upper bound of the delay between retries.
+	GetMaxBackoff() time.Duration
+
+	// GetDispatchInterval returns how often due deliveries are attempted.
+	GetDispatchInterval() time.Duration
+
+	// GetBatchSize returns the maximum number of deliveries attempted at once.
+	GetBatchSize() int
+
+	// GetMonitorInterval returns how often links are checked for expiration and destination health.
+	GetMonitorInterval() time.Duration
+}
+
+type WebhookConfig struct{}
+
+func NewWebhookConfig() *WebhookConfig {
+	return &WebhookConfig{}
+}
+
+// GetTimeout returns the timeout of a single delivery attempt.
+//
+// Returns:
+// - time.Duration: the timeout of an attempt.
+func (w *WebhookConfig) GetTimeout() time.Duration {
+	return mustDuration(WEBHOOK_TIMEOUT)
+}
+
+// GetMaxAttempts returns the number of attempts before a delivery fails.
+//
+// Returns:
+// - int: the maximum number of attempts.
+func (w *WebhookConfig) GetMaxAttempts() int {
+	return mustInt(WEBHOOK_MAX_ATTEMPTS)
+}
+
+// GetInitialBackoff returns the delay before the first retry.
+//
+// The delay doubles with every further retry.
+//
+// Returns:
+// - time.Duration: the initial backoff.
+func (w *WebhookConfig) GetInitialBackoff() time.Duration {
+	return mustDuration(WEBHOOK_INITIAL_BACKOFF)
+}
+
+// GetMaxBackoff returns the upper bound of the delay between retries.
+//
+// Returns:
+// - time.Duration: the maximum backoff.
+func (w *WebhookConfig) GetMaxBackoff() time.Duration {
+	return mustDuration(WEBHOOK_MAX_BACKOFF)
+}
+
+// GetDispatchInterval returns how often due deliveries are attempted.
+//
+// Returns:
+// - time.Duration: the dispatch interval.
+func (w *WebhookConfig) GetDispatchInterval() time.Duration {
+	return mustDuration(WEBHOOK_DISPATCH_INTERVAL)
+}
+
+// GetBatchSize returns the maximum number of deliveries attempted at once.
+//
+// Returns:
+// - int: the batch size.
+func (w *WebhookConfig) GetBatchSize() int {
+	return mustInt(WEBHOOK_BATCH_SIZE)
+}
+
+// GetMonitorInterval returns how often links are checked for expiration
+// and destination health.
+//
+// Returns:
+// - time.Duration: the monitor interval, e.g. 15m.
+func (w *WebhookConfig) GetMonitorInterval() time.Duration {
+	return mustDuration(WEBHOOK_MONITOR_INTERVAL)
+}
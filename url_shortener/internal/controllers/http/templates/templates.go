@@ -0,0 +1,35 @@
+package templates
+
+import (
+	"embed"
+	"html/template"
+	"time"
+)
+
+const (
+	PREVIEW  = "preview.html"
+	DEEPLINK = "deeplink.html"
+	ERROR    = "error.html"
+)
+
+//go:embed *.html
+var files embed.FS
+
+// New parses the embedded HTML templates.
+//
+// Pages are named after their file, e.g. "preview.html", and share the
+// "header" and "footer" templates of layout.html.
+//
+// Parameters:
+// - brand: the name of the service shown on every page.
+//
+// Returns:
+// - *template.Template: the parsed templates, to be set on the router.
+func New(brand string) *template.Template {
+	functions := template.FuncMap{
+		"brand": func() string { return brand },
+		"date":  func(t time.Time) string { return t.UTC().Format("January 2, 2006") },
+	}
+
+	return template.Must(template.New("").Funcs(functions).ParseFS(files, "*.html"))
+}
@@ -0,0 +1,68 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateAPIKeyParams struct {
+	Name        string   `json:"name"`
+	Owner       string   `json:"owner"`
+	WorkspaceID string   `json:"workspace_id"`
+	Scopes      []string `json:"scopes"`
+}
+
+type CreateAPIKeyResponse struct {
+	*entity.APIKey
+	Token string `json:"token"`
+}
+
+// createAPIKey is the HTTP handler for the "POST /api/v1/keys" endpoint.
+// The response contains the token of the key, which is not shown again.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) createAPIKey(c *gin.Context) {
+	var request CreateAPIKeyParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	key, token, err := h.service.APIKeys.Create(c.Request.Context(), request.Name, request.Owner, request.WorkspaceID, request.Scopes)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Token: token})
+}
+
+// listAPIKeys is the HTTP handler for the "GET /api/v1/keys" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listAPIKeys(c *gin.Context) {
+	keys, err := h.service.APIKeys.List(c.Request.Context(), "")
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// revokeAPIKey is the HTTP handler for the "DELETE /api/v1/keys/:id" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) revokeAPIKey(c *gin.Context) {
+	if err := h.service.APIKeys.Revoke(c.Request.Context(), "", c.Param(API_KEY_ID_PARAM)); err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
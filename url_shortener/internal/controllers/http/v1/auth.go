@@ -0,0 +1,292 @@
+package httpv1
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Principal is the authenticated client of a request.
+//
+// Fields:
+// - Kind: "api_key" or "user".
+// - Subject: the ID of the API key, or the user named by the subject claim of the JWT.
+// - UserID: the user the client acts for in workspaces, empty for API keys without owner.
+// - OwnerID: the owner of the links the client creates and may manage.
+// - WorkspaceID: the workspace the client acts in, empty outside of workspaces.
+// - Role: the role of the user in the workspace.
+// - Scopes: the scopes granted to the client, "admin" granting all of them.
+type Principal struct {
+	Kind        string
+	Subject     string
+	UserID      string
+	OwnerID     string
+	WorkspaceID string
+	Role        string
+	Scopes      []string
+}
+
+// Allows reports whether the principal is granted the given scope.
+func (p *Principal) Allows(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, entity.SCOPE_ADMIN)
+}
+
+// actor returns the principal as the actor of the operations it requests.
+func (p *Principal) actor() service.Actor {
+	return service.Actor{
+		UserID:      p.UserID,
+		OwnerID:     p.OwnerID,
+		WorkspaceID: p.WorkspaceID,
+		Scopes:      p.Scopes,
+		Admin:       p.Allows(entity.SCOPE_ADMIN),
+	}
+}
+
+// authenticate is the middleware identifying the client of an API request
+// by its API key, sent as "Authorization: Bearer <key>" or "X-API-Key", or
+// by a JWT of the identity provider sent as "Authorization: Bearer <jwt>".
+//
+// Requests without valid credentials are answered 401, unless
+// authentication is disabled in the config. Requests naming a workspace in
+// the X-Workspace-ID header act in it with the scopes of the client that
+// the role of the user allows, as do API keys of a workspace.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) authenticate(c *gin.Context) {
+	if !h.config.AuthConfig.GetEnabled() {
+		c.Next()
+		return
+	}
+
+	token := credentials(c)
+	if token == "" {
+		h.abortUnauthorized(c, ErrUnauthorized)
+		return
+	}
+
+	if !strings.HasPrefix(token, service.API_KEY_TOKEN_PREFIX) {
+		identity, err := h.service.Tokens.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			h.abortUnauthorized(c, err)
+			return
+		}
+
+		h.enter(c, &Principal{
+			Kind:    PRINCIPAL_USER,
+			Subject: identity.Subject,
+			UserID:  identity.Subject,
+			OwnerID: identity.Subject,
+			Scopes:  identity.Scopes,
+		})
+		return
+	}
+
+	key, err := h.service.APIKeys.Authenticate(c.Request.Context(), token)
+	if errors.Is(err, service.ErrInvalidAPIKey) {
+		h.abortUnauthorized(c, err)
+		return
+	}
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	principal := &Principal{
+		Kind:        PRINCIPAL_API_KEY,
+		Subject:     key.ID,
+		OwnerID:     key.OwnerID(),
+		WorkspaceID: key.WorkspaceID,
+		Scopes:      key.Scopes,
+	}
+	if key.WorkspaceID == "" {
+		principal.UserID = key.Owner
+	}
+
+	h.enter(c, principal)
+}
+
+// enter continues a request with its authenticated client, in the
+// workspace named by the request or by the API key.
+//
+// Clients that are not members of the workspace are answered 404. Within a
+// workspace, users and API keys keep the scopes of their token or key that
+// their role also allows, so that a token issued for some scopes never
+// gains others, while admins keep all of their scopes.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - principal: the client.
+func (h *Handler) enter(c *gin.Context, principal *Principal) {
+	workspaceID := c.GetHeader(WORKSPACE_HEADER)
+
+	if principal.WorkspaceID != "" {
+		// Keys of a workspace never act in another one.
+		if workspaceID != "" && workspaceID != principal.WorkspaceID {
+			abort(c, service.ErrWorkspaceNotFound)
+			return
+		}
+	} else if workspaceID != "" {
+		role, err := h.service.Workspaces.Role(c.Request.Context(), principal.actor(), workspaceID)
+		if err != nil {
+			abort(c, err)
+			return
+		}
+
+		principal.WorkspaceID = workspaceID
+		principal.OwnerID = entity.WorkspaceOwnerID(workspaceID)
+		principal.Role = role
+
+		if !principal.Allows(entity.SCOPE_ADMIN) {
+			scopes := []string{}
+			for _, scope := range principal.Scopes {
+				if entity.RoleAllows(role, scope) {
+					scopes = append(scopes, scope)
+				}
+			}
+			principal.Scopes = scopes
+		}
+	}
+
+	c.Set(PRINCIPAL_KEY, principal)
+	c.Next()
+}
+
+// requireScope returns the middleware refusing requests whose client is
+// not granted the scope.
+//
+// Parameters:
+// - scope: the scope required by the route.
+//
+// Returns:
+// - gin.HandlerFunc: the middleware.
+func (h *Handler) requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !h.config.AuthConfig.GetEnabled() {
+			c.Next()
+			return
+		}
+
+		principal, ok := principalOf(c)
+		if !ok {
+			h.abortUnauthorized(c, ErrUnauthorized)
+			return
+		}
+
+		if !principal.Allows(scope) {
+			abort(c, &RequestError{Err: ErrForbidden, Detail: "the " + scope + " scope is required"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// abortUnauthorized stops a request whose client could not be authenticated.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - err: why the client could not be authenticated.
+func (h *Handler) abortUnauthorized(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidToken) {
+		c.Header(WWW_AUTHENTICATE_HEADER, BEARER_SCHEME+` error="invalid_token"`)
+	} else {
+		c.Header(WWW_AUTHENTICATE_HEADER, BEARER_SCHEME)
+	}
+	abort(c, err)
+}
+
+// principalOf returns the authenticated client of a request.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - *Principal: the client.
+// - bool: false if the request is not authenticated.
+func principalOf(c *gin.Context) (*Principal, bool) {
+	value, ok := c.Get(PRINCIPAL_KEY)
+	if !ok {
+		return nil, false
+	}
+
+	principal, ok := value.(*Principal)
+	return principal, ok
+}
+
+// ownerOf returns the owner of the links created by a request.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - string: the owner of the client, empty when authentication is disabled.
+func ownerOf(c *gin.Context) string {
+	if principal, ok := principalOf(c); ok {
+		return principal.OwnerID
+	}
+
+	return ""
+}
+
+// actorOf returns the actor of the operations of a request.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - service.Actor: the actor, an admin when authentication is disabled.
+func actorOf(c *gin.Context) service.Actor {
+	if principal, ok := principalOf(c); ok {
+		return principal.actor()
+	}
+
+	return service.Actor{Admin: true}
+}
+
+// isAdmin reports whether a request may manage the links of every owner.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - bool: true for admins, and for every request when authentication is disabled.
+func isAdmin(c *gin.Context) bool {
+	principal, ok := principalOf(c)
+	return !ok || principal.Allows(entity.SCOPE_ADMIN)
+}
+
+// ownerFilter returns the owner whose resources a request may list.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - string: the owner of the client, or empty for admins, who see the resources of every owner.
+func ownerFilter(c *gin.Context) string {
+	if isAdmin(c) {
+		return ""
+	}
+
+	return ownerOf(c)
+}
+
+// credentials returns the API key sent with a request.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - string: the bearer token of the Authorization header, or the X-API-Key
+// header, empty if neither is sent.
+func credentials(c *gin.Context) string {
+	if scheme, token, ok := strings.Cut(c.GetHeader(AUTHORIZATION_HEADER), " "); ok && strings.EqualFold(scheme, BEARER_SCHEME) {
+		return strings.TrimSpace(token)
+	}
+
+	return strings.TrimSpace(c.GetHeader(API_KEY_HEADER))
+}
@@ -0,0 +1,37 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getCampaignStats is the HTTP handler for the "GET /api/v1/campaigns/stats" endpoint.
+// It returns the clicks of the links with campaign parameters, grouped by campaign.
+//
+// The query accepts "origin" to report the variants of a single origin,
+// and "from" and "to" (RFC 3339 or YYYY-MM-DD).
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) getCampaignStats(c *gin.Context) {
+	from, err := parseExportTime(c.Query(EXPORT_FROM_QUERY))
+	if err != nil {
+		abort(c, queryError(EXPORT_FROM_QUERY, err))
+		return
+	}
+
+	to, err := parseExportTime(c.Query(EXPORT_TO_QUERY))
+	if err != nil {
+		abort(c, queryError(EXPORT_TO_QUERY, err))
+		return
+	}
+
+	stats, err := h.service.Campaigns.Stats(c.Request.Context(), ownerFilter(c), c.Query(CAMPAIGN_ORIGIN_QUERY), from, to)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,67 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// newVisitor describes the client of a request.
+//
+// Parameters:
+// - c: the gin.Context of the request.
+//
+// Returns:
+// - entity.Visitor: the client.
+func (h *Handler) newVisitor(c *gin.Context) entity.Visitor {
+	return entity.Visitor{
+		IP:             h.visitorIP(c),
+		UserAgent:      c.Request.UserAgent(),
+		Referer:        c.Request.Referer(),
+		AcceptLanguage: c.GetHeader(ACCEPT_LANGUAGE_HEADER),
+		DoNotTrack:     c.GetHeader(DO_NOT_TRACK_HEADER) == OPT_OUT_VALUE || c.GetHeader(GPC_HEADER) == OPT_OUT_VALUE,
+	}
+}
+
+// recordClick queues the click of the given URL to be stored, and counted
+// towards the quota of its owner, in the background, so that analytics
+// and metering never delay the redirect.
+//
+// Parameters:
+// - url: the URL that was clicked.
+// - visitor: the client that clicked.
+func (h *Handler) recordClick(url entity.IURL, visitor entity.Visitor) {
+	h.clicks.Record(url, visitor)
+}
+
+// visitorIP returns the address of the client, read from X-Forwarded-For
+// only behind the configured trusted proxies.
+//
+// Parameters:
+// - c: the gin.Context of the request.
+//
+// Returns:
+// - string: the address of the client.
+func (h *Handler) visitorIP(c *gin.Context) string {
+	return h.clientIP.Resolve(c.Request.RemoteAddr, c.GetHeader(FORWARDED_FOR_HEADER))
+}
+
+// eraseClicks is the HTTP handler for the "/api/v1/links/:code/clicks" endpoint.
+// It deletes all raw and aggregated analytics of the given link.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) eraseClicks(c *gin.Context) {
+	short, ok := h.lookupShort(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Clicks.Erase(c.Request.Context(), actorOf(c), short); err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
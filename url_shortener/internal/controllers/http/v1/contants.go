@@ -1,5 +1,74 @@
 package httpv1
 
 const (
-	SHORTEN_URL_PARAM = "url"
+	SHORTEN_URL_PARAM = "path"
+	LINK_CODE_PARAM   = "code"
+	WEBHOOK_ID_PARAM  = "id"
+	DELIVERY_ID_PARAM = "delivery"
+	API_KEY_ID_PARAM  = "id"
+	WORKSPACE_PARAM   = "workspace"
+	MEMBER_PARAM      = "user"
+	INVITATION_PARAM  = "invitation"
+
+	DO_NOT_TRACK_HEADER = "DNT"
+	GPC_HEADER          = "Sec-GPC"
+	OPT_OUT_VALUE       = "1"
+
+	FORWARDED_FOR_HEADER   = "X-Forwarded-For"
+	ACCEPT_LANGUAGE_HEADER = "Accept-Language"
+	ACCEPT_HEADER          = "Accept"
+
+	XHTML_MIME = "application/xhtml+xml"
+
+	REQUEST_ID_HEADER    = "X-Request-ID"
+	REQUEST_ID_KEY       = "request_id"
+	PROBLEM_CONTENT_TYPE = "application/problem+json"
+	PROBLEM_TYPE         = "about:blank"
+
+	AUTHORIZATION_HEADER    = "Authorization"
+	API_KEY_HEADER          = "X-API-Key"
+	WWW_AUTHENTICATE_HEADER = "WWW-Authenticate"
+	BEARER_SCHEME           = "Bearer"
+	PRINCIPAL_KEY           = "principal"
+	PRINCIPAL_API_KEY       = "api_key"
+	PRINCIPAL_USER          = "user"
+	WORKSPACE_HEADER        = "X-Workspace-ID"
+
+	RATE_LIMIT_LIMIT_HEADER     = "RateLimit-Limit"
+	RATE_LIMIT_REMAINING_HEADER = "RateLimit-Remaining"
+	RATE_LIMIT_RESET_HEADER     = "RateLimit-Reset"
+	RATE_LIMIT_POLICY_HEADER    = "RateLimit-Policy"
+	RETRY_AFTER_HEADER          = "Retry-After"
+
+	RATE_LIMIT_REDIRECT_POLICY = "redirect"
+	RATE_LIMIT_SHORTEN_POLICY  = "shorten"
+	RATE_LIMIT_ADMIN_POLICY    = "admin"
+	RATE_LIMIT_API_POLICY      = "api"
+	RATE_LIMIT_AUTH_POLICY     = "auth"
+
+	EXPORT_FORMAT_QUERY     = "format"
+	EXPORT_FIELDS_QUERY     = "fields"
+	EXPORT_FROM_QUERY       = "from"
+	EXPORT_TO_QUERY         = "to"
+	EXPORT_AGGREGATED_QUERY = "aggregated"
+	EXPORT_DATE_LAYOUT      = "2006-01-02"
+
+	GZIP_ENCODING = "gzip"
+
+	CAMPAIGN_ORIGIN_QUERY = "origin"
+
+	USAGE_MONTH_QUERY   = "month"
+	USAGE_ACCOUNT_QUERY = "account"
+
+	SPLIT_COOKIE_PREFIX = "split_"
+	SPLIT_COOKIE_PATH   = "/s/"
+
+	SHORT_LINK_PATH = "/s"
+	PREVIEW_SUFFIX  = "+"
+	PREVIEW_PATH    = "/preview"
+	PREVIEW_CSP     = "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'"
+
+	APPLE_APP_SITE_ASSOCIATION_PATH = "/.well-known/apple-app-site-association"
+	ASSET_LINKS_PATH                = "/.well-known/assetlinks.json"
+	DEEPLINK_CSP                    = PREVIEW_CSP + "; script-src 'nonce-%s'"
 )
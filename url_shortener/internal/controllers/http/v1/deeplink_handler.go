@@ -0,0 +1,98 @@
+package httpv1
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/flew1x/url_shortener_ms/internal/controllers/http/templates"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// AppAssociations holds the files proving that the apps of deep links may
+// open links of the service.
+//
+// Fields:
+// - AppleAppSiteAssociation: the apple-app-site-association JSON, nil if none is served.
+// - AssetLinks: the assetlinks.json JSON, nil if none is served.
+type AppAssociations struct {
+	AppleAppSiteAssociation []byte
+	AssetLinks              []byte
+}
+
+// DeepLinkPage is the data of the page opening the app of a deep link.
+type DeepLinkPage struct {
+	AppURI        template.URL
+	Store         bool
+	FallbackURL   string
+	FallbackDelay int64
+	Nonce         string
+}
+
+// appleAppSiteAssociation is the HTTP handler for the
+// "/.well-known/apple-app-site-association" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) appleAppSiteAssociation(c *gin.Context) {
+	h.serveAssociation(c, h.associations.AppleAppSiteAssociation)
+}
+
+// assetLinks is the HTTP handler for the "/.well-known/assetlinks.json" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) assetLinks(c *gin.Context) {
+	h.serveAssociation(c, h.associations.AssetLinks)
+}
+
+// serveAssociation writes an app association file.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - content: the JSON file, nil if none is served.
+func (h *Handler) serveAssociation(c *gin.Context, content []byte) {
+	if content == nil {
+		abort(c, ErrNotFound)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", content)
+}
+
+// renderDeepLink writes the page opening the app of a deep link, which
+// loads the store page or the web destination if the app does not open.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - app: the app opened on the device of the visitor.
+// - destination: the web destination of the link.
+func (h *Handler) renderDeepLink(c *gin.Context, app entity.AppTarget, destination string) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		c.Redirect(http.StatusFound, destination)
+		return
+	}
+	encodedNonce := base64.StdEncoding.EncodeToString(nonce)
+
+	fallback := destination
+	if app.Store != "" {
+		fallback = app.Store
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Security-Policy", fmt.Sprintf(DEEPLINK_CSP, encodedNonce))
+	c.Header("X-Frame-Options", "DENY")
+
+	c.HTML(http.StatusOK, templates.DEEPLINK, DeepLinkPage{
+		// App URIs are checked for unsafe schemes when links are saved.
+		AppURI:        template.URL(app.URI),
+		Store:         app.Store != "",
+		FallbackURL:   fallback,
+		FallbackDelay: h.config.DeepLinkConfig.GetFallbackDelay().Milliseconds(),
+		Nonce:         encodedNonce,
+	})
+}
@@ -0,0 +1,121 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/flew1x/url_shortener_ms/internal/controllers/http/templates"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorPage is the data of the error page of a short link.
+type ErrorPage struct {
+	Title   string
+	Message string
+	Detail  string
+}
+
+// visitError is the answer to a visit of a short link that failed.
+type visitError struct {
+	status  int
+	title   string
+	message string
+}
+
+// visitErrors maps the errors of short link visits to their answer, any
+// other error being an internal error.
+var visitErrors = map[error]visitError{
+	service.ErrLinkNotFound: {http.StatusNotFound, "Link not found", "This short link does not exist. Check that it was copied completely."},
+	service.ErrLinkExpired:  {http.StatusGone, "Link expired", "This short link has expired and no longer leads anywhere."},
+	service.ErrLinkDisabled: {http.StatusUnavailableForLegalReasons, "Link disabled", "This short link has been disabled."},
+
+	service.ErrQuotaExceeded: {http.StatusTooManyRequests, "Link temporarily unavailable", "This short link has been visited too often. Please try again later."},
+}
+
+// internalVisitError answers visits failing for any other reason.
+var internalVisitError = visitError{http.StatusInternalServerError, "Something went wrong", "The link could not be opened. Please try again later."}
+
+// abortVisit answers a visit of a short link that cannot be redirected,
+// with a branded HTML page for browsers and a problem for other clients.
+//
+// Unknown links are expected, bots probe random codes, so only internal
+// errors are logged.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - err: ErrLinkNotFound, ErrLinkExpired, ErrLinkDisabled or a QuotaError, any other error being answered as an internal error.
+// - detail: additional text of the HTML page, may be empty.
+func (h *Handler) abortVisit(c *gin.Context, err error, detail string) {
+	if !wantsHTML(c) {
+		if detail != "" {
+			err = &RequestError{Err: err, Detail: detail}
+		}
+		abort(c, err)
+		return
+	}
+
+	answer, known := findVisitError(err)
+	if !known {
+		h.logger.Error("error visiting short link", slog.String("path", c.Request.URL.Path), slog.String("err", err.Error()))
+		answer, err = internalVisitError, ErrInternalError
+	}
+
+	setQuotaRetryAfter(c, err)
+	h.abortWithPage(c, answer.status, ErrorPage{Title: answer.title, Message: answer.message, Detail: detail})
+}
+
+// findVisitError returns the answer to an error or to an error it wraps.
+//
+// Parameters:
+// - err: the error.
+//
+// Returns:
+// - visitError: the answer.
+// - bool: false if the error has no answer.
+func findVisitError(err error) (visitError, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if answer, ok := visitErrors[err]; ok {
+			return answer, true
+		}
+	}
+
+	return visitError{}, false
+}
+
+// abortWithPage answers a request with the error page.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - status: the HTTP status of the answer.
+// - page: the content of the page.
+func (h *Handler) abortWithPage(c *gin.Context, status int, page ErrorPage) {
+	c.Header("Content-Security-Policy", PREVIEW_CSP)
+	c.HTML(status, templates.ERROR, page)
+	c.Abort()
+}
+
+// wantsHTML reports whether the client prefers HTML to JSON, as browsers
+// following a link do. The first of them listed in Accept wins, clients
+// accepting anything or sending no Accept header receive JSON.
+//
+// Parameters:
+// - c: the gin.Context of the request.
+//
+// Returns:
+// - bool: true if an HTML page should be sent.
+func wantsHTML(c *gin.Context) bool {
+	for _, accepted := range strings.Split(c.GetHeader(ACCEPT_HEADER), ",") {
+		mediaType, _, _ := strings.Cut(accepted, ";")
+		switch strings.TrimSpace(mediaType) {
+		case gin.MIMEHTML, XHTML_MIME:
+			return true
+		case gin.MIMEJSON, "*/*":
+			return false
+		}
+	}
+
+	return false
+}
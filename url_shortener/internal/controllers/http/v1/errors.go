@@ -8,4 +8,7 @@ var (
 	ErrInvalidRequest = errors.New("invalid request")
 	ErrRequiredUrl    = errors.New("url is required")
 	ErrNotFound       = errors.New("not found")
+	ErrUnauthorized   = errors.New("authentication required")
+	ErrForbidden      = errors.New("credentials lack the required scope")
+	ErrRateLimited    = errors.New("too many requests, retry later")
 )
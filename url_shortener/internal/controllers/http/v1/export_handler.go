@@ -0,0 +1,203 @@
+package httpv1
+
+import (
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/export"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// exportLinkClicks is the HTTP handler for the "/api/v1/links/:code/clicks/export" endpoint.
+// It streams the clicks of a single link.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) exportLinkClicks(c *gin.Context) {
+	short, ok := h.lookupShort(c)
+	if !ok {
+		return
+	}
+
+	h.exportClicks(c, short)
+}
+
+// exportAllClicks is the HTTP handler for the "/api/v1/clicks/export" endpoint.
+// It streams the clicks of every link of the client, or of every link for admins.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) exportAllClicks(c *gin.Context) {
+	h.exportClicks(c, "")
+}
+
+// exportClicks streams raw or aggregated clicks as CSV or NDJSON.
+//
+// The query accepts "format" (csv or ndjson), "fields" (comma-separated),
+// "from" and "to" (RFC 3339 or YYYY-MM-DD) and "aggregated". The response
+// is gzip-compressed when the Accept-Encoding of the client accepts gzip
+// with a weight above zero.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - short: the shortened URL, or empty for every link of the client.
+func (h *Handler) exportClicks(c *gin.Context, short string) {
+	opts, err := parseExportOptions(c)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+	opts.Short = short
+	opts.OwnerID = ownerFilter(c)
+
+	if err := opts.Validate(); err != nil {
+		abort(c, err)
+		return
+	}
+
+	body := &exportWriter{
+		c:      c,
+		format: opts.Format,
+		gzip:   utils.AcceptsEncoding(c.GetHeader("Accept-Encoding"), GZIP_ENCODING),
+	}
+	defer body.Close()
+
+	err = h.service.Clicks.Export(c.Request.Context(), actorOf(c), opts, body)
+	if err == nil {
+		// An empty export is still a file.
+		if !body.started {
+			body.start()
+		}
+		return
+	}
+
+	if !body.started {
+		abort(c, err)
+		return
+	}
+
+	// The status line has already been sent, so the client can only
+	// notice the failure through the truncated body.
+	h.logger.Error("Failed to export clicks", slog.String("short", short), slog.String("err", err.Error()))
+}
+
+// exportWriter is the body of an export response, whose headers are only
+// sent with the first row, so that errors found before can still be
+// answered with a problem.
+//
+// Fields:
+// - c: the gin.Context of the export.
+// - format: the export format.
+// - gzip: true to compress the body.
+// - started: true once the headers have been sent.
+// - body: the writer of the response body, compressed or not.
+// - closer: the compressor to complete, nil if the body is not compressed.
+type exportWriter struct {
+	c       *gin.Context
+	format  string
+	gzip    bool
+	started bool
+	body    io.Writer
+	closer  io.Closer
+}
+
+// Write sends the headers of the export before its first bytes.
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+
+	return w.body.Write(p)
+}
+
+// Close completes the compressed body, if any.
+func (w *exportWriter) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+
+	return w.closer.Close()
+}
+
+// start sends the headers and status of the export.
+func (w *exportWriter) start() {
+	w.started = true
+	w.body = w.c.Writer
+
+	w.c.Header("Content-Type", export.ContentType(w.format))
+	w.c.Header("Content-Disposition", "attachment; filename=\"clicks."+w.format+"\"")
+
+	if w.gzip {
+		w.c.Header("Content-Encoding", GZIP_ENCODING)
+		w.c.Header("Vary", "Accept-Encoding")
+
+		gzipWriter := gzip.NewWriter(w.c.Writer)
+		w.body = gzipWriter
+		w.closer = gzipWriter
+	}
+
+	w.c.Status(http.StatusOK)
+}
+
+// parseExportOptions reads the export options from the query string.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - service.ExportOptions: the parsed export options.
+// - error: ErrInvalidRequest naming the malformed query parameter.
+func parseExportOptions(c *gin.Context) (service.ExportOptions, error) {
+	opts := service.ExportOptions{
+		Format: c.DefaultQuery(EXPORT_FORMAT_QUERY, export.FORMAT_CSV),
+	}
+
+	if fields := c.Query(EXPORT_FIELDS_QUERY); fields != "" {
+		opts.Fields = strings.Split(fields, ",")
+	}
+
+	if aggregated := c.Query(EXPORT_AGGREGATED_QUERY); aggregated != "" {
+		value, err := strconv.ParseBool(aggregated)
+		if err != nil {
+			return opts, queryError(EXPORT_AGGREGATED_QUERY, err)
+		}
+		opts.Aggregated = value
+	}
+
+	var err error
+	if opts.From, err = parseExportTime(c.Query(EXPORT_FROM_QUERY)); err != nil {
+		return opts, queryError(EXPORT_FROM_QUERY, err)
+	}
+	if opts.To, err = parseExportTime(c.Query(EXPORT_TO_QUERY)); err != nil {
+		return opts, queryError(EXPORT_TO_QUERY, err)
+	}
+
+	return opts, nil
+}
+
+// parseExportTime parses an RFC 3339 timestamp or a YYYY-MM-DD date.
+//
+// Parameters:
+// - value: the value to parse, empty for no bound.
+//
+// Returns:
+// - time.Time: the parsed time, or zero for an empty value.
+// - error: an error if the value is malformed.
+func parseExportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	return time.Parse(EXPORT_DATE_LAYOUT, value)
+}
@@ -2,60 +2,141 @@ package httpv1
 
 import (
 	"log/slog"
-	"time"
 
-	"github.com/didip/tollbooth"
-	"github.com/didip/tollbooth/limiter"
-	"github.com/didip/tollbooth_gin"
 	"github.com/flew1x/url_shortener_ms/internal/cache"
 	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/controllers/http/templates"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
 	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/clientip"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	logger  *slog.Logger
-	service *service.Service
-	config  *config.Config
-	cache   *cache.Cache
+	logger       *slog.Logger
+	service      *service.Service
+	config       *config.Config
+	cache        *cache.Cache
+	clientIP     *clientip.Resolver
+	associations AppAssociations
+	rateLimits   RateLimits
+	clicks       *service.ClickRecorder
 }
 
-func NewHandler(logger *slog.Logger, service *service.Service, config *config.Config, cache *cache.Cache) *Handler {
+func NewHandler(
+	logger *slog.Logger,
+	service *service.Service,
+	config *config.Config,
+	cache *cache.Cache,
+	clientIP *clientip.Resolver,
+	associations AppAssociations,
+	rateLimits RateLimits,
+	clicks *service.ClickRecorder,
+) *Handler {
 	return &Handler{
-		logger:  logger,
-		service: service,
-		config:  config,
-		cache:   cache,
+		logger:       logger,
+		service:      service,
+		config:       config,
+		cache:        cache,
+		clientIP:     clientIP,
+		associations: associations,
+		rateLimits:   rateLimits,
+		clicks:       clicks,
 	}
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
+	// Codes may contain '/', which link endpoints receive escaped as %2F.
+	router.UseRawPath = true
+	router.SetHTMLTemplate(templates.New(h.config.ServerConfig.GetBrandName()))
 	router.Use(gin.Recovery())
+	router.Use(h.requestID)
 	router.Use(gin.Logger())
-	router.Use()
+	router.Use(h.problems)
+	router.NoRoute(func(c *gin.Context) { abort(c, ErrNotFound) })
 
-	floatRate := float64(h.config.ServerConfig.GetLimitPerSecond())
-	limiter := tollbooth.NewLimiter(floatRate, &limiter.ExpirableOptions{
-		DefaultExpirationTTL: time.Second,
-	})
+	limitRedirects := h.rateLimit(RATE_LIMIT_REDIRECT_POLICY, h.rateLimits.Redirect)
+	limitShorten := h.rateLimit(RATE_LIMIT_SHORTEN_POLICY, h.rateLimits.Shorten)
+	limitAdmin := h.rateLimit(RATE_LIMIT_ADMIN_POLICY, h.rateLimits.Admin)
+	limitAPI := h.rateLimit(RATE_LIMIT_API_POLICY, h.rateLimits.API)
+	limitAuth := h.rateLimitIP(RATE_LIMIT_AUTH_POLICY, h.rateLimits.Auth)
 
-	common := router.Group("/", tollbooth_gin.LimitHandler(limiter))
+	common := router.Group("/")
 	{
 		api := common.Group("/api")
 		{
 			api.GET("/healthcheck", h.healthcheck)
 
-			v1 := api.Group("/v1")
+			api.GET("/v1/healthcheck", h.healthcheck)
+
+			// Requests are limited by IP address before authentication, so
+			// that clients guessing credentials are limited too.
+			v1 := api.Group("/v1", limitAuth, h.authenticate)
 			{
-				v1.GET("/healthcheck", h.healthcheck)
-				v1.POST("/shorten", h.shortenURL)
+				readLinks := h.requireScope(entity.SCOPE_LINKS_READ)
+				writeLinks := h.requireScope(entity.SCOPE_LINKS_WRITE)
+				readStats := h.requireScope(entity.SCOPE_STATS_READ)
+
+				v1.POST("/shorten", limitShorten, writeLinks, h.shortenURL)
+
+				links := v1.Group("/links")
+				{
+					links.GET("/:code", limitAPI, readLinks, h.getLink)
+					links.PATCH("/:code", limitShorten, writeLinks, h.updateLink)
+					links.DELETE("/:code/clicks", limitShorten, writeLinks, h.eraseClicks)
+					links.GET("/:code/clicks/export", limitAPI, readStats, h.exportLinkClicks)
+					links.POST("/:code/variants", limitShorten, writeLinks, h.createVariant)
+					links.GET("/:code/stats", limitAPI, readStats, h.getLinkStats)
+				}
+
+				v1.GET("/clicks/export", limitAdmin, readStats, h.exportAllClicks)
+				v1.GET("/campaigns/stats", limitAPI, readStats, h.getCampaignStats)
+				v1.GET("/usage", limitAPI, readStats, h.getUsage)
+
+				webhooks := v1.Group("/webhooks", limitAdmin, h.requireScope(entity.SCOPE_WEBHOOKS))
+				{
+					webhooks.POST("", h.registerWebhook)
+					webhooks.GET("", h.listWebhooks)
+					webhooks.DELETE("/:id", h.deleteWebhook)
+					webhooks.GET("/:id/deliveries", h.listWebhookDeliveries)
+					webhooks.POST("/:id/deliveries/:delivery/replay", h.replayWebhookDelivery)
+				}
+
+				keys := v1.Group("/keys", limitAdmin, h.requireScope(entity.SCOPE_ADMIN))
+				{
+					keys.POST("", h.createAPIKey)
+					keys.GET("", h.listAPIKeys)
+					keys.DELETE("/:id", h.revokeAPIKey)
+				}
+
+				// Workspace operations are authorized by the role of the user in the workspace.
+				workspaces := v1.Group("/workspaces", limitAdmin)
+				{
+					workspaces.POST("", h.createWorkspace)
+					workspaces.GET("", h.listWorkspaces)
+					workspaces.GET("/:workspace", h.getWorkspace)
+					workspaces.GET("/:workspace/members", h.listMembers)
+					workspaces.PATCH("/:workspace/members/:user", h.updateMember)
+					workspaces.DELETE("/:workspace/members/:user", h.removeMember)
+					workspaces.POST("/:workspace/invitations", h.inviteMember)
+					workspaces.GET("/:workspace/invitations", h.listInvitations)
+					workspaces.DELETE("/:workspace/invitations/:invitation", h.revokeInvitation)
+					workspaces.POST("/:workspace/keys", h.createWorkspaceKey)
+					workspaces.GET("/:workspace/keys", h.listWorkspaceKeys)
+					workspaces.DELETE("/:workspace/keys/:id", h.revokeWorkspaceKey)
+				}
+
+				v1.POST("/invitations/accept", limitAdmin, h.acceptInvitation)
 			}
 
 		}
 
-		common.Any("s/:url", h.redirectToOriginalURL)
+		common.Any("s/*"+SHORTEN_URL_PARAM, limitRedirects, h.redirectToOriginalURL)
+
+		common.GET(APPLE_APP_SITE_ASSOCIATION_PATH, limitRedirects, h.appleAppSiteAssociation)
+		common.GET(ASSET_LINKS_PATH, limitRedirects, h.assetLinks)
 	}
 
 	return router
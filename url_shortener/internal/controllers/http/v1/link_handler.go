@@ -0,0 +1,239 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateLinkParams struct {
+	URL             *string                 `json:"url"`
+	RedirectCode    *int                    `json:"redirect_code"`
+	QueryPolicy     *string                 `json:"query_policy"`
+	QueryPrecedence *string                 `json:"query_precedence"`
+	QueryAllowlist  *[]string               `json:"query_allowlist"`
+	Prefix          *bool                   `json:"prefix"`
+	UTM             *entity.UTM             `json:"utm"`
+	Rules           *[]entity.TargetingRule `json:"rules"`
+	Languages       *[]entity.LanguageRule  `json:"languages"`
+	Split           *entity.Split           `json:"split"`
+	Schedule        *entity.Schedule        `json:"schedule"`
+	Interstitial    *bool                   `json:"interstitial"`
+	DeepLink        *entity.DeepLink        `json:"deep_link"`
+	Disabled        *bool                   `json:"disabled"`
+	DisabledReason  *string                 `json:"disabled_reason"`
+}
+
+type CreateVariantParams struct {
+	UTM entity.UTM `json:"utm"`
+}
+
+// getLink is the HTTP handler for the "GET /api/v1/links/:code" endpoint.
+// It returns the link with its settings.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) getLink(c *gin.Context) {
+	link, ok := h.lookupLink(c, entity.SCOPE_LINKS_READ)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// updateLink is the HTTP handler for the "PATCH /api/v1/links/:code" endpoint.
+// It changes the destination or settings of a link. Omitted fields are kept.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) updateLink(c *gin.Context) {
+	var request UpdateLinkParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	link, ok := h.lookupLink(c, entity.SCOPE_LINKS_WRITE)
+	if !ok {
+		return
+	}
+
+	updated := entity.CopyURL(link)
+	if request.URL != nil {
+		updated.Origin = *request.URL
+	}
+	if request.RedirectCode != nil {
+		updated.RedirectCode = *request.RedirectCode
+	}
+	if request.QueryPolicy != nil {
+		updated.QueryPolicy = *request.QueryPolicy
+	}
+	if request.QueryPrecedence != nil {
+		updated.QueryPrecedence = *request.QueryPrecedence
+	}
+	if request.QueryAllowlist != nil {
+		updated.QueryAllowlist = *request.QueryAllowlist
+	}
+	if request.Prefix != nil {
+		updated.Prefix = *request.Prefix
+	}
+	if request.UTM != nil {
+		// An empty object removes the campaign parameters.
+		updated.UTM = nil
+		if !request.UTM.IsZero() {
+			updated.UTM = request.UTM
+		}
+	}
+	if request.Rules != nil {
+		updated.Rules = *request.Rules
+	}
+	if request.Languages != nil {
+		updated.Languages = *request.Languages
+	}
+	if request.Split != nil {
+		// A split without destinations removes it.
+		updated.Split = nil
+		if len(request.Split.Destinations) > 0 {
+			updated.Split = request.Split
+		}
+	}
+	if request.Interstitial != nil {
+		updated.Interstitial = *request.Interstitial
+	}
+	if request.Disabled != nil {
+		updated.Disabled = *request.Disabled
+	}
+	if request.DisabledReason != nil {
+		updated.DisabledReason = *request.DisabledReason
+	}
+	if request.DeepLink != nil {
+		// An empty object removes the deep link.
+		updated.DeepLink = nil
+		if !request.DeepLink.IsZero() {
+			updated.DeepLink = request.DeepLink
+		}
+	}
+	if request.Schedule != nil {
+		// An empty object removes the schedule.
+		updated.Schedule = nil
+		if !request.Schedule.IsZero() {
+			updated.Schedule = request.Schedule
+		}
+	}
+
+	stored, err := h.service.UrlShortener.Update(c.Request.Context(), actorOf(c), updated)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stored)
+}
+
+// createVariant is the HTTP handler for the "POST /api/v1/links/:code/variants" endpoint.
+// It creates a new link to the same origin with the same settings, differing
+// only in its campaign parameters.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) createVariant(c *gin.Context) {
+	var request CreateVariantParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	if err := service.ValidateUTM(request.UTM); err != nil {
+		abort(c, err)
+		return
+	}
+
+	link, ok := h.lookupLink(c, entity.SCOPE_LINKS_WRITE)
+	if !ok {
+		return
+	}
+
+	options := service.LinkOptionsOf(link)
+	options.UTM = &request.UTM
+
+	shortURL, err := h.service.UrlShortener.Create(c.Request.Context(), actorOf(c), link.GetOrigin(), options)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GetShortenUrlResponse{ShortURL: shortURL})
+}
+
+// getLinkStats is the HTTP handler for the "GET /api/v1/links/:code/stats" endpoint.
+// It returns the number of clicks of a link, by split destination for split links.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) getLinkStats(c *gin.Context) {
+	short, ok := h.lookupShort(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.service.Clicks.Stats(c.Request.Context(), actorOf(c), short)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// lookupLink loads the link named by the code path parameter, if the
+// request may use the scope on it, and aborts the request otherwise.
+//
+// Links of other owners are answered as not found, so that their codes
+// cannot be probed.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - scope: the scope the route requires.
+//
+// Returns:
+// - entity.IURL: the link.
+// - bool: false if the request has been aborted.
+func (h *Handler) lookupLink(c *gin.Context, scope string) (entity.IURL, bool) {
+	short, ok := h.lookupShort(c)
+	if !ok {
+		return nil, false
+	}
+
+	link, err := h.service.UrlShortener.Get(c.Request.Context(), actorOf(c), short, scope)
+	if err != nil {
+		abort(c, err)
+		return nil, false
+	}
+
+	return link, true
+}
+
+// lookupShort returns the short URL named by the code path parameter and
+// aborts the request if there is none.
+//
+// Whether the request may use the link is decided by the services.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - string: the short URL.
+// - bool: false if the request has been aborted.
+func (h *Handler) lookupShort(c *gin.Context) (string, bool) {
+	code := c.Param(LINK_CODE_PARAM)
+	if code == "" {
+		abort(c, ErrRequiredUrl)
+		return "", false
+	}
+
+	shortURL := h.service.UrlShortener.BuildShortURL(code)
+	return shortURL.String(), true
+}
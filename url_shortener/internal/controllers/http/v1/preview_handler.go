@@ -0,0 +1,75 @@
+package httpv1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/controllers/http/templates"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewPage is the data of the preview page of a link.
+type PreviewPage struct {
+	Short       string
+	Destination string
+	Title       string
+	CreatedAt   time.Time
+	ContinueURL string
+	Varies      bool
+}
+
+// previewLink shows the preview page of the link at the given path
+// instead of redirecting to it.
+//
+// The page shows the origin in effect and continues to the short link, so
+// that the visit is redirected and counted as usual.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - path: the visited path without its preview suffix.
+func (h *Handler) previewLink(c *gin.Context, path string) {
+	link, rest, err := h.service.UrlShortener.Resolve(c.Request.Context(), path)
+	if err != nil {
+		h.abortVisit(c, err, disabledReason(link))
+		return
+	}
+
+	link, ok := h.scheduledURL(c, link)
+	if !ok {
+		return
+	}
+
+	destination, err := utils.AppendPath(link.GetOrigin(), rest)
+	if err != nil {
+		h.abortVisit(c, err, "")
+		return
+	}
+
+	varies := len(link.GetRules()) > 0 || len(link.GetLanguages()) > 0 || link.GetSplit() != nil
+	h.renderPreview(c, link, destination, SHORT_LINK_PATH+path, varies)
+}
+
+// renderPreview writes the preview page of a link.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - link: the previewed link.
+// - destination: the URL shown as the destination.
+// - continueURL: the URL of the continue button.
+// - varies: whether other visitors may be sent elsewhere.
+func (h *Handler) renderPreview(c *gin.Context, link entity.IURL, destination, continueURL string, varies bool) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Security-Policy", PREVIEW_CSP)
+	c.Header("X-Frame-Options", "DENY")
+
+	c.HTML(http.StatusOK, templates.PREVIEW, PreviewPage{
+		Short:       link.GetShort(),
+		Destination: destination,
+		Title:       h.service.Previews.Title(c.Request.Context(), destination),
+		CreatedAt:   link.GetCreatedAt(),
+		ContinueURL: continueURL,
+		Varies:      varies,
+	})
+}
@@ -0,0 +1,334 @@
+package httpv1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/export"
+	"github.com/flew1x/url_shortener_ms/pkg/safebrowsing"
+	"github.com/flew1x/url_shortener_ms/pkg/urlpolicy"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Problem is an RFC 7807 problem details object.
+//
+// Fields:
+// - Type: "about:blank", the problem being identified by its code.
+// - Title: the HTTP status text.
+// - Status: the HTTP status.
+// - Detail: what went wrong for this request.
+// - Instance: the path of the request.
+// - Code: the stable identifier of the problem, e.g. "link_not_found".
+// - RequestID: the identifier of the request, also sent as X-Request-ID.
+// - Errors: the request fields causing the problem.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a request field causing a problem.
+//
+// Fields:
+// - Field: the name of the field, as sent in the request.
+// - Code: the stable identifier of the problem of the field.
+// - Detail: what is wrong with the field.
+type FieldError struct {
+	Field  string `json:"field"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// RequestError adds details to an error answered as a problem.
+//
+// Fields:
+// - Err: the error, which decides the status and code of the problem.
+// - Detail: replaces the message of the error, if set.
+// - Fields: the request fields causing the error, if known.
+type RequestError struct {
+	Err    error
+	Detail string
+	Fields []FieldError
+}
+
+// Error implements error.
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// problemType describes the problem caused by an error.
+type problemType struct {
+	status int
+	code   string
+	field  string
+}
+
+// problemTypes maps the errors answered to clients to their problem, any
+// other error being an internal error.
+var problemTypes = map[error]problemType{
+	ErrInternalError:  {http.StatusInternalServerError, "internal_error", ""},
+	ErrNotValidURL:    {http.StatusBadRequest, "invalid_url", "url"},
+	ErrInvalidRequest: {http.StatusBadRequest, "invalid_request", ""},
+	ErrRequiredUrl:    {http.StatusBadRequest, "url_required", "url"},
+	ErrNotFound:       {http.StatusNotFound, "not_found", ""},
+	ErrUnauthorized:   {http.StatusUnauthorized, "unauthorized", ""},
+	ErrForbidden:      {http.StatusForbidden, "insufficient_scope", ""},
+	ErrRateLimited:    {http.StatusTooManyRequests, "rate_limited", ""},
+
+	service.ErrNotValidURL:           {http.StatusBadRequest, "invalid_url", "url"},
+	service.ErrUnknownExportField:    {http.StatusBadRequest, "unknown_export_field", "fields"},
+	service.ErrInvalidTimeRange:      {http.StatusBadRequest, "invalid_time_range", "to"},
+	service.ErrUnknownEventType:      {http.StatusBadRequest, "unknown_event_type", "events"},
+	service.ErrInvalidClickThreshold: {http.StatusBadRequest, "invalid_click_threshold", "click_threshold"},
+	service.ErrWebhookNotFound:       {http.StatusNotFound, "webhook_not_found", ""},
+	service.ErrDeliveryNotFound:      {http.StatusNotFound, "delivery_not_found", ""},
+	service.ErrWebhookRejected:       {http.StatusBadGateway, "webhook_rejected", ""},
+	service.ErrInvalidWebhookURL:     {http.StatusBadRequest, "invalid_webhook_url", "url"},
+	service.ErrInvalidRedirectCode:   {http.StatusBadRequest, "invalid_redirect_code", "redirect_code"},
+	service.ErrInvalidCode:           {http.StatusBadRequest, "invalid_code", "code"},
+	service.ErrCodeTaken:             {http.StatusConflict, "code_taken", "code"},
+	service.ErrLinkNotFound:          {http.StatusNotFound, "link_not_found", ""},
+	service.ErrInvalidUTM:            {http.StatusBadRequest, "invalid_utm", "utm"},
+	service.ErrInvalidTargetingRule:  {http.StatusBadRequest, "invalid_targeting_rule", "rules"},
+	service.ErrInvalidLanguageRule:   {http.StatusBadRequest, "invalid_language_rule", "languages"},
+	service.ErrInvalidSplit:          {http.StatusBadRequest, "invalid_split", "split"},
+	service.ErrInvalidSchedule:       {http.StatusBadRequest, "invalid_schedule", "schedule"},
+	service.ErrLinkNotYetActive:      {http.StatusNotFound, "link_not_yet_active", ""},
+	service.ErrLinkExpired:           {http.StatusGone, "link_expired", ""},
+	service.ErrLinkDisabled:          {http.StatusUnavailableForLegalReasons, "link_disabled", ""},
+	service.ErrInvalidDisabledReason: {http.StatusBadRequest, "invalid_disabled_reason", "disabled_reason"},
+	service.ErrLinkLocked:            {http.StatusForbidden, "link_locked", "disabled"},
+	service.ErrInvalidDeepLink:       {http.StatusBadRequest, "invalid_deep_link", "deep_link"},
+	service.ErrInvalidAPIKey:         {http.StatusUnauthorized, "invalid_api_key", ""},
+	service.ErrInvalidAPIKeyName:     {http.StatusBadRequest, "invalid_api_key_name", "name"},
+	service.ErrUnknownScope:          {http.StatusBadRequest, "unknown_scope", "scopes"},
+	service.ErrAPIKeyNotFound:        {http.StatusNotFound, "api_key_not_found", ""},
+	service.ErrInvalidOwner:          {http.StatusBadRequest, "invalid_owner", "owner"},
+	service.ErrInvalidToken:          {http.StatusUnauthorized, "invalid_token", ""},
+	service.ErrWorkspaceScope:        {http.StatusBadRequest, "invalid_workspace_scope", "scopes"},
+	service.ErrWorkspaceNotFound:     {http.StatusNotFound, "workspace_not_found", ""},
+	service.ErrInsufficientRole:      {http.StatusForbidden, "insufficient_role", ""},
+	service.ErrInsufficientScope:     {http.StatusForbidden, "insufficient_scope", ""},
+	service.ErrNotOwner:              {http.StatusNotFound, "not_found", ""},
+	service.ErrInvalidWorkspaceName:  {http.StatusBadRequest, "invalid_workspace_name", "name"},
+	service.ErrUserRequired:          {http.StatusForbidden, "user_required", ""},
+	service.ErrUnknownRole:           {http.StatusBadRequest, "unknown_role", "role"},
+	service.ErrInvalidInvitee:        {http.StatusBadRequest, "invalid_invitee", "invitee"},
+	service.ErrAlreadyMember:         {http.StatusConflict, "already_member", ""},
+	service.ErrMemberNotFound:        {http.StatusNotFound, "member_not_found", ""},
+	service.ErrLastOwner:             {http.StatusConflict, "last_owner", ""},
+	service.ErrInvitationNotFound:    {http.StatusNotFound, "invitation_not_found", ""},
+	service.ErrInvalidInvitation:     {http.StatusBadRequest, "invalid_invitation", "token"},
+	service.ErrQuotaExceeded:         {http.StatusTooManyRequests, "quota_exceeded", ""},
+
+	utils.ErrNotValidURL:         {http.StatusBadRequest, "invalid_url", "url"},
+	utils.ErrNotValidQueryPolicy: {http.StatusBadRequest, "invalid_query_policy", "query_policy"},
+
+	export.ErrUnknownFormat: {http.StatusBadRequest, "unknown_export_format", "format"},
+
+	urlpolicy.ErrBlocked:    {http.StatusBadRequest, "destination_blocked", ""},
+	urlpolicy.ErrNotAllowed: {http.StatusBadRequest, "destination_not_allowed", ""},
+
+	safebrowsing.ErrThreat: {http.StatusBadRequest, "destination_malicious", ""},
+}
+
+// validRequestID matches the request IDs accepted from clients.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// abort stops the request with an error, answered by the problems middleware.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - err: the error.
+func abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// requestID is the middleware identifying every request.
+//
+// The X-Request-ID of the client is kept when it is well-formed, so that
+// requests can be followed across services, and a new one is generated
+// otherwise. The ID is sent back in the X-Request-ID response header.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) requestID(c *gin.Context) {
+	id := c.GetHeader(REQUEST_ID_HEADER)
+	if !validRequestID.MatchString(id) {
+		id = utils.NewID()
+	}
+
+	c.Set(REQUEST_ID_KEY, id)
+	c.Header(REQUEST_ID_HEADER, id)
+
+	c.Next()
+}
+
+// problems is the middleware answering requests aborted with an error as
+// application/problem+json, unless a response has already been written.
+//
+// Errors without a problem are internal errors, logged and answered
+// without their message.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) problems(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	problem := h.newProblem(c, err)
+	setQuotaRetryAfter(c, err)
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		h.logger.Error("error encoding problem " + err.Error())
+		c.Status(problem.Status)
+		return
+	}
+
+	c.Data(problem.Status, PROBLEM_CONTENT_TYPE, body)
+}
+
+// newProblem describes an error as a problem.
+//
+// Parameters:
+// - c: the gin.Context of the failed request.
+// - err: the error.
+//
+// Returns:
+// - Problem: the problem answered to the client.
+func (h *Handler) newProblem(c *gin.Context, err error) Problem {
+	kind, known := findProblemType(err)
+	if !known {
+		h.logger.Error(
+			"error handling request",
+			slog.String("path", c.Request.URL.Path),
+			slog.String("request_id", c.GetString(REQUEST_ID_KEY)),
+			slog.String("err", err.Error()),
+		)
+		kind, err = problemTypes[ErrInternalError], ErrInternalError
+	}
+
+	problem := Problem{
+		Type:      PROBLEM_TYPE,
+		Title:     http.StatusText(kind.status),
+		Status:    kind.status,
+		Detail:    err.Error(),
+		Instance:  c.Request.URL.Path,
+		Code:      kind.code,
+		RequestID: c.GetString(REQUEST_ID_KEY),
+	}
+
+	var requestError *RequestError
+	if errors.As(err, &requestError) {
+		if requestError.Detail != "" {
+			problem.Detail = requestError.Detail
+		}
+		problem.Errors = requestError.Fields
+	}
+
+	if len(problem.Errors) == 0 && kind.field != "" {
+		problem.Errors = []FieldError{{Field: kind.field, Code: kind.code, Detail: problem.Detail}}
+	}
+
+	return problem
+}
+
+// findProblemType returns the problem of an error or of an error it wraps.
+//
+// Parameters:
+// - err: the error.
+//
+// Returns:
+// - problemType: the problem.
+// - bool: false if the error has no problem.
+func findProblemType(err error) (problemType, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if kind, ok := problemTypes[err]; ok {
+			return kind, true
+		}
+	}
+
+	return problemType{}, false
+}
+
+// bindError describes why a request body could not be read.
+//
+// Parameters:
+// - err: the error returned by binding the body.
+//
+// Returns:
+// - error: ErrInvalidRequest, with the field of the wrong type or the
+// position of the syntax error when known.
+func bindError(err error) error {
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+
+	switch {
+	case errors.As(err, &typeError):
+		return &RequestError{Err: ErrInvalidRequest, Fields: []FieldError{{
+			Field:  typeError.Field,
+			Code:   "invalid_type",
+			Detail: "must be " + jsonKind(typeError.Type.Kind().String()),
+		}}}
+	case errors.As(err, &syntaxError):
+		return &RequestError{Err: ErrInvalidRequest, Detail: "malformed JSON: " + syntaxError.Error()}
+	case errors.Is(err, io.EOF):
+		return &RequestError{Err: ErrInvalidRequest, Detail: "request body is empty"}
+	default:
+		return ErrInvalidRequest
+	}
+}
+
+// queryError describes a query parameter that could not be parsed.
+//
+// Parameters:
+// - name: the name of the query parameter.
+// - err: the parsing error.
+//
+// Returns:
+// - error: ErrInvalidRequest, with the query parameter as its field.
+func queryError(name string, err error) error {
+	return &RequestError{Err: ErrInvalidRequest, Fields: []FieldError{{
+		Field:  name,
+		Code:   "invalid_value",
+		Detail: err.Error(),
+	}}}
+}
+
+// jsonKind names a Go kind as the JSON type it is decoded from.
+func jsonKind(kind string) string {
+	switch kind {
+	case "string":
+		return "a string"
+	case "bool":
+		return "a boolean"
+	case "slice", "array":
+		return "an array"
+	case "struct", "map", "ptr":
+		return "an object"
+	default:
+		return "a number"
+	}
+}
@@ -0,0 +1,122 @@
+package httpv1
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimits are the limits of the requests of clients, by route group.
+//
+// Fields:
+// - Limiter: the limiter shared by the instances of the service, nil to disable rate limiting.
+// - Redirect: the limit of redirects, previews and app association files.
+// - Shorten: the limit of link creations and changes.
+// - Admin: the limit of API key, workspace and webhook operations.
+// - API: the limit of the other API requests.
+// - Auth: the limit of the API requests of an IP address, before authentication.
+//
+// A zero limit is not enforced.
+type RateLimits struct {
+	Limiter  *ratelimit.Limiter
+	Redirect ratelimit.Limit
+	Shorten  ratelimit.Limit
+	Admin    ratelimit.Limit
+	API      ratelimit.Limit
+	Auth     ratelimit.Limit
+}
+
+// rateLimit returns the middleware limiting the requests of each client.
+//
+// Authenticated clients are limited by API key or user, others by IP
+// address. Responses carry the RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset and RateLimit-Policy headers, and refused requests are
+// answered 429 with Retry-After. Requests are let through while Redis
+// cannot be reached, so that redirects keep working.
+//
+// Parameters:
+// - policy: the name of the route group, separating its counts from those of other groups.
+// - limit: the limit of the route group.
+//
+// Returns:
+// - gin.HandlerFunc: the middleware.
+func (h *Handler) rateLimit(policy string, limit ratelimit.Limit) gin.HandlerFunc {
+	return h.limitBy(policy, limit, h.rateLimitClient)
+}
+
+// rateLimitIP returns the middleware limiting the requests of each IP
+// address, authenticated or not, like rateLimit.
+//
+// Parameters:
+// - policy: the name of the route group, separating its counts from those of other groups.
+// - limit: the limit of the route group.
+//
+// Returns:
+// - gin.HandlerFunc: the middleware.
+func (h *Handler) rateLimitIP(policy string, limit ratelimit.Limit) gin.HandlerFunc {
+	return h.limitBy(policy, limit, func(c *gin.Context) string {
+		return "ip:" + h.visitorIP(c)
+	})
+}
+
+// limitBy returns the middleware limiting the requests of each client.
+//
+// Parameters:
+// - policy: the name of the route group, separating its counts from those of other groups.
+// - limit: the limit of the route group.
+// - client: returns the client counted for a request.
+//
+// Returns:
+// - gin.HandlerFunc: the middleware.
+func (h *Handler) limitBy(policy string, limit ratelimit.Limit, client func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.rateLimits.Limiter == nil || limit.Count == 0 {
+			c.Next()
+			return
+		}
+
+		result, err := h.rateLimits.Limiter.Allow(c.Request.Context(), policy+":"+client(c), limit)
+		if err != nil {
+			h.logger.Warn("Rate limiter unavailable, request let through", slog.String("policy", policy), slog.String("err", err.Error()))
+			c.Next()
+			return
+		}
+
+		c.Header(RATE_LIMIT_LIMIT_HEADER, strconv.Itoa(limit.Count))
+		c.Header(RATE_LIMIT_REMAINING_HEADER, strconv.Itoa(result.Remaining))
+		c.Header(RATE_LIMIT_RESET_HEADER, strconv.Itoa(seconds(result.ResetAfter)))
+		c.Header(RATE_LIMIT_POLICY_HEADER, strconv.Itoa(limit.Count)+";w="+strconv.Itoa(seconds(limit.Period)))
+
+		if !result.Allowed {
+			c.Header(RETRY_AFTER_HEADER, strconv.Itoa(seconds(result.RetryAfter)))
+			abort(c, ErrRateLimited)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitClient returns the client counted by the rate limiter.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+//
+// Returns:
+// - string: "api_key:<id>" or "user:<subject>" for authenticated clients, "ip:<address>" otherwise.
+func (h *Handler) rateLimitClient(c *gin.Context) string {
+	if principal, ok := principalOf(c); ok {
+		return principal.Kind + ":" + principal.Subject
+	}
+
+	return "ip:" + h.visitorIP(c)
+}
+
+// seconds rounds a duration up to whole seconds, as rate limit headers are.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -1,14 +1,35 @@
 package httpv1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/flew1x/url_shortener_ms/internal/entity"
 	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type GetShortenURLParams struct {
-	URL string `json:"url"`
+	URL             string                 `json:"url"`
+	Code            string                 `json:"code"`
+	Prefix          bool                   `json:"prefix"`
+	RedirectCode    int                    `json:"redirect_code"`
+	QueryPolicy     string                 `json:"query_policy"`
+	QueryPrecedence string                 `json:"query_precedence"`
+	QueryAllowlist  []string               `json:"query_allowlist"`
+	UTM             *entity.UTM            `json:"utm"`
+	Rules           []entity.TargetingRule `json:"rules"`
+	Languages       []entity.LanguageRule  `json:"languages"`
+	Split           *entity.Split          `json:"split"`
+	Schedule        *entity.Schedule       `json:"schedule"`
+	Interstitial    bool                   `json:"interstitial"`
+	DeepLink        *entity.DeepLink       `json:"deep_link"`
 }
 
 type GetShortenUrlResponse struct {
@@ -24,47 +45,281 @@ type GetShortenUrlResponse struct {
 func (h *Handler) shortenURL(c *gin.Context) {
 	var request GetShortenURLParams
 	if err := c.ShouldBindJSON(&request); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, ErrInvalidRequest)
+		abort(c, bindError(err))
 		return
 	}
 
 	if request.URL == "" {
-		c.AbortWithStatusJSON(http.StatusBadRequest, ErrRequiredUrl)
+		abort(c, ErrRequiredUrl)
 		return
 	}
 
-	shortURL, err := h.service.UrlShortener.Create(c.Request.Context(), request.URL)
+	options := service.LinkOptions{
+		Code:            request.Code,
+		Prefix:          request.Prefix,
+		RedirectCode:    request.RedirectCode,
+		QueryPolicy:     request.QueryPolicy,
+		QueryPrecedence: request.QueryPrecedence,
+		QueryAllowlist:  request.QueryAllowlist,
+		UTM:             request.UTM,
+		Rules:           request.Rules,
+		Languages:       request.Languages,
+		Split:           request.Split,
+		Schedule:        request.Schedule,
+		Interstitial:    request.Interstitial,
+		DeepLink:        request.DeepLink,
+	}
+
+	shortURL, err := h.service.UrlShortener.Create(c.Request.Context(), actorOf(c), request.URL, options)
 	if err != nil {
-		if err == service.ErrNotValidURL {
-			c.AbortWithStatusJSON(http.StatusBadRequest, service.ErrNotValidURL)
-			return
-		}
-		_ = c.AbortWithError(http.StatusInternalServerError, ErrInternalError)
+		abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, GetShortenUrlResponse{ShortURL: shortURL})
 }
 
-// redirectToOriginalURL is the HTTP handler for the "/{shorten_url}" endpoint.
+// redirectToOriginalURL is the HTTP handler for the "/s/*path" endpoint.
 // It redirects the client to the original URL associated with the given short URL.
+// The destination is chosen by the targeting rules of the link from the
+// User-Agent, the location and the languages of the client, among the
+// destinations scheduled at the time of the visit. For prefix links, the rest of the path is appended to it.
+// Paths ending with "+" or "/preview" show the preview page of the link
+// instead, as do all visits of links with a mandatory interstitial. Mobile
+// visitors of deep links receive a page opening the app.
 //
 // Parameters:
 // - c: the gin.Context for the operation.
 func (h *Handler) redirectToOriginalURL(c *gin.Context) {
-	shortURL := c.Param(SHORTEN_URL_PARAM)
-	if shortURL == "" {
-		c.AbortWithStatusJSON(http.StatusBadRequest, ErrRequiredUrl)
+	path := c.Param(SHORTEN_URL_PARAM)
+	if path == "" || path == "/" {
+		h.abortVisit(c, service.ErrLinkNotFound, "")
 		return
 	}
 
-	generatedURL := h.service.UrlShortener.BuildShortURL(shortURL)
+	if code, found := strings.CutSuffix(path, PREVIEW_SUFFIX); found {
+		h.previewLink(c, code)
+		return
+	}
 
-	originalURL, err := h.service.UrlShortener.GetByShort(c.Request.Context(), generatedURL.String())
+	originalURL, rest, err := h.service.UrlShortener.Resolve(c.Request.Context(), path)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrInternalError)
+		// Prefix links receive "/preview" as part of their path.
+		if code, found := strings.CutSuffix(path, PREVIEW_PATH); found && err == service.ErrLinkNotFound {
+			h.previewLink(c, code)
+			return
+		}
+		h.abortVisit(c, err, disabledReason(originalURL))
+		return
+	}
+
+	originalURL, ok := h.scheduledURL(c, originalURL)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Usage.Check(c.Request.Context(), originalURL.GetOwnerID(), entity.USAGE_REDIRECTS); err != nil {
+		h.abortVisit(c, err, "")
+		return
+	}
+
+	if len(originalURL.GetRules()) > 0 || originalURL.GetDeepLink() != nil {
+		c.Writer.Header().Add("Vary", "User-Agent")
+	}
+	if len(originalURL.GetLanguages()) > 0 {
+		c.Writer.Header().Add("Vary", ACCEPT_LANGUAGE_HEADER)
+	}
+
+	visitor := h.newVisitor(c)
+	split := originalURL.GetSplit()
+	if split != nil && split.Sticky {
+		visitor.Variant, _ = c.Cookie(splitCookieName(originalURL))
+	}
+
+	target := h.service.Targeting.SelectDestination(originalURL, visitor)
+
+	if split != nil && split.Sticky && target.Variant != "" && target.Variant != visitor.Variant {
+		maxAge := int(h.config.URLConfig.GetSplitCookieMaxAge().Seconds())
+		secure := h.config.ServerConfig.GetScheme() == "https"
+		c.SetCookie(splitCookieName(originalURL), target.Variant, maxAge, SPLIT_COOKIE_PATH, "", secure, true)
+	}
+	visitor.Variant = target.Variant
+
+	destination, err := utils.AppendPath(target.URL, rest)
+	if err != nil {
+		h.abortVisit(c, err, "")
+		return
+	}
+
+	destination, err = utils.PassQuery(
+		destination,
+		c.Request.URL.Query(),
+		originalURL.GetQueryPolicy(),
+		originalURL.GetQueryPrecedence(),
+		originalURL.GetQueryAllowlist(),
+	)
+	if err != nil {
+		h.abortVisit(c, err, "")
+		return
+	}
+
+	if utm := originalURL.GetUTM(); utm != nil {
+		if destination, err = utils.SetQuery(destination, utm.Values()); err != nil {
+			h.abortVisit(c, err, "")
+			return
+		}
+	}
+
+	h.recordClick(originalURL, visitor)
+
+	if originalURL.IsInterstitial() {
+		h.renderPreview(c, originalURL, destination, destination, false)
+		return
+	}
+
+	if app, ok := h.service.Targeting.SelectApp(originalURL, visitor); ok {
+		h.renderDeepLink(c, app, destination)
+		return
+	}
+
+	code := h.redirectCode(originalURL)
+	c.Header("Cache-Control", h.redirectCacheControl(originalURL, code))
+	c.Redirect(code, destination)
+}
+
+// scheduledURL applies the schedule of a link at the time of the visit and
+// aborts the request if the link is not available.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - url: the link.
+//
+// Returns:
+// - entity.IURL: the link, with the scheduled destination as its origin.
+// - bool: false if the request has been aborted.
+func (h *Handler) scheduledURL(c *gin.Context, url entity.IURL) (entity.IURL, bool) {
+	state, err := h.service.Schedules.Evaluate(url, time.Now())
+	switch err {
+	case nil:
+	case service.ErrLinkNotYetActive:
+		h.notYetAvailable(c, url, state)
+		return nil, false
+	default:
+		h.abortVisit(c, err, "")
+		return nil, false
+	}
+
+	if state.Origin == url.GetOrigin() {
+		return url, true
+	}
+
+	scheduled := entity.CopyURL(url)
+	scheduled.Origin = state.Origin
+
+	return scheduled, true
+}
+
+// notYetAvailable answers a visit to a link before its activation time,
+// with a redirect to its pending destination or the configured message.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - url: the link.
+// - state: the state of the schedule of the link.
+func (h *Handler) notYetAvailable(c *gin.Context, url entity.IURL, state service.ScheduleState) {
+	c.Header("Cache-Control", "no-store")
+
+	if pending := url.GetSchedule().Pending; pending != "" {
+		c.Redirect(http.StatusFound, pending)
+		return
+	}
+
+	retryAfter := math.Ceil(time.Until(state.ActiveAt).Seconds())
+	c.Header("Retry-After", strconv.Itoa(int(retryAfter)))
+
+	message := h.config.URLConfig.GetSchedulePendingMessage()
+	if wantsHTML(c) {
+		h.abortWithPage(c, http.StatusNotFound, ErrorPage{
+			Title:   "Not available yet",
+			Message: message,
+			Detail:  "Available from " + state.ActiveAt.UTC().Format(time.RFC1123) + ".",
+		})
 		return
 	}
 
-	c.Redirect(http.StatusTemporaryRedirect, originalURL.GetOrigin())
+	abort(c, &RequestError{
+		Err:    service.ErrLinkNotYetActive,
+		Detail: message + " Available from " + state.ActiveAt.UTC().Format(time.RFC3339) + ".",
+	})
+}
+
+// disabledReason returns why a link was disabled.
+//
+// Parameters:
+// - url: the link, may be nil.
+//
+// Returns:
+// - string: the reason, empty if there is none or the link is enabled.
+func disabledReason(url entity.IURL) string {
+	if url == nil || !url.IsDisabled() {
+		return ""
+	}
+
+	return url.GetDisabledReason()
+}
+
+// splitCookieName returns the name of the cookie keeping the split
+// destination of a visitor for the given link.
+//
+// Parameters:
+// - url: the link.
+//
+// Returns:
+// - string: the cookie name, derived from a hash of the short URL.
+func splitCookieName(url entity.IURL) string {
+	sum := sha256.Sum256([]byte(url.GetShort()))
+	return SPLIT_COOKIE_PREFIX + hex.EncodeToString(sum[:8])
+}
+
+// redirectCode returns the HTTP status used to redirect to the given URL.
+//
+// Parameters:
+// - url: the URL redirected to.
+//
+// Returns:
+// - int: the status of the URL, or the configured default.
+func (h *Handler) redirectCode(url entity.IURL) int {
+	if code := url.GetRedirectCode(); code != 0 {
+		return code
+	}
+
+	return h.config.URLConfig.GetRedirectStatusCode()
+}
+
+// redirectCacheControl returns the Cache-Control header of a redirect.
+//
+// Permanent redirects may be cached by browsers. Temporary redirects are
+// never cached, so that every visit reaches the server and is counted.
+// Neither are the redirects of links whose destination depends on the
+// visitor or on the time of the visit, whatever their status: a cached
+// redirect would send a visitor where an earlier one was sent, e.g. to the
+// destination of another country or language.
+//
+// Parameters:
+// - url: the link redirected from.
+// - code: the HTTP status of the redirect.
+//
+// Returns:
+// - string: the Cache-Control header value.
+func (h *Handler) redirectCacheControl(url entity.IURL, code int) string {
+	if len(url.GetRules()) > 0 || len(url.GetLanguages()) > 0 || url.GetSplit() != nil || url.GetSchedule() != nil {
+		return "no-store"
+	}
+
+	if code == http.StatusMovedPermanently || code == http.StatusPermanentRedirect {
+		maxAge := int(h.config.URLConfig.GetPermanentRedirectMaxAge().Seconds())
+		return "public, max-age=" + strconv.Itoa(maxAge)
+	}
+
+	return "no-store"
 }
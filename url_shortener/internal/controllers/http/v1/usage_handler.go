@@ -0,0 +1,71 @@
+package httpv1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// getUsage is the HTTP handler for the "GET /api/v1/usage" endpoint.
+// It returns the links created and redirects served for the account of
+// the client during a month, by day, with the quota of its plan.
+//
+// The query accepts "month" (YYYY-MM, the current month by default), and
+// "account" for admins to report on any API key, user or workspace.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) getUsage(c *gin.Context) {
+	month := time.Now()
+	if value := c.Query(USAGE_MONTH_QUERY); value != "" {
+		parsed, err := time.Parse(entity.USAGE_MONTH_LAYOUT, value)
+		if err != nil {
+			abort(c, queryError(USAGE_MONTH_QUERY, errors.New("must be YYYY-MM")))
+			return
+		}
+		month = parsed
+	}
+
+	account := ownerOf(c)
+	if value := c.Query(USAGE_ACCOUNT_QUERY); value != "" && value != account {
+		if !isAdmin(c) {
+			abort(c, &RequestError{Err: ErrForbidden, Detail: "only admins can report on other accounts"})
+			return
+		}
+		account = value
+	}
+	if account == "" {
+		abort(c, &RequestError{Err: ErrInvalidRequest, Fields: []FieldError{{
+			Field:  USAGE_ACCOUNT_QUERY,
+			Code:   "required",
+			Detail: "is required when requests are not authenticated",
+		}}})
+		return
+	}
+
+	report, err := h.service.Usage.Report(c.Request.Context(), account, month)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// setQuotaRetryAfter tells clients refused by a quota when to retry, with
+// the Retry-After header.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+// - err: the error the request is answered with.
+func setQuotaRetryAfter(c *gin.Context, err error) {
+	var quotaError *service.QuotaError
+	if errors.As(err, &quotaError) {
+		c.Header(RETRY_AFTER_HEADER, strconv.Itoa(seconds(time.Until(quotaError.ResetAt))))
+	}
+}
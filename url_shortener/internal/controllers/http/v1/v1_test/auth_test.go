@@ -0,0 +1,198 @@
+package httpv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	httpv1 "github.com/flew1x/url_shortener_ms/internal/controllers/http/v1"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/internal/repository/memory"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/mocks"
+	"github.com/flew1x/url_shortener_ms/pkg/jwks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/mock/gomock"
+)
+
+// TOKEN_TESTDATA is the JWKS fixture shared with the service tests.
+const TOKEN_TESTDATA = "../../../../service/service_test/testdata"
+
+// tokenAuthConfig returns the authentication configuration of the auth
+// tests, accepting the JWTs of the fixture with the user in "email".
+func tokenAuthConfig(ctrl *gomock.Controller) *mocks.MockIAuthConfig {
+	auth := mocks.NewMockIAuthConfig(ctrl)
+	auth.EXPECT().GetEnabled().Return(true).AnyTimes()
+	auth.EXPECT().GetJWTAlgorithms().Return([]string{"RS256"}).AnyTimes()
+	auth.EXPECT().GetJWTIssuer().Return("https://sso.example.com").AnyTimes()
+	auth.EXPECT().GetJWTAudience().Return("url-shortener").AnyTimes()
+	auth.EXPECT().GetJWTLeeway().Return(time.Minute).AnyTimes()
+	auth.EXPECT().GetJWTSubjectClaim().Return("email").AnyTimes()
+	auth.EXPECT().GetJWTScopesClaim().Return("scope").AnyTimes()
+	auth.EXPECT().GetJWTDefaultScopes().Return(nil).AnyTimes()
+	return auth
+}
+
+// signToken returns a JWT of the fixture for a user with scopes.
+func signToken(t *testing.T, subject, scope string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(TOKEN_TESTDATA + "/rsa.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   "https://sso.example.com",
+		"aud":   "url-shortener",
+		"email": subject,
+		"scope": scope,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "rsa-1"
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// workspaceLinkRouter returns the routes of a handler authenticating the
+// JWTs of the fixture, serving the link promo of the workspace acme, whose
+// only member is jane@example.com, an editor.
+func workspaceLinkRouter(ctrl *gomock.Controller) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	link := &entity.URL{Short: "http://localhost/s/promo", Origin: "https://shop.example/promo", OwnerID: entity.WorkspaceOwnerID("acme")}
+	cache := mocks.NewMockIUrlCache(ctrl)
+	cache.EXPECT().GetByShortUrl(gomock.Any(), link.Short).Return(link, nil).AnyTimes()
+
+	members := mocks.NewMockIMemberRepository(ctrl)
+	members.EXPECT().Get(gomock.Any(), "acme", gomock.Any()).DoAndReturn(func(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+		if userID != "jane@example.com" {
+			return nil, repository.ErrNotFound
+		}
+		return &entity.Member{WorkspaceID: workspaceID, UserID: userID, Role: entity.ROLE_EDITOR}, nil
+	}).AnyTimes()
+
+	auth := tokenAuthConfig(ctrl)
+	cfg := &config.Config{AuthConfig: auth, ServerConfig: serverConfig{}}
+	policy := service.NewPolicy(slog.Default(), nil, members)
+	services := &service.Service{
+		Tokens:       service.NewTokenService(slog.Default(), auth, jwks.NewKeySet(TOKEN_TESTDATA+"/jwks.json", time.Hour)),
+		UrlShortener: service.NewURLService(slog.Default(), nil, cache, nil, nil, service.DestinationPolicies{}, policy, cfg),
+		Workspaces:   service.NewWorkspaceService(slog.Default(), policy, nil, members, nil, nil),
+	}
+
+	return httpv1.NewHandler(slog.Default(), services, cfg, nil, nil, httpv1.AppAssociations{}, httpv1.RateLimits{}, nil).InitRoutes()
+}
+
+func TestReservedSubject(t *testing.T) {
+	router := workspaceLinkRouter(gomock.NewController(t))
+
+	tests := []struct {
+		name    string
+		subject string
+		want    int
+	}{
+		{name: "workspace subject", subject: "workspace:acme", want: http.StatusUnauthorized},
+		{name: "API key subject", subject: "key:k1", want: http.StatusUnauthorized},
+		{name: "user outside the workspace", subject: "joe@example.com", want: http.StatusNotFound},
+		{name: "member", subject: "jane@example.com", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/links/promo", nil)
+			request.Header.Set("Authorization", "Bearer "+signToken(t, tt.subject, entity.SCOPE_LINKS_READ))
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.want {
+				t.Errorf("GET /api/v1/links/promo as %q = %d, want %d: %s", tt.subject, recorder.Code, tt.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestWorkspaceTokenScopes(t *testing.T) {
+	router := workspaceLinkRouter(gomock.NewController(t))
+
+	tests := []struct {
+		name  string
+		scope string
+		want  int
+	}{
+		{name: "scope of the token and the role", scope: entity.SCOPE_LINKS_WRITE, want: http.StatusBadRequest},
+		{name: "scope of the role only", scope: entity.SCOPE_STATS_READ, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPatch, "/api/v1/links/promo", strings.NewReader(`{"url":"not a url"}`))
+			request.Header.Set("Authorization", "Bearer "+signToken(t, "jane@example.com", tt.scope))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("X-Workspace-ID", "acme")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.want {
+				t.Errorf("PATCH by an editor with %s = %d, want %d: %s", tt.scope, recorder.Code, tt.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestAPIKeyCredentials(t *testing.T) {
+	store := memory.NewWebhookStore()
+	store.Webhooks["w1"] = entity.NewWebhook("w1", "alice", "https://crm.example/hook", "secret", []string{entity.EVENT_LINK_CLICKED}, "", 0)
+	router := newRouter(store, httpv1.RateLimits{})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		code    int
+		listed  int
+	}{
+		{"bearer", map[string]string{"Authorization": "Bearer usk_alice"}, http.StatusOK, 1},
+		{"x-api-key", map[string]string{"X-API-Key": " usk_alice "}, http.StatusOK, 1},
+		{"bearer before x-api-key", map[string]string{"Authorization": "Bearer usk_bob", "X-API-Key": "usk_alice"}, http.StatusOK, 0},
+		{"no credentials", nil, http.StatusUnauthorized, 0},
+		{"unknown key", map[string]string{"X-API-Key": "usk_mallory"}, http.StatusUnauthorized, 0},
+		{"missing scope", map[string]string{"Authorization": "Bearer usk_carol"}, http.StatusForbidden, 0},
+		{"admin scope", map[string]string{"X-API-Key": "usk_admin"}, http.StatusOK, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil)
+			for name, value := range tt.headers {
+				request.Header.Set(name, value)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.code {
+				t.Fatalf("list webhooks = %d, want %d: %s", recorder.Code, tt.code, recorder.Body)
+			}
+			if tt.code == http.StatusOK {
+				if n := strings.Count(recorder.Body.String(), `"id":`); n != tt.listed {
+					t.Errorf("listed %d webhooks, want %d", n, tt.listed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package httpv1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	httpv1 "github.com/flew1x/url_shortener_ms/internal/controllers/http/v1"
+	"github.com/flew1x/url_shortener_ms/internal/repository/memory"
+	"github.com/flew1x/url_shortener_ms/pkg/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRateLimits limits webhook operations to 2 a minute, with the counts kept by the server.
+func newRateLimits(server *miniredis.Miniredis) httpv1.RateLimits {
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	return httpv1.RateLimits{Limiter: ratelimit.NewLimiter(client), Admin: ratelimit.Limit{Count: 2, Period: time.Minute}}
+}
+
+func listWebhooks(router http.Handler, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRateLimit(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.SetTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	store := memory.NewWebhookStore()
+	router := newRouter(store, newRateLimits(server))
+
+	tests := []struct {
+		status     int
+		remaining  string
+		reset      string
+		retryAfter string
+	}{
+		{status: http.StatusOK, remaining: "1", reset: "30"},
+		{status: http.StatusOK, remaining: "0", reset: "60"},
+		{status: http.StatusTooManyRequests, remaining: "0", reset: "60", retryAfter: "30"},
+	}
+
+	for i, tt := range tests {
+		recorder := listWebhooks(router, "usk_alice")
+
+		if recorder.Code != tt.status {
+			t.Fatalf("request %d: status = %d, want %d: %s", i+1, recorder.Code, tt.status, recorder.Body)
+		}
+
+		headers := map[string]string{
+			"RateLimit-Limit":     "2",
+			"RateLimit-Remaining": tt.remaining,
+			"RateLimit-Reset":     tt.reset,
+			"RateLimit-Policy":    "2;w=60",
+			"Retry-After":         tt.retryAfter,
+		}
+		for name, want := range headers {
+			if got := recorder.Header().Get(name); got != want {
+				t.Errorf("request %d: %s = %q, want %q", i+1, name, got, want)
+			}
+		}
+	}
+
+	var problem struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(listWebhooks(router, "usk_alice").Body.Bytes(), &problem); err != nil || problem.Code != "rate_limited" {
+		t.Errorf("refused request problem = %+v, %v, want rate_limited", problem, err)
+	}
+
+	// Every client has its own limit.
+	if recorder := listWebhooks(router, "usk_bob"); recorder.Code != http.StatusOK {
+		t.Errorf("request of another client = %d, want 200", recorder.Code)
+	}
+}
+
+func TestRateLimitUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := memory.NewWebhookStore()
+	router := newRouter(store, newRateLimits(server))
+	server.Close()
+
+	// Requests are let through without limit while Redis cannot be reached.
+	for i := 0; i < 3; i++ {
+		recorder := listWebhooks(router, "usk_alice")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("request %d without Redis = %d, want 200: %s", i+1, recorder.Code, recorder.Body)
+		}
+		if got := recorder.Header().Get("RateLimit-Limit"); got != "" {
+			t.Errorf("request %d without Redis RateLimit-Limit = %q, want none", i+1, got)
+		}
+	}
+}
+
+func TestRateLimitBeforeAuthentication(t *testing.T) {
+	server := miniredis.RunT(t)
+	rateLimits := newRateLimits(server)
+	rateLimits.Auth = ratelimit.Limit{Count: 2, Period: time.Minute}
+
+	store := memory.NewWebhookStore()
+	router := newRouter(store, rateLimits)
+
+	// Guessed keys are counted by IP address, although they never authenticate.
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		if recorder := listWebhooks(router, "usk_guess"); recorder.Code != want {
+			t.Fatalf("request %d: status = %d, want %d: %s", i+1, recorder.Code, want, recorder.Body)
+		}
+	}
+
+	// The limit is per address, whatever the key.
+	if recorder := listWebhooks(router, "usk_alice"); recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("valid key from the same address = %d, want 429", recorder.Code)
+	}
+}
@@ -0,0 +1,150 @@
+package httpv1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	httpv1 "github.com/flew1x/url_shortener_ms/internal/controllers/http/v1"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository/memory"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/clientip"
+	"github.com/gin-gonic/gin"
+)
+
+type authConfig struct{ config.IAuthConfig }
+
+func (authConfig) GetEnabled() bool { return true }
+
+type serverConfig struct{ config.IServerConfig }
+
+func (serverConfig) GetBrandName() string { return "Shortener" }
+func (serverConfig) GetScheme() string    { return "http" }
+func (serverConfig) GetBindIP() string    { return "localhost" }
+
+type webhookConfig struct{ config.IWebhookConfig }
+
+func (webhookConfig) GetTimeout() time.Duration { return time.Second }
+
+// apiKeys authenticates the API keys of the tests, named by their token.
+type apiKeys struct {
+	service.IAPIKeyService
+	keys map[string]*entity.APIKey
+}
+
+func (a apiKeys) Authenticate(ctx context.Context, token string) (*entity.APIKey, error) {
+	if key, ok := a.keys[token]; ok {
+		return key, nil
+	}
+	return nil, service.ErrInvalidAPIKey
+}
+
+// newRouter returns the routes of a handler whose webhooks are kept in the store.
+func newRouter(store *memory.WebhookStore, rateLimits httpv1.RateLimits) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	keys := apiKeys{keys: map[string]*entity.APIKey{
+		"usk_alice": entity.NewAPIKey("k1", "ci", "alice", "", "usk_alice", "", []string{entity.SCOPE_WEBHOOKS}),
+		"usk_bob":   entity.NewAPIKey("k2", "ci", "bob", "", "usk_bob", "", []string{entity.SCOPE_WEBHOOKS}),
+		"usk_admin": entity.NewAPIKey("k3", "ops", "", "", "usk_admin", "", []string{entity.SCOPE_ADMIN}),
+		"usk_carol": entity.NewAPIKey("k4", "ci", "carol", "", "usk_carol", "", []string{entity.SCOPE_LINKS_READ}),
+	}}
+
+	services := &service.Service{
+		APIKeys:  keys,
+		Webhooks: service.NewWebhookService(slog.Default(), store, memory.NewDeliveryStore(store), nil, nil, service.NewPolicy(slog.Default(), nil, nil), webhookConfig{}),
+	}
+	cfg := &config.Config{AuthConfig: authConfig{}, ServerConfig: serverConfig{}}
+
+	return httpv1.NewHandler(slog.Default(), services, cfg, nil, clientip.NewResolver(nil), httpv1.AppAssociations{}, rateLimits, nil).InitRoutes()
+}
+
+func TestWebhookOwnership(t *testing.T) {
+	store := memory.NewWebhookStore()
+	store.Webhooks["w1"] = entity.NewWebhook("w1", "alice", "https://crm.example/hook", "secret", []string{entity.EVENT_LINK_CLICKED}, "", 0)
+	event := entity.Event{ID: "e1", Type: entity.EVENT_LINK_CLICKED}
+	store.Deliveries["d1"] = entity.NewWebhookDelivery("d1", "w1", event, `{"ip":"203.0.113.7"}`)
+
+	router := newRouter(store, httpv1.RateLimits{})
+
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	listed := func(token string) int {
+		recorder := serve(http.MethodGet, "/api/v1/webhooks", token)
+		var webhooks []entity.Webhook
+		if err := json.Unmarshal(recorder.Body.Bytes(), &webhooks); err != nil {
+			t.Fatalf("list as %s: %v: %s", token, err, recorder.Body)
+		}
+		return len(webhooks)
+	}
+
+	if n := listed("usk_bob"); n != 0 {
+		t.Errorf("another owner lists %d webhooks, want 0", n)
+	}
+	if n := listed("usk_alice"); n != 1 {
+		t.Errorf("the owner lists %d webhooks, want 1", n)
+	}
+	if n := listed("usk_admin"); n != 1 {
+		t.Errorf("an admin lists %d webhooks, want 1", n)
+	}
+
+	for _, tt := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/webhooks/w1/deliveries"},
+		{http.MethodPost, "/api/v1/webhooks/w1/deliveries/d1/replay"},
+		{http.MethodDelete, "/api/v1/webhooks/w1"},
+	} {
+		if recorder := serve(tt.method, tt.path, "usk_bob"); recorder.Code != http.StatusNotFound {
+			t.Errorf("%s %s by another owner = %d, want 404", tt.method, tt.path, recorder.Code)
+		}
+	}
+	if len(store.Webhooks) != 1 || len(store.Deliveries) != 1 {
+		t.Fatalf("another owner changed the webhooks: %d webhooks, %d deliveries", len(store.Webhooks), len(store.Deliveries))
+	}
+
+	if recorder := serve(http.MethodGet, "/api/v1/webhooks/w1/deliveries", "usk_alice"); recorder.Code != http.StatusOK {
+		t.Errorf("deliveries by the owner = %d, want 200", recorder.Code)
+	}
+	if recorder := serve(http.MethodPost, "/api/v1/webhooks/w1/deliveries/d1/replay", "usk_alice"); recorder.Code != http.StatusAccepted {
+		t.Errorf("replay by the owner = %d, want 202", recorder.Code)
+	}
+	if recorder := serve(http.MethodDelete, "/api/v1/webhooks/w1", "usk_alice"); recorder.Code != http.StatusNoContent {
+		t.Errorf("delete by the owner = %d, want 204", recorder.Code)
+	}
+}
+
+func TestRegisterAccountWebhook(t *testing.T) {
+	store := memory.NewWebhookStore()
+	router := newRouter(store, httpv1.RateLimits{})
+
+	body := `{"url":"https://crm.example/hook","events":["link.expired","destination.health_changed"]}`
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer usk_bob")
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("register for every link = %d, want 201: %s", recorder.Code, recorder.Body)
+	}
+
+	var webhook entity.Webhook
+	if err := json.Unmarshal(recorder.Body.Bytes(), &webhook); err != nil {
+		t.Fatal(err)
+	}
+	if webhook.OwnerID != "bob" || webhook.Short != "" {
+		t.Errorf("registered webhook owner = %q, short = %q, want bob's links", webhook.OwnerID, webhook.Short)
+	}
+}
@@ -0,0 +1,108 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RegisterWebhookParams struct {
+	URL            string   `json:"url"`
+	Events         []string `json:"events"`
+	Code           string   `json:"code"`
+	ClickThreshold int64    `json:"click_threshold"`
+}
+
+// registerWebhook is the HTTP handler for the "POST /api/v1/webhooks" endpoint.
+// It registers a webhook for every link of the owner of the client or, if a
+// code is given, for a single link.
+// The response contains the signing secret, which is not shown again.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) registerWebhook(c *gin.Context) {
+	var request RegisterWebhookParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	if request.URL == "" {
+		abort(c, ErrRequiredUrl)
+		return
+	}
+
+	short := ""
+	if request.Code != "" {
+		shortURL := h.service.UrlShortener.BuildShortURL(request.Code)
+		short = shortURL.String()
+	}
+
+	webhook, err := h.service.Webhooks.Register(c.Request.Context(), actorOf(c), request.URL, request.Events, short, request.ClickThreshold)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// listWebhooks is the HTTP handler for the "GET /api/v1/webhooks" endpoint.
+// It returns the webhooks of the client, and those of every owner to admins.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listWebhooks(c *gin.Context) {
+	webhooks, err := h.service.Webhooks.List(c.Request.Context(), actorOf(c))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// deleteWebhook is the HTTP handler for the "DELETE /api/v1/webhooks/:id" endpoint.
+// Webhooks of other owners are answered as not found.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	if err := h.service.Webhooks.Delete(c.Request.Context(), actorOf(c), c.Param(WEBHOOK_ID_PARAM)); err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// listWebhookDeliveries is the HTTP handler for the "GET /api/v1/webhooks/:id/deliveries" endpoint.
+// It returns the most recent deliveries of the webhook with their status.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listWebhookDeliveries(c *gin.Context) {
+	deliveries, err := h.service.Webhooks.Deliveries(c.Request.Context(), actorOf(c), c.Param(WEBHOOK_ID_PARAM))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// replayWebhookDelivery is the HTTP handler for the
+// "POST /api/v1/webhooks/:id/deliveries/:delivery/replay" endpoint.
+// It schedules the payload of an earlier delivery to be sent again.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) replayWebhookDelivery(c *gin.Context) {
+	delivery, err := h.service.Webhooks.Replay(c.Request.Context(), actorOf(c), c.Param(WEBHOOK_ID_PARAM), c.Param(DELIVERY_ID_PARAM))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
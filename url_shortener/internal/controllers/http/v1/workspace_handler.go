@@ -0,0 +1,251 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateWorkspaceParams struct {
+	Name string `json:"name"`
+}
+
+type UpdateMemberParams struct {
+	Role string `json:"role"`
+}
+
+type InviteMemberParams struct {
+	Invitee string `json:"invitee"`
+	Role    string `json:"role"`
+}
+
+type InviteMemberResponse struct {
+	*entity.Invitation
+	Token string `json:"token"`
+}
+
+type AcceptInvitationParams struct {
+	Token string `json:"token"`
+}
+
+type CreateWorkspaceKeyParams struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// createWorkspace is the HTTP handler for the "POST /api/v1/workspaces" endpoint.
+// The user creating the workspace becomes its owner.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) createWorkspace(c *gin.Context) {
+	var request CreateWorkspaceParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	workspace, err := h.service.Workspaces.Create(c.Request.Context(), actorOf(c), request.Name)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, workspace)
+}
+
+// listWorkspaces is the HTTP handler for the "GET /api/v1/workspaces" endpoint.
+// It returns the workspaces the user is a member of.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listWorkspaces(c *gin.Context) {
+	workspaces, err := h.service.Workspaces.List(c.Request.Context(), actorOf(c))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workspaces)
+}
+
+// getWorkspace is the HTTP handler for the "GET /api/v1/workspaces/:workspace" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) getWorkspace(c *gin.Context) {
+	workspace, err := h.service.Workspaces.Get(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+// listMembers is the HTTP handler for the "GET /api/v1/workspaces/:workspace/members" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listMembers(c *gin.Context) {
+	members, err := h.service.Workspaces.Members(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// updateMember is the HTTP handler for the "PATCH /api/v1/workspaces/:workspace/members/:user" endpoint.
+// It changes the role of a member.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) updateMember(c *gin.Context) {
+	var request UpdateMemberParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	err := h.service.Workspaces.SetRole(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM), c.Param(MEMBER_PARAM), request.Role)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// removeMember is the HTTP handler for the "DELETE /api/v1/workspaces/:workspace/members/:user" endpoint.
+// Members may remove themselves to leave the workspace.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) removeMember(c *gin.Context) {
+	if err := h.service.Workspaces.RemoveMember(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM), c.Param(MEMBER_PARAM)); err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// inviteMember is the HTTP handler for the "POST /api/v1/workspaces/:workspace/invitations" endpoint.
+// The response contains the token of the invitation, which is not shown again.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) inviteMember(c *gin.Context) {
+	var request InviteMemberParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	invitation, token, err := h.service.Workspaces.Invite(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM), request.Invitee, request.Role)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, InviteMemberResponse{Invitation: invitation, Token: token})
+}
+
+// listInvitations is the HTTP handler for the "GET /api/v1/workspaces/:workspace/invitations" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listInvitations(c *gin.Context) {
+	invitations, err := h.service.Workspaces.Invitations(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// revokeInvitation is the HTTP handler for the "DELETE /api/v1/workspaces/:workspace/invitations/:invitation" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) revokeInvitation(c *gin.Context) {
+	if err := h.service.Workspaces.RevokeInvitation(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM), c.Param(INVITATION_PARAM)); err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// acceptInvitation is the HTTP handler for the "POST /api/v1/invitations/accept" endpoint.
+// The user becomes a member of the workspace of the invitation.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) acceptInvitation(c *gin.Context) {
+	var request AcceptInvitationParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	member, err := h.service.Workspaces.Accept(c.Request.Context(), actorOf(c), request.Token)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// createWorkspaceKey is the HTTP handler for the "POST /api/v1/workspaces/:workspace/keys" endpoint.
+// The response contains the token of the key, which is not shown again.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) createWorkspaceKey(c *gin.Context) {
+	var request CreateWorkspaceKeyParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abort(c, bindError(err))
+		return
+	}
+
+	key, token, err := h.service.Workspaces.CreateKey(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM), request.Name, request.Scopes)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Token: token})
+}
+
+// listWorkspaceKeys is the HTTP handler for the "GET /api/v1/workspaces/:workspace/keys" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) listWorkspaceKeys(c *gin.Context) {
+	keys, err := h.service.Workspaces.Keys(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM))
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// revokeWorkspaceKey is the HTTP handler for the "DELETE /api/v1/workspaces/:workspace/keys/:id" endpoint.
+//
+// Parameters:
+// - c: the gin.Context for the operation.
+func (h *Handler) revokeWorkspaceKey(c *gin.Context) {
+	if err := h.service.Workspaces.RevokeKey(c.Request.Context(), actorOf(c), c.Param(WORKSPACE_PARAM), c.Param(API_KEY_ID_PARAM)); err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
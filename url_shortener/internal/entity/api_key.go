@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	SCOPE_LINKS_READ  = "links:read"
+	SCOPE_LINKS_WRITE = "links:write"
+	SCOPE_STATS_READ  = "stats:read"
+	SCOPE_WEBHOOKS    = "webhooks:manage"
+	SCOPE_ADMIN       = "admin"
+)
+
+// API_KEY_OWNER_PREFIX starts the owner ID of links created with a key
+// without owner, followed by the ID of the key.
+const API_KEY_OWNER_PREFIX = "key:"
+
+// Scopes lists the scopes an API key can be granted.
+var Scopes = []string{SCOPE_LINKS_READ, SCOPE_LINKS_WRITE, SCOPE_STATS_READ, SCOPE_WEBHOOKS, SCOPE_ADMIN}
+
+// APIKey represents a key authenticating clients of the API.
+//
+// Only the SHA-256 hash of the token is stored, the token itself is
+// returned once when the key is created.
+//
+// Fields:
+// - ID: the unique identifier of the key.
+// - Name: what the key is used for, e.g. "ci".
+// - Owner: the user the links created with the key belong to, empty for the key itself.
+// - WorkspaceID: the workspace the key acts in, empty for a key outside of workspaces.
+// - Prefix: the start of the token, to recognize the key.
+// - Hash: the hex SHA-256 hash of the token.
+// - Scopes: the operations the key is allowed, "admin" allowing all of them.
+// - CreatedAt: the time when the key was created.
+// - RevokedAt: the time when the key was revoked, nil while it is valid.
+type APIKey struct {
+	ID          string     `json:"id" bson:"_id"`
+	Name        string     `json:"name"`
+	Owner       string     `json:"owner,omitempty"`
+	WorkspaceID string     `json:"workspace_id,omitempty"`
+	Prefix      string     `json:"prefix"`
+	Hash        string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// OwnerID returns the owner of the links created with the key.
+func (k *APIKey) OwnerID() string {
+	if k.WorkspaceID != "" {
+		return WorkspaceOwnerID(k.WorkspaceID)
+	}
+	if k.Owner != "" {
+		return k.Owner
+	}
+
+	return API_KEY_OWNER_PREFIX + k.ID
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func NewAPIKey(id, name, owner, workspaceID, prefix, hash string, scopes []string) *APIKey {
+	return &APIKey{
+		ID:          id,
+		Name:        name,
+		Owner:       owner,
+		WorkspaceID: workspaceID,
+		Prefix:      prefix,
+		Hash:        hash,
+		Scopes:      scopes,
+		CreatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"time"
+)
+
+type IClick interface {
+	// GetShort returns the shortened URL that was clicked.
+	GetShort() string
+
+	// GetIP returns the anonymized IP address of the visitor.
+	GetIP() string
+
+	// GetUserAgent returns the User-Agent of the visitor.
+	GetUserAgent() string
+
+	// GetReferer returns the referring page of the visitor.
+	GetReferer() string
+
+	// GetCreatedAt returns the time when the click happened.
+	GetCreatedAt() time.Time
+
+	// GetVariant returns the split destination the visitor was sent to, or empty.
+	GetVariant() string
+}
+
+// Click represents a single visit of a shortened URL.
+//
+// Fields:
+// - Short: the shortened URL that was clicked.
+// - IP: the anonymized IP address of the visitor.
+// - UserAgent: the User-Agent of the visitor.
+// - Referer: the referring page of the visitor.
+// - CreatedAt: the time when the click happened.
+// - Variant: the split destination the visitor was sent to, or empty.
+type Click struct {
+	Short     string    `json:"short"`      // the shortened URL
+	IP        string    `json:"ip"`         // the anonymized IP address
+	UserAgent string    `json:"user_agent"` // the User-Agent of the visitor
+	Referer   string    `json:"referer"`    // the referring page
+	CreatedAt time.Time `json:"created_at"` // the time when the click happened
+	Variant   string    `json:"variant"`    // the split destination
+}
+
+// GetShort implements IClick.
+func (c *Click) GetShort() string {
+	return c.Short
+}
+
+// GetIP implements IClick.
+func (c *Click) GetIP() string {
+	return c.IP
+}
+
+// GetUserAgent implements IClick.
+func (c *Click) GetUserAgent() string {
+	return c.UserAgent
+}
+
+// GetReferer implements IClick.
+func (c *Click) GetReferer() string {
+	return c.Referer
+}
+
+// GetCreatedAt implements IClick.
+func (c *Click) GetCreatedAt() time.Time {
+	return c.CreatedAt
+}
+
+// GetVariant implements IClick.
+func (c *Click) GetVariant() string {
+	return c.Variant
+}
+
+func NewClick(short, ip, userAgent, referer, variant string) IClick {
+	return &Click{
+		Short:     short,
+		IP:        ip,
+		UserAgent: userAgent,
+		Referer:   referer,
+		CreatedAt: time.Now(),
+		Variant:   variant,
+	}
+}
+
+// ClickAggregate represents the number of clicks of a shortened URL on
+// a single day, kept after the raw clicks have been deleted.
+//
+// Fields:
+// - Short: the shortened URL.
+// - Day: the day the clicks happened, truncated to midnight UTC.
+// - Variant: the split destination of the clicks, or empty.
+// - Clicks: the number of clicks on that day.
+type ClickAggregate struct {
+	Short   string    `json:"short"`   // the shortened URL
+	Day     time.Time `json:"day"`     // the day of the clicks
+	Variant string    `json:"variant"` // the split destination
+	Clicks  int64     `json:"clicks"`  // the number of clicks
+}
+
+// LinkStats holds the clicks of a shortened URL.
+//
+// Fields:
+// - Short: the shortened URL.
+// - Clicks: the total number of clicks.
+// - Variants: the number of clicks by split destination, for split links.
+type LinkStats struct {
+	Short    string           `json:"short"`              // the shortened URL
+	Clicks   int64            `json:"clicks"`             // the total number of clicks
+	Variants map[string]int64 `json:"variants,omitempty"` // the clicks by split destination
+}
@@ -0,0 +1,33 @@
+package entity
+
+// DeepLink opens the native app of a link on mobile devices.
+//
+// Visitors on iOS or Android are sent to the app URI of their platform,
+// and to its store page, or the web destination, if the app does not open.
+//
+// Fields:
+// - IOS: the URI opening the iOS app, e.g. "myapp://product/42".
+// - Android: the URI opening the Android app, e.g. "myapp://product/42" or an "intent://" URI.
+// - IOSStore: the App Store page of the iOS app, empty to fall back to the web destination.
+// - AndroidStore: the Google Play page of the Android app, empty to fall back to the web destination.
+type DeepLink struct {
+	IOS          string `json:"ios,omitempty"`           // the URI opening the iOS app
+	Android      string `json:"android,omitempty"`       // the URI opening the Android app
+	IOSStore     string `json:"ios_store,omitempty"`     // the App Store page
+	AndroidStore string `json:"android_store,omitempty"` // the Google Play page
+}
+
+// IsZero reports whether the deep link opens no app.
+func (d DeepLink) IsZero() bool {
+	return d.IOS == "" && d.Android == "" && d.IOSStore == "" && d.AndroidStore == ""
+}
+
+// AppTarget is the app a visitor is sent to.
+//
+// Fields:
+// - URI: the URI opening the app.
+// - Store: the store page opened if the app is not installed, empty for the web destination.
+type AppTarget struct {
+	URI   string
+	Store string
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	EVENT_LINK_CREATED = "link.created"
+	EVENT_LINK_UPDATED = "link.updated"
+	EVENT_LINK_DELETED = "link.deleted"
+	EVENT_LINK_CLICKED = "link.clicked"
+	EVENT_LINK_EXPIRED = "link.expired"
+
+	EVENT_DESTINATION_HEALTH_CHANGED = "destination.health_changed"
+)
+
+// Event represents something that happened to a shortened URL.
+//
+// Fields:
+// - ID: the unique identifier of the event, used to deduplicate deliveries.
+// - Type: the type of the event, e.g. "link.created".
+// - Short: the shortened URL the event is about.
+// - Origin: the original URL of the shortened URL.
+// - OwnerID: the owner of the shortened URL, empty for links created without authentication.
+// - Data: additional event-specific attributes.
+// - OccurredAt: the time when the event happened.
+type Event struct {
+	ID         string         `json:"id"`                 // the unique identifier of the event
+	Type       string         `json:"type"`               // the type of the event
+	Short      string         `json:"short"`              // the shortened URL
+	Origin     string         `json:"origin"`             // the original URL
+	OwnerID    string         `json:"owner_id,omitempty"` // the owner of the shortened URL
+	Data       map[string]any `json:"data"`               // event-specific attributes
+	OccurredAt time.Time      `json:"occurred_at"`        // the time when the event happened
+}
+
+func NewEvent(id, eventType string, url IURL, data map[string]any) Event {
+	return Event{
+		ID:         id,
+		Type:       eventType,
+		Short:      url.GetShort(),
+		Origin:     url.GetOrigin(),
+		OwnerID:    url.GetOwnerID(),
+		Data:       data,
+		OccurredAt: time.Now(),
+	}
+}
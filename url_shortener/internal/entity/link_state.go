@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+const (
+	DESTINATION_HEALTH_UP   = "up"
+	DESTINATION_HEALTH_DOWN = "down"
+)
+
+// LinkState holds what the link monitor last saw of a link, so that its
+// events are published once per change.
+//
+// Fields:
+// - Short: the shortened URL.
+// - Expired: the expiration time of the schedule link.expired was published for, empty if none.
+// - Health: the health of the destination at the last check, "up", "down" or empty if never checked.
+// - CheckedAt: the time of the last health check.
+type LinkState struct {
+	Short     string    `json:"short" bson:"_id"`     // the shortened URL
+	Expired   string    `json:"expired,omitempty"`    // the expiration published
+	Health    string    `json:"health,omitempty"`     // the health of the destination
+	CheckedAt time.Time `json:"checked_at,omitempty"` // the time of the last health check
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "slices"
+
+// Schedule holds the activation window and the planned destination changes
+// of a link.
+//
+// Times are RFC 3339 timestamps, or local date-times such as
+// "2024-05-01T09:00" read in the timezone of the schedule when the link is
+// visited, so that they follow daylight saving changes.
+//
+// Fields:
+// - Timezone: the IANA name of the timezone of local times, e.g. "Europe/Berlin", empty for the configured default.
+// - NotBefore: the time the link becomes available, empty for no bound.
+// - NotAfter: the time the link expires, empty for no bound.
+// - Pending: the URL visitors are redirected to before activation, empty for the "not yet available" response.
+// - Changes: the destinations replacing the origin, ordered by their start time.
+type Schedule struct {
+	Timezone  string                 `json:"timezone,omitempty"`   // the timezone of local times
+	NotBefore string                 `json:"not_before,omitempty"` // the activation time
+	NotAfter  string                 `json:"not_after,omitempty"`  // the expiration time
+	Pending   string                 `json:"pending,omitempty"`    // the URL before activation
+	Changes   []ScheduledDestination `json:"changes,omitempty"`    // the planned destinations
+}
+
+// IsZero reports whether the schedule has no window and no change.
+func (s Schedule) IsZero() bool {
+	return s.NotBefore == "" && s.NotAfter == "" && s.Pending == "" && len(s.Changes) == 0
+}
+
+// ScheduledDestination replaces the origin of a link from its start time
+// until the start of the next change.
+//
+// Fields:
+// - From: the start time of the change.
+// - Destination: the URL redirected to.
+type ScheduledDestination struct {
+	From        string `json:"from"`        // the start time
+	Destination string `json:"destination"` // the URL redirected to
+}
+
+// copySchedule returns a copy of the given schedule.
+func copySchedule(schedule *Schedule) *Schedule {
+	if schedule == nil {
+		return nil
+	}
+
+	copied := *schedule
+	copied.Changes = slices.Clone(schedule.Changes)
+	return &copied
+}
@@ -0,0 +1,64 @@
+package entity
+
+// TargetingRule sends the clients it matches to its own destination.
+//
+// Every non-empty condition must match. Rules of a link are evaluated in
+// order and the first matching one wins.
+//
+// Fields:
+// - OS: the operating system, e.g. "ios" or "android".
+// - Device: the device class, e.g. "mobile" or "desktop".
+// - Browser: the browser, e.g. "chrome" or "safari".
+// - Country: the ISO 3166-1 alpha-2 code of the country of the client, e.g. "DE".
+// - Region: the ISO 3166-2 code of the region of the client, e.g. "DE-BY".
+// - Destination: the URL matching clients are redirected to.
+type TargetingRule struct {
+	OS          string `json:"os,omitempty"`      // the operating system
+	Device      string `json:"device,omitempty"`  // the device class
+	Browser     string `json:"browser,omitempty"` // the browser
+	Country     string `json:"country,omitempty"` // the country of the client
+	Region      string `json:"region,omitempty"`  // the region of the client
+	Destination string `json:"destination"`       // the URL redirected to
+}
+
+// LanguageRule sends clients preferring its language to its own destination.
+//
+// Fields:
+// - Language: the BCP 47 tag of the language, e.g. "de" or "pt-BR".
+// - Destination: the URL matching clients are redirected to.
+type LanguageRule struct {
+	Language    string `json:"language"`    // the language tag
+	Destination string `json:"destination"` // the URL redirected to
+}
+
+// Split divides the visitors of a link between weighted destinations.
+//
+// Fields:
+// - Sticky: whether a visitor keeps the destination it was first sent to.
+// - Destinations: the weighted destinations.
+type Split struct {
+	Sticky       bool                  `json:"sticky,omitempty"` // whether visitors keep their destination
+	Destinations []WeightedDestination `json:"destinations"`     // the weighted destinations
+}
+
+// WeightedDestination is one of the destinations of a split.
+//
+// Fields:
+// - ID: the name of the variant, reported in click stats.
+// - Destination: the URL the visitors of the variant are redirected to.
+// - Weight: the share of visitors sent to the variant, relative to the others.
+type WeightedDestination struct {
+	ID          string `json:"id"`          // the name of the variant
+	Destination string `json:"destination"` // the URL redirected to
+	Weight      int    `json:"weight"`      // the relative share of visitors
+}
+
+// Target is the destination chosen for a visitor.
+//
+// Fields:
+// - URL: the URL to redirect to.
+// - Variant: the split destination chosen, or empty.
+type Target struct {
+	URL     string
+	Variant string
+}
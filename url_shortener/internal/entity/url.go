@@ -1,9 +1,21 @@
 package entity
 
 import (
+	"slices"
 	"time"
 )
 
+const (
+	// DISABLED_BY_OWNER marks links disabled by their owner, who may enable them again.
+	DISABLED_BY_OWNER = "owner"
+
+	// DISABLED_BY_ADMIN marks links disabled by an admin, who alone may enable them again.
+	DISABLED_BY_ADMIN = "admin"
+
+	// DISABLED_BY_THREAT marks links disabled because a destination is on a threat list.
+	DISABLED_BY_THREAT = "threat"
+)
+
 type IURL interface {
 	// GetShort returns the shortened URL.
 	GetShort() string
@@ -13,6 +25,56 @@ type IURL interface {
 
 	// GetCreatedAt returns the time when the URL was created.
 	GetCreatedAt() time.Time
+
+	// GetRedirectCode returns the HTTP status used to redirect, or zero for the default.
+	GetRedirectCode() int
+
+	// GetQueryPolicy returns how the visited query is passed to the origin.
+	GetQueryPolicy() string
+
+	// GetQueryPrecedence returns which side wins merge conflicts.
+	GetQueryPrecedence() string
+
+	// GetQueryAllowlist returns the parameter names passed to the origin, or nil for all.
+	GetQueryAllowlist() []string
+
+	// IsPrefix reports whether the URL also matches longer paths, whose
+	// remainder is appended to the origin.
+	IsPrefix() bool
+
+	// GetUTM returns the campaign parameters added to the origin, or nil for none.
+	GetUTM() *UTM
+
+	// GetRules returns the ordered targeting rules, or nil for none.
+	GetRules() []TargetingRule
+
+	// GetLanguages returns the language rules, or nil for none.
+	GetLanguages() []LanguageRule
+
+	// GetSplit returns the weighted destinations, or nil for none.
+	GetSplit() *Split
+
+	// GetSchedule returns the activation window and planned destinations, or nil for none.
+	GetSchedule() *Schedule
+
+	// IsInterstitial reports whether visitors are shown a preview page
+	// before continuing to the destination.
+	IsInterstitial() bool
+
+	// GetDeepLink returns the apps opened on mobile devices, or nil for none.
+	GetDeepLink() *DeepLink
+
+	// IsDisabled reports whether the link no longer redirects.
+	IsDisabled() bool
+
+	// GetDisabledReason returns why the link was disabled, shown to its visitors.
+	GetDisabledReason() string
+
+	// GetDisabledBy returns who disabled the link, e.g. DISABLED_BY_OWNER, empty if it is enabled.
+	GetDisabledBy() string
+
+	// GetOwnerID returns the user or API key owning the link, empty for links created without authentication.
+	GetOwnerID() string
 }
 
 // URL represents a shortened URL.
@@ -23,10 +85,42 @@ type IURL interface {
 // - Short: the shortened URL.
 // - Clicks: the number of times the URL has been clicked.
 // - CreatedAt: the time when the URL was created.
+// - RedirectCode: the HTTP status used to redirect, or zero for the default.
+// - QueryPolicy: how the visited query is passed to the origin, empty meaning drop.
+// - QueryPrecedence: which side wins merge conflicts, empty meaning origin.
+// - QueryAllowlist: the parameter names passed to the origin, empty meaning all.
+// - Prefix: whether the URL also matches longer paths.
+// - UTM: the campaign parameters added to the origin, nil for none.
+// - Rules: the ordered targeting rules, the origin being used when none matches.
+// - Languages: the language rules, used when no targeting rule matches.
+// - Split: the weighted destinations replacing the origin, nil for none.
+// - Schedule: the activation window and planned destinations, nil for none.
+// - Interstitial: whether visitors are shown a preview page before continuing.
+// - DeepLink: the apps opened on mobile devices, nil for none.
+// - Disabled: whether the link no longer redirects.
+// - DisabledReason: why the link was disabled, shown to its visitors.
+// - DisabledBy: who disabled the link, only its owner may enable a link it disabled itself.
+// - OwnerID: the user or API key owning the link, empty for links created without authentication.
 type URL struct {
-	Short     string    `json:"short"`      // the shortened URL
-	Origin    string    `json:"origin"`     // the original URL
-	CreatedAt time.Time `json:"created_at"` // the time when the URL was created
+	Short           string          `json:"short"`                      // the shortened URL
+	Origin          string          `json:"origin"`                     // the original URL
+	CreatedAt       time.Time       `json:"created_at"`                 // the time when the URL was created
+	RedirectCode    int             `json:"redirect_code,omitempty"`    // the HTTP status used to redirect
+	QueryPolicy     string          `json:"query_policy,omitempty"`     // how the visited query is passed on
+	QueryPrecedence string          `json:"query_precedence,omitempty"` // which side wins merge conflicts
+	QueryAllowlist  []string        `json:"query_allowlist,omitempty"`  // the parameter names passed on
+	Prefix          bool            `json:"prefix,omitempty"`           // whether longer paths match too
+	UTM             *UTM            `json:"utm,omitempty"`              // the campaign parameters
+	Rules           []TargetingRule `json:"rules,omitempty"`            // the ordered targeting rules
+	Languages       []LanguageRule  `json:"languages,omitempty"`        // the language rules
+	Split           *Split          `json:"split,omitempty"`            // the weighted destinations
+	Schedule        *Schedule       `json:"schedule,omitempty"`         // the activation window
+	Interstitial    bool            `json:"interstitial,omitempty"`     // whether a preview is shown first
+	DeepLink        *DeepLink       `json:"deep_link,omitempty"`        // the apps opened on mobile devices
+	Disabled        bool            `json:"disabled,omitempty"`         // whether the link no longer redirects
+	DisabledReason  string          `json:"disabled_reason,omitempty"`  // why the link was disabled
+	DisabledBy      string          `json:"disabled_by,omitempty"`      // who disabled the link
+	OwnerID         string          `json:"owner_id,omitempty"`         // the owner of the link
 }
 
 // GetCreatedAt implements IURL.
@@ -44,6 +138,86 @@ func (u *URL) GetShort() string {
 	return u.Short
 }
 
+// GetRedirectCode implements IURL.
+func (u *URL) GetRedirectCode() int {
+	return u.RedirectCode
+}
+
+// GetQueryPolicy implements IURL.
+func (u *URL) GetQueryPolicy() string {
+	return u.QueryPolicy
+}
+
+// GetQueryPrecedence implements IURL.
+func (u *URL) GetQueryPrecedence() string {
+	return u.QueryPrecedence
+}
+
+// GetQueryAllowlist implements IURL.
+func (u *URL) GetQueryAllowlist() []string {
+	return u.QueryAllowlist
+}
+
+// IsPrefix implements IURL.
+func (u *URL) IsPrefix() bool {
+	return u.Prefix
+}
+
+// GetUTM implements IURL.
+func (u *URL) GetUTM() *UTM {
+	return u.UTM
+}
+
+// GetRules implements IURL.
+func (u *URL) GetRules() []TargetingRule {
+	return u.Rules
+}
+
+// GetLanguages implements IURL.
+func (u *URL) GetLanguages() []LanguageRule {
+	return u.Languages
+}
+
+// GetSplit implements IURL.
+func (u *URL) GetSplit() *Split {
+	return u.Split
+}
+
+// GetSchedule implements IURL.
+func (u *URL) GetSchedule() *Schedule {
+	return u.Schedule
+}
+
+// IsInterstitial implements IURL.
+func (u *URL) IsInterstitial() bool {
+	return u.Interstitial
+}
+
+// GetDeepLink implements IURL.
+func (u *URL) GetDeepLink() *DeepLink {
+	return u.DeepLink
+}
+
+// IsDisabled implements IURL.
+func (u *URL) IsDisabled() bool {
+	return u.Disabled
+}
+
+// GetDisabledReason implements IURL.
+func (u *URL) GetDisabledReason() string {
+	return u.DisabledReason
+}
+
+// GetDisabledBy implements IURL.
+func (u *URL) GetDisabledBy() string {
+	return u.DisabledBy
+}
+
+// GetOwnerID implements IURL.
+func (u *URL) GetOwnerID() string {
+	return u.OwnerID
+}
+
 func NewURL(short, origin string) IURL {
 	return &URL{
 		Short:     short,
@@ -51,3 +225,57 @@ func NewURL(short, origin string) IURL {
 		CreatedAt: time.Now(),
 	}
 }
+
+// CopyURL returns a modifiable copy of the given URL.
+func CopyURL(url IURL) *URL {
+	return &URL{
+		Short:           url.GetShort(),
+		Origin:          url.GetOrigin(),
+		CreatedAt:       url.GetCreatedAt(),
+		RedirectCode:    url.GetRedirectCode(),
+		QueryPolicy:     url.GetQueryPolicy(),
+		QueryPrecedence: url.GetQueryPrecedence(),
+		QueryAllowlist:  slices.Clone(url.GetQueryAllowlist()),
+		Prefix:          url.IsPrefix(),
+		UTM:             copyUTM(url.GetUTM()),
+		Rules:           slices.Clone(url.GetRules()),
+		Languages:       slices.Clone(url.GetLanguages()),
+		Split:           copySplit(url.GetSplit()),
+		Schedule:        copySchedule(url.GetSchedule()),
+		Interstitial:    url.IsInterstitial(),
+		DeepLink:        copyDeepLink(url.GetDeepLink()),
+		Disabled:        url.IsDisabled(),
+		DisabledReason:  url.GetDisabledReason(),
+		DisabledBy:      url.GetDisabledBy(),
+		OwnerID:         url.GetOwnerID(),
+	}
+}
+
+// copySplit returns a copy of the given split.
+func copySplit(split *Split) *Split {
+	if split == nil {
+		return nil
+	}
+
+	return &Split{Sticky: split.Sticky, Destinations: slices.Clone(split.Destinations)}
+}
+
+// copyDeepLink returns a copy of the given deep link.
+func copyDeepLink(deepLink *DeepLink) *DeepLink {
+	if deepLink == nil {
+		return nil
+	}
+
+	copied := *deepLink
+	return &copied
+}
+
+// copyUTM returns a copy of the given campaign parameters.
+func copyUTM(utm *UTM) *UTM {
+	if utm == nil {
+		return nil
+	}
+
+	copied := *utm
+	return &copied
+}
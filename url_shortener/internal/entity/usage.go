@@ -0,0 +1,115 @@
+package entity
+
+import "time"
+
+const (
+	// USAGE_LINKS counts the links created by an account.
+	USAGE_LINKS = "links"
+
+	// USAGE_REDIRECTS counts the redirects served for the links of an account.
+	USAGE_REDIRECTS = "redirects"
+)
+
+const (
+	USAGE_DAY_LAYOUT   = "2006-01-02"
+	USAGE_MONTH_LAYOUT = "2006-01"
+)
+
+const (
+	USAGE_WINDOW_DAY   = "daily"
+	USAGE_WINDOW_MONTH = "monthly"
+)
+
+// Usage counts what an account used during a UTC day or month.
+//
+// Fields:
+// - ID: the account and the period, an account having one usage per period.
+// - Account: the owner ID of the API key, user or workspace.
+// - Period: the day, "2006-01-02", or the month, "2006-01".
+// - Links: the number of links created.
+// - Redirects: the number of redirects served.
+// - UpdatedAt: the time when the counts were last saved.
+type Usage struct {
+	ID        string    `json:"-" bson:"_id"`
+	Account   string    `json:"-"`
+	Period    string    `json:"period"`
+	Links     int64     `json:"links"`
+	Redirects int64     `json:"redirects"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func NewUsage(account, period string) *Usage {
+	return &Usage{
+		ID:      account + " " + period,
+		Account: account,
+		Period:  period,
+	}
+}
+
+// Count returns the count of a metric, USAGE_LINKS or USAGE_REDIRECTS.
+func (u *Usage) Count(metric string) int64 {
+	switch metric {
+	case USAGE_LINKS:
+		return u.Links
+	case USAGE_REDIRECTS:
+		return u.Redirects
+	default:
+		return 0
+	}
+}
+
+// Merge keeps the highest counts of two copies of the same usage, the
+// counts only ever growing.
+func (u *Usage) Merge(other *Usage) {
+	u.Links = max(u.Links, other.Links)
+	u.Redirects = max(u.Redirects, other.Redirects)
+}
+
+// UsagePeriods returns the day and the month counting a use at a time.
+func UsagePeriods(at time.Time) (day, month string) {
+	at = at.UTC()
+	return at.Format(USAGE_DAY_LAYOUT), at.Format(USAGE_MONTH_LAYOUT)
+}
+
+// Quota limits what an account may use per UTC day and month, zero
+// meaning unlimited.
+//
+// Fields:
+// - DailyLinks: the links created per day.
+// - MonthlyLinks: the links created per month.
+// - DailyRedirects: the redirects served per day.
+// - MonthlyRedirects: the redirects served per month.
+type Quota struct {
+	DailyLinks       int64 `json:"daily_links,omitempty"`
+	MonthlyLinks     int64 `json:"monthly_links,omitempty"`
+	DailyRedirects   int64 `json:"daily_redirects,omitempty"`
+	MonthlyRedirects int64 `json:"monthly_redirects,omitempty"`
+}
+
+// Limits returns the daily and monthly limits of a metric.
+func (q Quota) Limits(metric string) (daily, monthly int64) {
+	switch metric {
+	case USAGE_LINKS:
+		return q.DailyLinks, q.MonthlyLinks
+	case USAGE_REDIRECTS:
+		return q.DailyRedirects, q.MonthlyRedirects
+	default:
+		return 0, 0
+	}
+}
+
+// UsageReport is the usage of an account during a month.
+//
+// Fields:
+// - Account: the owner ID of the API key, user or workspace.
+// - Plan: the name of the plan of the account.
+// - Quota: the limits of the plan.
+// - Month: the usage of the month.
+// - Days: the usage of the days of the month with any use, in order.
+type UsageReport struct {
+	Account string   `json:"account"`
+	Plan    string   `json:"plan"`
+	Quota   Quota    `json:"quota"`
+	Month   *Usage   `json:"month"`
+	Days    []*Usage `json:"days"`
+}
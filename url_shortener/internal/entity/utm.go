@@ -0,0 +1,63 @@
+package entity
+
+import "net/url"
+
+const (
+	UTM_SOURCE   = "utm_source"
+	UTM_MEDIUM   = "utm_medium"
+	UTM_CAMPAIGN = "utm_campaign"
+	UTM_TERM     = "utm_term"
+	UTM_CONTENT  = "utm_content"
+)
+
+// UTM holds the campaign parameters added to the origin of a link.
+//
+// Fields:
+// - Source: the referrer, e.g. "newsletter".
+// - Medium: the marketing medium, e.g. "email".
+// - Campaign: the campaign name.
+// - Term: the paid search keywords.
+// - Content: what differentiates variants of the same campaign.
+type UTM struct {
+	Source   string `json:"source,omitempty"`   // the referrer
+	Medium   string `json:"medium,omitempty"`   // the marketing medium
+	Campaign string `json:"campaign,omitempty"` // the campaign name
+	Term     string `json:"term,omitempty"`     // the paid search keywords
+	Content  string `json:"content,omitempty"`  // the variant of the campaign
+}
+
+// IsZero reports whether no parameter is set.
+func (u UTM) IsZero() bool {
+	return u == UTM{}
+}
+
+// Values returns the set parameters as query values.
+func (u UTM) Values() url.Values {
+	values := url.Values{}
+
+	for name, value := range map[string]string{
+		UTM_SOURCE:   u.Source,
+		UTM_MEDIUM:   u.Medium,
+		UTM_CAMPAIGN: u.Campaign,
+		UTM_TERM:     u.Term,
+		UTM_CONTENT:  u.Content,
+	} {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+
+	return values
+}
+
+// CampaignStats holds the clicks of the links of a campaign.
+//
+// Fields:
+// - Campaign: the campaign name.
+// - Links: the number of links of the campaign.
+// - Clicks: the number of clicks on these links.
+type CampaignStats struct {
+	Campaign string `json:"campaign"` // the campaign name
+	Links    int    `json:"links"`    // the number of links
+	Clicks   int64  `json:"clicks"`   // the number of clicks
+}
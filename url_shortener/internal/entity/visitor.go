@@ -0,0 +1,19 @@
+package entity
+
+// Visitor describes the client following a shortened URL.
+//
+// Fields:
+// - IP: the IP address of the client.
+// - UserAgent: the User-Agent header of the client.
+// - Referer: the Referer header of the client.
+// - AcceptLanguage: the Accept-Language header of the client.
+// - DoNotTrack: whether the client sent a DNT or Sec-GPC opt-out.
+// - Variant: the split destination the client was assigned to, or empty.
+type Visitor struct {
+	IP             string
+	UserAgent      string
+	Referer        string
+	AcceptLanguage string
+	DoNotTrack     bool
+	Variant        string
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	EVENT_CLICK_THRESHOLD_REACHED = "click.threshold_reached"
+)
+
+const (
+	DELIVERY_STATUS_PENDING   = "pending"
+	DELIVERY_STATUS_SUCCEEDED = "succeeded"
+	DELIVERY_STATUS_FAILED    = "failed"
+)
+
+// Webhook represents an endpoint notified about events.
+//
+// Fields:
+// - ID: the unique identifier of the webhook.
+// - OwnerID: the owner of the client that registered the webhook, who alone may manage it.
+// - URL: the endpoint deliveries are posted to.
+// - Secret: the key deliveries are signed with.
+// - Events: the subscribed event types.
+// - Short: the shortened URL the webhook is limited to, or empty for every link.
+// - ClickThreshold: the number of clicks that triggers click.threshold_reached.
+// - ThresholdReached: whether click.threshold_reached has already fired.
+// - CreatedAt: the time when the webhook was registered.
+type Webhook struct {
+	ID               string    `json:"id" bson:"_id"`
+	OwnerID          string    `json:"owner_id,omitempty"`
+	URL              string    `json:"url"`
+	Secret           string    `json:"secret,omitempty"`
+	Events           []string  `json:"events"`
+	Short            string    `json:"short,omitempty"`
+	ClickThreshold   int64     `json:"click_threshold,omitempty"`
+	ThresholdReached bool      `json:"threshold_reached"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Subscribes reports whether the webhook wants the given event type.
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, subscribed := range w.Events {
+		if subscribed == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewWebhook(id, ownerID, url, secret string, events []string, short string, clickThreshold int64) *Webhook {
+	return &Webhook{
+		ID:             id,
+		OwnerID:        ownerID,
+		URL:            url,
+		Secret:         secret,
+		Events:         events,
+		Short:          short,
+		ClickThreshold: clickThreshold,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// WebhookDelivery represents a single attempt series to deliver an event
+// to a webhook.
+//
+// Fields:
+// - ID: the unique identifier of the delivery.
+// - WebhookID: the webhook the event is delivered to.
+// - EventID: the delivered event.
+// - EventType: the type of the delivered event.
+// - Payload: the JSON body posted to the webhook.
+// - Status: "pending", "succeeded" or "failed".
+// - Attempts: the number of attempts made so far.
+// - ResponseStatus: the HTTP status of the last attempt.
+// - LastError: the error of the last failed attempt.
+// - ReplayOf: the delivery this one replays, if any.
+// - NextAttemptAt: the time of the next attempt of a pending delivery.
+// - CreatedAt: the time when the delivery was created.
+// - UpdatedAt: the time of the last attempt.
+type WebhookDelivery struct {
+	ID             string    `json:"id" bson:"_id"`
+	WebhookID      string    `json:"webhook_id"`
+	EventID        string    `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	Payload        string    `json:"payload"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	ResponseStatus int       `json:"response_status,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	ReplayOf       string    `json:"replay_of,omitempty"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func NewWebhookDelivery(id, webhookID string, event Event, payload string) *WebhookDelivery {
+	now := time.Now()
+
+	return &WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        DELIVERY_STATUS_PENDING,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
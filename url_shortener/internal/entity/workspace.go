@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ROLE_OWNER  = "owner"
+	ROLE_ADMIN  = "admin"
+	ROLE_EDITOR = "editor"
+	ROLE_VIEWER = "viewer"
+)
+
+const (
+	// ACTION_MANAGE_MEMBERS allows inviting members and changing the roles of
+	// admins, editors and viewers.
+	ACTION_MANAGE_MEMBERS = "members:manage"
+
+	// ACTION_MANAGE_KEYS allows creating and revoking the API keys of a workspace.
+	ACTION_MANAGE_KEYS = "keys:manage"
+
+	// ACTION_MANAGE_OWNERS allows granting and removing the owner role.
+	ACTION_MANAGE_OWNERS = "owners:manage"
+)
+
+// WORKSPACE_OWNER_PREFIX starts the owner ID of the links of a workspace,
+// followed by the ID of the workspace.
+const WORKSPACE_OWNER_PREFIX = "workspace:"
+
+// Roles lists the roles of workspace members, from the most to the least privileged.
+var Roles = []string{ROLE_OWNER, ROLE_ADMIN, ROLE_EDITOR, ROLE_VIEWER}
+
+// WorkspaceScopes lists the scopes that can be granted within a workspace.
+var WorkspaceScopes = []string{SCOPE_LINKS_READ, SCOPE_LINKS_WRITE, SCOPE_STATS_READ}
+
+// rolePermissions lists the scopes and actions each role is allowed.
+var rolePermissions = map[string][]string{
+	ROLE_VIEWER: {SCOPE_LINKS_READ, SCOPE_STATS_READ},
+	ROLE_EDITOR: {SCOPE_LINKS_READ, SCOPE_LINKS_WRITE, SCOPE_STATS_READ},
+	ROLE_ADMIN:  {SCOPE_LINKS_READ, SCOPE_LINKS_WRITE, SCOPE_STATS_READ, ACTION_MANAGE_MEMBERS, ACTION_MANAGE_KEYS},
+	ROLE_OWNER:  {SCOPE_LINKS_READ, SCOPE_LINKS_WRITE, SCOPE_STATS_READ, ACTION_MANAGE_MEMBERS, ACTION_MANAGE_KEYS, ACTION_MANAGE_OWNERS},
+}
+
+// RoleAllows reports whether a role is allowed a scope or an action.
+func RoleAllows(role, permission string) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// WorkspaceOwnerID returns the owner ID of the links of a workspace.
+func WorkspaceOwnerID(workspaceID string) string {
+	return WORKSPACE_OWNER_PREFIX + workspaceID
+}
+
+// WorkspaceOfOwner returns the workspace owning the links of an owner ID.
+//
+// Returns:
+// - string: the ID of the workspace.
+// - bool: false if the owner is not a workspace.
+func WorkspaceOfOwner(ownerID string) (string, bool) {
+	return strings.CutPrefix(ownerID, WORKSPACE_OWNER_PREFIX)
+}
+
+// IsReservedOwnerID reports whether an owner ID names a workspace or an
+// API key, which a user or the owner of an API key can never be.
+func IsReservedOwnerID(ownerID string) bool {
+	return strings.HasPrefix(ownerID, WORKSPACE_OWNER_PREFIX) || strings.HasPrefix(ownerID, API_KEY_OWNER_PREFIX)
+}
+
+// Workspace represents a team sharing links and API keys, strictly
+// separated from other workspaces.
+//
+// Fields:
+// - ID: the unique identifier of the workspace.
+// - Name: the name of the workspace, e.g. the client it is for.
+// - CreatedBy: the user who created the workspace.
+// - CreatedAt: the time when the workspace was created.
+type Workspace struct {
+	ID        string    `json:"id" bson:"_id"`
+	Name      string    `json:"name"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewWorkspace(id, name, createdBy string) *Workspace {
+	return &Workspace{
+		ID:        id,
+		Name:      name,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Member represents the role of a user in a workspace.
+//
+// Fields:
+// - ID: the workspace and the user, a user being a member once.
+// - WorkspaceID: the ID of the workspace.
+// - UserID: the user, as identified by the subject claim of their JWT or the owner of their API keys.
+// - Role: "owner", "admin", "editor" or "viewer".
+// - CreatedAt: the time when the user joined the workspace.
+type Member struct {
+	ID          string    `json:"-" bson:"_id"`
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewMember(workspaceID, userID, role string) *Member {
+	return &Member{
+		ID:          workspaceID + " " + userID,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        role,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Invitation represents an invitation of a user to join a workspace.
+//
+// Only the SHA-256 hash of the token is stored, the token itself is
+// returned once when the invitation is created.
+//
+// Fields:
+// - ID: the unique identifier of the invitation.
+// - WorkspaceID: the ID of the workspace.
+// - Invitee: the user who may accept the invitation.
+// - Role: the role the invitee is given.
+// - Hash: the hex SHA-256 hash of the token.
+// - InvitedBy: the user who sent the invitation.
+// - CreatedAt: the time when the invitation was created.
+// - ExpiresAt: the time after which the invitation cannot be accepted.
+// - AcceptedAt: the time when the invitation was accepted, nil while it is pending.
+type Invitation struct {
+	ID          string     `json:"id" bson:"_id"`
+	WorkspaceID string     `json:"workspace_id"`
+	Invitee     string     `json:"invitee"`
+	Role        string     `json:"role"`
+	Hash        string     `json:"-"`
+	InvitedBy   string     `json:"invited_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+}
+
+// IsPending reports whether the invitation can still be accepted.
+func (i *Invitation) IsPending() bool {
+	return i.AcceptedAt == nil && time.Now().Before(i.ExpiresAt)
+}
+
+func NewInvitation(id, workspaceID, invitee, role, hash, invitedBy string, ttl time.Duration) *Invitation {
+	now := time.Now()
+
+	return &Invitation{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		Invitee:     invitee,
+		Role:        role,
+		Hash:        hash,
+		InvitedBy:   invitedBy,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
@@ -0,0 +1,17 @@
+package events
+
+const (
+	REDIS_STREAM_ID_FIELD      = "id"
+	REDIS_STREAM_TYPE_FIELD    = "type"
+	REDIS_STREAM_PAYLOAD_FIELD = "payload"
+
+	HTTP_CONTENT_TYPE = "application/json"
+)
+
+const (
+	// OUTBOX_CONSUMER_SINK is the outbox consumer of the configured sink.
+	OUTBOX_CONSUMER_SINK = "sink"
+
+	// OUTBOX_CONSUMER_WEBHOOKS is the outbox consumer scheduling webhook deliveries.
+	OUTBOX_CONSUMER_WEBHOOKS = "webhooks"
+)
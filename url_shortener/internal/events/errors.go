@@ -0,0 +1,8 @@
+package events
+
+import "errors"
+
+var (
+	ErrUnknownSink      = errors.New("unknown events sink")
+	ErrDeliveryRejected = errors.New("events delivery rejected")
+)
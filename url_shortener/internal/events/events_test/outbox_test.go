@@ -0,0 +1,160 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/events"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+)
+
+// outboxRecord is an event of the in-memory outbox.
+type outboxRecord struct {
+	entry repository.OutboxEntry
+	next  time.Time
+	dead  bool
+}
+
+// outbox keeps the events of a single consumer in memory, oldest first.
+type outbox struct {
+	records []*outboxRecord
+}
+
+func newOutbox(ids ...string) *outbox {
+	o := &outbox{}
+	for _, id := range ids {
+		o.records = append(o.records, &outboxRecord{entry: repository.OutboxEntry{Event: entity.Event{ID: id}}})
+	}
+	return o
+}
+
+func (o *outbox) Add(ctx context.Context, consumers []string, events []entity.Event) error {
+	return nil
+}
+
+func (o *outbox) Claim(ctx context.Context, consumer string, limit int, lease time.Duration) ([]repository.OutboxEntry, error) {
+	var entries []repository.OutboxEntry
+	for _, record := range o.records {
+		if len(entries) < limit && !record.dead && !record.next.After(time.Now()) {
+			entries = append(entries, record.entry)
+		}
+	}
+	return entries, nil
+}
+
+func (o *outbox) Remove(ctx context.Context, consumer string, ids []string) error {
+	o.records = slices.DeleteFunc(o.records, func(record *outboxRecord) bool {
+		return slices.Contains(ids, record.entry.Event.ID)
+	})
+	return nil
+}
+
+func (o *outbox) Retry(ctx context.Context, consumer string, ids []string, next time.Time) error {
+	for _, record := range o.find(ids) {
+		record.entry.Attempts++
+		record.next = next
+	}
+	return nil
+}
+
+func (o *outbox) DeadLetter(ctx context.Context, consumer string, ids []string) error {
+	for _, record := range o.find(ids) {
+		record.entry.Attempts++
+		record.dead = true
+	}
+	return nil
+}
+
+func (o *outbox) find(ids []string) []*outboxRecord {
+	var records []*outboxRecord
+	for _, record := range o.records {
+		if slices.Contains(ids, record.entry.Event.ID) {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// sink rejects the events with the given IDs and records the others.
+type sink struct {
+	rejected  []string
+	delivered []string
+}
+
+func (s *sink) Publish(ctx context.Context, batch ...entity.Event) error {
+	for _, event := range batch {
+		if slices.Contains(s.rejected, event.ID) {
+			return events.ErrDeliveryRejected
+		}
+	}
+	for _, event := range batch {
+		s.delivered = append(s.delivered, event.ID)
+	}
+	return nil
+}
+
+// eventsConfig relays one event at a time.
+type eventsConfig struct {
+	config.IEventsConfig
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func (c eventsConfig) GetBatchSize() int                { return 1 }
+func (c eventsConfig) GetLease() time.Duration          { return time.Minute }
+func (c eventsConfig) GetMaxAttempts() int              { return c.maxAttempts }
+func (c eventsConfig) GetInitialBackoff() time.Duration { return c.backoff }
+func (c eventsConfig) GetMaxBackoff() time.Duration     { return time.Hour }
+
+func TestRelayRetriesRejectedEvents(t *testing.T) {
+	store := newOutbox("bad", "good")
+	target := &sink{rejected: []string{"bad"}}
+	relay := events.NewRelay(slog.Default(), store, events.OUTBOX_CONSUMER_SINK, target, eventsConfig{maxAttempts: 3, backoff: time.Hour})
+
+	if err := relay.Flush(context.Background()); !errors.Is(err, events.ErrDeliveryRejected) {
+		t.Fatalf("first flush: got %v, want the rejection", err)
+	}
+
+	// The rejected event waits for its backoff instead of holding back the next one.
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("second flush: %v", err)
+	}
+	if !slices.Equal(target.delivered, []string{"good"}) {
+		t.Errorf("delivered %v, want [good]", target.delivered)
+	}
+
+	bad := store.find([]string{"bad"})[0]
+	if bad.entry.Attempts != 1 || bad.dead || time.Until(bad.next) < 59*time.Minute {
+		t.Errorf("rejected event: attempts %d, dead %v, next in %v", bad.entry.Attempts, bad.dead, time.Until(bad.next))
+	}
+}
+
+func TestRelayDeadLettersAfterMaxAttempts(t *testing.T) {
+	store := newOutbox("bad", "good")
+	target := &sink{rejected: []string{"bad"}}
+	relay := events.NewRelay(slog.Default(), store, events.OUTBOX_CONSUMER_SINK, target, eventsConfig{maxAttempts: 2})
+
+	for range 2 {
+		if err := relay.Flush(context.Background()); !errors.Is(err, events.ErrDeliveryRejected) {
+			t.Fatalf("got %v, want the rejection", err)
+		}
+	}
+
+	bad := store.find([]string{"bad"})[0]
+	if !bad.dead || bad.entry.Attempts != 2 {
+		t.Errorf("rejected event: attempts %d, dead %v, want 2 attempts and dead", bad.entry.Attempts, bad.dead)
+	}
+
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("last flush: %v", err)
+	}
+	if !slices.Equal(target.delivered, []string{"good"}) {
+		t.Errorf("delivered %v, want [good]", target.delivered)
+	}
+}
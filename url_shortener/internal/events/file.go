@@ -0,0 +1,62 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+)
+
+// filePublisher appends events to a newline-delimited JSON file.
+type filePublisher struct {
+	logger *slog.Logger
+	mu     sync.Mutex
+	file   *os.File
+}
+
+// NewFilePublisher returns an IEventPublisher that appends events to an NDJSON file.
+//
+// Parameters:
+// - logger: the logger object.
+// - path: the path of the file, created if it does not exist.
+//
+// Returns:
+// - IEventPublisher: the file publisher.
+// - error: an error if the file could not be opened.
+func NewFilePublisher(logger *slog.Logger, path string) (IEventPublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filePublisher{logger: logger, file: file}, nil
+}
+
+// Publish implements IEventPublisher.
+//
+// The batch is written with a single write and synced to disk before
+// Publish returns.
+func (p *filePublisher) Publish(ctx context.Context, events ...entity.Event) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.file.Write(buffer.Bytes()); err != nil {
+		p.logger.Error("Failed to append events to file", slog.String("err", err.Error()))
+		return err
+	}
+
+	return p.file.Sync()
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+)
+
+// httpPublisher posts batches of events as a JSON array to an HTTP endpoint.
+type httpPublisher struct {
+	logger *slog.Logger
+	client *http.Client
+	url    string
+}
+
+// NewHTTPPublisher returns an IEventPublisher that posts batches of events to an HTTP endpoint.
+//
+// Parameters:
+// - logger: the logger object.
+// - url: the URL of the endpoint.
+// - timeout: the timeout of a single post.
+//
+// Returns:
+// - IEventPublisher: the HTTP publisher.
+func NewHTTPPublisher(logger *slog.Logger, url string, timeout time.Duration) IEventPublisher {
+	return &httpPublisher{logger: logger, client: &http.Client{Timeout: timeout}, url: url}
+}
+
+// Publish implements IEventPublisher.
+//
+// The batch is accepted only if the endpoint answers with a 2xx status.
+func (p *httpPublisher) Publish(ctx context.Context, events ...entity.Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", HTTP_CONTENT_TYPE)
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		p.logger.Error("Failed to post events", slog.String("url", p.url), slog.String("err", err.Error()))
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		p.logger.Error("Events rejected", slog.String("url", p.url), slog.Int("status", response.StatusCode))
+		return ErrDeliveryRejected
+	}
+
+	return nil
+}
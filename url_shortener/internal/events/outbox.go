@@ -0,0 +1,196 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+)
+
+// outboxPublisher stores events in the outbox instead of delivering them,
+// so that they survive restarts and sink outages.
+type outboxPublisher struct {
+	logger     *slog.Logger
+	repository repository.IOutboxRepository
+	consumers  []string
+}
+
+// NewOutboxPublisher returns an IEventPublisher that stores events in the
+// outbox, once for every consumer.
+//
+// Parameters:
+// - logger: the logger object.
+// - repository: the outbox repository.
+// - consumers: the consumers relaying the events, see NewRelay.
+//
+// Returns:
+// - IEventPublisher: the outbox publisher.
+func NewOutboxPublisher(logger *slog.Logger, repository repository.IOutboxRepository, consumers ...string) IEventPublisher {
+	return &outboxPublisher{logger: logger, repository: repository, consumers: consumers}
+}
+
+// Publish implements IEventPublisher.
+func (p *outboxPublisher) Publish(ctx context.Context, events ...entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return p.repository.Add(ctx, p.consumers, events)
+}
+
+// Relay moves the events of one outbox consumer to its sink.
+//
+// An event is removed from the outbox only after the sink accepted it, so
+// every event is delivered at least once. Consumers should deduplicate
+// events by their ID.
+//
+// Events are leased before delivery, so relays of several instances share
+// the outbox without delivering the same event. A rejected batch is retried
+// with exponential backoff and dead-lettered after the maximum number of
+// attempts, so it never holds back newer events. Every consumer has its own
+// copy of the events, so a failing sink does not delay the others.
+type Relay struct {
+	logger     *slog.Logger
+	repository repository.IOutboxRepository
+	consumer   string
+	sink       IEventPublisher
+	config     config.IEventsConfig
+}
+
+// NewRelay returns a Relay, which relays nothing until Run.
+//
+// Parameters:
+// - logger: the logger object.
+// - repository: the outbox repository.
+// - consumer: the outbox consumer whose events are relayed.
+// - sink: the publisher the events are delivered to.
+// - config: the events configuration.
+//
+// Returns:
+// - *Relay: the relay.
+func NewRelay(logger *slog.Logger, repository repository.IOutboxRepository, consumer string, sink IEventPublisher, config config.IEventsConfig) *Relay {
+	return &Relay{logger: logger, repository: repository, consumer: consumer, sink: sink, config: config}
+}
+
+// Run delivers pending events until the context is canceled.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.GetRelayInterval())
+	defer ticker.Stop()
+
+	for {
+		if err := r.Flush(ctx); err != nil {
+			r.logger.Error("Failed to relay events", slog.String("consumer", r.consumer), slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush delivers due events batch by batch until none is left or a batch
+// is rejected.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if reading the outbox or delivering a batch failed.
+func (r *Relay) Flush(ctx context.Context) error {
+	batchSize := r.config.GetBatchSize()
+
+	for {
+		entries, err := r.repository.Claim(ctx, r.consumer, batchSize, r.config.GetLease())
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		pending := make([]entity.Event, len(entries))
+		ids := make([]string, len(entries))
+		for i, entry := range entries {
+			pending[i] = entry.Event
+			ids[i] = entry.Event.ID
+		}
+
+		if err := r.sink.Publish(ctx, pending...); err != nil {
+			return errors.Join(err, r.fail(ctx, entries))
+		}
+
+		if err := r.repository.Remove(ctx, r.consumer, ids); err != nil {
+			return err
+		}
+
+		r.logger.Debug("Relayed events", slog.String("consumer", r.consumer), slog.Int("count", len(entries)))
+
+		if len(entries) < batchSize {
+			return nil
+		}
+	}
+}
+
+// fail reschedules the events of a rejected batch, dead-lettering those
+// that reached the maximum number of attempts.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - entries: the events of the batch.
+//
+// Returns:
+// - error: an error if the events could not be updated.
+func (r *Relay) fail(ctx context.Context, entries []repository.OutboxEntry) error {
+	var dead []string
+	retries := map[int][]string{}
+
+	for _, entry := range entries {
+		attempts := entry.Attempts + 1
+		if attempts >= r.config.GetMaxAttempts() {
+			dead = append(dead, entry.Event.ID)
+			continue
+		}
+
+		retries[attempts] = append(retries[attempts], entry.Event.ID)
+	}
+
+	if len(dead) > 0 {
+		r.logger.Error("Dead-lettered events", slog.String("consumer", r.consumer), slog.Any("ids", dead))
+
+		if err := r.repository.DeadLetter(ctx, r.consumer, dead); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for attempts, ids := range retries {
+		if err := r.repository.Retry(ctx, r.consumer, ids, now.Add(r.backoff(attempts))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the next attempt after the given
+// number of attempts.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.GetInitialBackoff()
+	maxDelay := r.config.GetMaxBackoff()
+
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
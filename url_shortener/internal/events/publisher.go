@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+type IEventPublisher interface {
+	// Publish delivers the events. Either all events are accepted or an error is returned.
+	Publish(ctx context.Context, events ...entity.Event) error
+}
+
+// NewSink returns the IEventPublisher selected in the events configuration.
+//
+// Parameters:
+// - logger: the logger object.
+// - eventsConfig: the events configuration.
+// - redisClient: the Redis client used by the Redis stream sink.
+//
+// Returns:
+// - IEventPublisher: the configured sink.
+// - error: an error if the sink could not be created.
+func NewSink(logger *slog.Logger, eventsConfig config.IEventsConfig, redisClient *redis.Client) (IEventPublisher, error) {
+	switch eventsConfig.GetSink() {
+	case config.EVENTS_SINK_NONE:
+		return NewNoopPublisher(), nil
+	case config.EVENTS_SINK_REDIS_STREAM:
+		return NewRedisStreamPublisher(logger, redisClient, eventsConfig.GetRedisStream(), eventsConfig.GetRedisStreamMaxLen()), nil
+	case config.EVENTS_SINK_FILE:
+		return NewFilePublisher(logger, eventsConfig.GetFilePath())
+	case config.EVENTS_SINK_HTTP:
+		return NewHTTPPublisher(logger, eventsConfig.GetHTTPURL(), eventsConfig.GetHTTPTimeout()), nil
+	default:
+		return nil, ErrUnknownSink
+	}
+}
+
+// noopPublisher discards events.
+type noopPublisher struct{}
+
+// NewNoopPublisher returns an IEventPublisher that discards events.
+func NewNoopPublisher() IEventPublisher {
+	return &noopPublisher{}
+}
+
+// Publish implements IEventPublisher.
+func (p *noopPublisher) Publish(ctx context.Context, events ...entity.Event) error {
+	return nil
+}
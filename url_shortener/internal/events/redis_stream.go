@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStreamPublisher appends events to a Redis stream.
+type redisStreamPublisher struct {
+	logger *slog.Logger
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamPublisher returns an IEventPublisher that appends events to a Redis stream.
+//
+// Parameters:
+// - logger: the logger object.
+// - client: the Redis client.
+// - stream: the name of the stream.
+// - maxLen: the approximate maximum length of the stream.
+//
+// Returns:
+// - IEventPublisher: the Redis stream publisher.
+func NewRedisStreamPublisher(logger *slog.Logger, client *redis.Client, stream string, maxLen int) IEventPublisher {
+	return &redisStreamPublisher{logger: logger, client: client, stream: stream, maxLen: int64(maxLen)}
+}
+
+// Publish implements IEventPublisher.
+//
+// Every event becomes a stream entry with its ID, type and JSON payload.
+// The entries are sent in a single pipeline.
+func (p *redisStreamPublisher) Publish(ctx context.Context, events ...entity.Event) error {
+	pipe := p.client.Pipeline()
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: p.stream,
+			MaxLen: p.maxLen,
+			Approx: true,
+			Values: map[string]any{
+				REDIS_STREAM_ID_FIELD:      event.ID,
+				REDIS_STREAM_TYPE_FIELD:    event.Type,
+				REDIS_STREAM_PAYLOAD_FIELD: payload,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		p.logger.Error("Failed to append events to stream", slog.String("stream", p.stream), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
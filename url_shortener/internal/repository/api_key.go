@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAPIKeyRepository interface {
+	// Create stores a new API key.
+	Create(ctx context.Context, key *entity.APIKey) error
+
+	// GetByHash returns the API key with the given token hash.
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+
+	// List returns the API keys, optionally of a single workspace, newest first.
+	List(ctx context.Context, workspaceID string) ([]*entity.APIKey, error)
+
+	// Revoke flags an API key as revoked, optionally only if it belongs to a workspace.
+	Revoke(ctx context.Context, workspaceID, id string, revokedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewAPIKeyRepository(logger *slog.Logger, database *mongo.Database) IAPIKeyRepository {
+	return &apiKeyRepository{logger: logger, collection: database.Collection(API_KEYS_COLLECTION)}
+}
+
+// Create stores a new API key.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - key: the key to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	if _, err := r.collection.InsertOne(ctx, key); err != nil {
+		r.logger.Error("error creating api key: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetByHash returns the API key with the given token hash.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - hash: the hex SHA-256 hash of the token.
+//
+// Returns:
+// - *entity.APIKey: the key, revoked or not.
+// - error: ErrNotFound if no key has this hash, or an error if the operation failed.
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error getting api key: " + err.Error())
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// List returns the API keys, newest first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the workspace of the keys, or empty for every key.
+//
+// Returns:
+// - []*entity.APIKey: the keys.
+// - error: an error if the operation failed.
+func (r *apiKeyRepository) List(ctx context.Context, workspaceID string) ([]*entity.APIKey, error) {
+	filter := bson.M{}
+	if workspaceID != "" {
+		filter["workspaceid"] = workspaceID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("error finding api keys: " + err.Error())
+		return nil, err
+	}
+
+	keys := []*entity.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		r.logger.Error("error decoding api keys: " + err.Error())
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Revoke flags an API key as revoked.
+//
+// Revoking a key twice keeps the time of the first revocation.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the workspace the key must belong to, or empty for any key.
+// - id: the ID of the key.
+// - revokedAt: the time of the revocation.
+//
+// Returns:
+// - error: ErrNotFound if the key does not exist, or an error if the operation failed.
+func (r *apiKeyRepository) Revoke(ctx context.Context, workspaceID, id string, revokedAt time.Time) error {
+	filter := bson.M{"_id": id}
+	if workspaceID != "" {
+		filter["workspaceid"] = workspaceID
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		filter,
+		bson.A{bson.M{"$set": bson.M{"revokedat": bson.M{"$ifNull": bson.A{"$revokedat", revokedAt}}}}},
+	)
+	if err != nil {
+		r.logger.Error("error revoking api key: " + err.Error())
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,505 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IClickRepository interface {
+	// Create stores a new click in the repository.
+	Create(ctx context.Context, click entity.IClick) error
+
+	// DeleteByShort deletes all raw and aggregated clicks of a shortened URL.
+	DeleteByShort(ctx context.Context, short string) error
+
+	// AggregateBefore folds raw clicks older than the given time into daily
+	// aggregates and deletes them.
+	AggregateBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// Stream calls fn for every raw click matching the filter, oldest first.
+	Stream(ctx context.Context, filter ClickFilter, fn func(entity.IClick) error) error
+
+	// Count returns the total number of clicks of a shortened URL, including aggregated ones.
+	Count(ctx context.Context, short string) (int64, error)
+
+	// CountByVariant returns the number of clicks of a shortened URL by
+	// split destination, including aggregated ones.
+	CountByVariant(ctx context.Context, short string) (map[string]int64, error)
+
+	// CountByShorts returns the number of clicks of several shortened URLs
+	// within the time range of the filter, including aggregated ones.
+	CountByShorts(ctx context.Context, filter ClickFilter, shorts []string) (map[string]int64, error)
+
+	// StreamAggregates calls fn for every daily aggregate matching the filter, oldest first.
+	StreamAggregates(ctx context.Context, filter ClickFilter, fn func(entity.ClickAggregate) error) error
+}
+
+// ClickFilter selects clicks by shortened URL and time range.
+//
+// Fields:
+// - Short: the shortened URL, or empty for all URLs.
+// - Shorts: the shortened URLs when Short is empty, or nil for all URLs.
+// - From: the inclusive lower time bound, or zero for no bound.
+// - To: the exclusive upper time bound, or zero for no bound.
+type ClickFilter struct {
+	Short  string
+	Shorts []string
+	From   time.Time
+	To     time.Time
+}
+
+// build converts the filter into a MongoDB query on the given time field.
+//
+// Parameters:
+// - timeField: the name of the document field holding the time.
+//
+// Returns:
+// - bson.M: the MongoDB query.
+func (f ClickFilter) build(timeField string) bson.M {
+	query := bson.M{}
+	if f.Short != "" {
+		query["short"] = f.Short
+	} else if f.Shorts != nil {
+		query["short"] = bson.M{"$in": f.Shorts}
+	}
+
+	bounds := bson.M{}
+	if !f.From.IsZero() {
+		bounds["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		bounds["$lt"] = f.To
+	}
+	if len(bounds) > 0 {
+		query[timeField] = bounds
+	}
+
+	return query
+}
+
+type clickRepository struct {
+	logger     *slog.Logger
+	clicks     *mongo.Collection
+	aggregates *mongo.Collection
+}
+
+func NewClickRepository(logger *slog.Logger, database *mongo.Database) IClickRepository {
+	return &clickRepository{
+		logger:     logger,
+		clicks:     database.Collection(CLICKS_COLLECTION),
+		aggregates: database.Collection(CLICK_AGGREGATES_COLLECTION),
+	}
+}
+
+// Create stores a new click in the repository.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - click: the click to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *clickRepository) Create(ctx context.Context, click entity.IClick) error {
+	if _, err := r.clicks.InsertOne(ctx, click); err != nil {
+		r.logger.Error("error creating click: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByShort deletes all raw and aggregated clicks of a shortened URL.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - short: the shortened URL whose clicks are deleted.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *clickRepository) DeleteByShort(ctx context.Context, short string) error {
+	filter := bson.M{"short": short}
+
+	if _, err := r.clicks.DeleteMany(ctx, filter); err != nil {
+		r.logger.Error("error deleting clicks: " + err.Error())
+		return err
+	}
+
+	if _, err := r.aggregates.DeleteMany(ctx, filter); err != nil {
+		r.logger.Error("error deleting click aggregates: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// AggregateBefore folds raw clicks older than the given time into daily
+// aggregates and deletes them.
+//
+// The clicks are first claimed with a run ID, then counted and deleted by
+// that ID. Every run keeps its own aggregates, raised with $max rather than
+// incremented, so a run that is repeated, e.g. by another replica or after
+// a crash between counting and deleting, never counts a click twice. Runs
+// claimed longer than CLICK_RUN_LEASE ago are resumed under their own ID.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - before: raw clicks created before this time are aggregated.
+//
+// Returns:
+// - int64: the number of raw clicks deleted.
+// - error: an error if the operation failed.
+func (r *clickRepository) AggregateBefore(ctx context.Context, before time.Time) (int64, error) {
+	now := time.Now()
+
+	stale, err := r.clicks.Distinct(ctx, "run", bson.M{"runat": bson.M{"$lt": now.Add(-CLICK_RUN_LEASE)}})
+	if err != nil {
+		r.logger.Error("error finding stale click runs: " + err.Error())
+		return 0, err
+	}
+
+	runs := make([]string, 0, len(stale)+1)
+	for _, run := range stale {
+		if id, ok := run.(string); ok {
+			runs = append(runs, id)
+		}
+	}
+
+	run := utils.NewID()
+	claim := bson.M{"createdat": bson.M{"$lt": before}, "run": bson.M{"$exists": false}}
+
+	claimed, err := r.clicks.UpdateMany(ctx, claim, bson.M{"$set": bson.M{"run": run, "runat": now}})
+	if err != nil {
+		r.logger.Error("error claiming clicks: " + err.Error())
+		return 0, err
+	}
+	if claimed.ModifiedCount > 0 {
+		runs = append(runs, run)
+	}
+
+	var deleted int64
+	for _, run := range runs {
+		count, err := r.aggregateRun(ctx, run)
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += count
+	}
+
+	return deleted, nil
+}
+
+// aggregateRun folds the raw clicks claimed by a run into its daily
+// aggregates and deletes them.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - run: the ID of the run.
+//
+// Returns:
+// - int64: the number of raw clicks deleted.
+// - error: an error if the operation failed.
+func (r *clickRepository) aggregateRun(ctx context.Context, run string) (int64, error) {
+	match := bson.M{"run": run}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"short":   "$short",
+				"day":     bson.M{"$dateTrunc": bson.M{"date": "$createdat", "unit": "day"}},
+				"variant": "$variant",
+			},
+			"clicks": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.clicks.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("error aggregating clicks: " + err.Error())
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var group struct {
+			ID struct {
+				Short   string    `bson:"short"`
+				Day     time.Time `bson:"day"`
+				Variant string    `bson:"variant"`
+			} `bson:"_id"`
+			Clicks int64 `bson:"clicks"`
+		}
+
+		if err := cursor.Decode(&group); err != nil {
+			r.logger.Error("error decoding click aggregate: " + err.Error())
+			return 0, err
+		}
+
+		// A run only loses clicks by deleting them, so its largest count is
+		// the full one, even if this run was resumed halfway through deleting.
+		filter := bson.M{"short": group.ID.Short, "day": group.ID.Day, "variant": group.ID.Variant, "run": run}
+		update := bson.M{"$max": bson.M{"clicks": group.Clicks}}
+
+		if _, err := r.aggregates.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+			r.logger.Error("error saving click aggregate: " + err.Error())
+			return 0, err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		r.logger.Error("error iterating click aggregates: " + err.Error())
+		return 0, err
+	}
+
+	result, err := r.clicks.DeleteMany(ctx, match)
+	if err != nil {
+		r.logger.Error("error deleting aggregated clicks: " + err.Error())
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// Stream calls fn for every raw click matching the filter, oldest first.
+//
+// Clicks are read through a cursor, so the result set is never loaded
+// into memory at once.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - filter: the filter selecting the clicks.
+// - fn: the function called for every click. Iteration stops on its first error.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *clickRepository) Stream(ctx context.Context, filter ClickFilter, fn func(entity.IClick) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}})
+
+	cursor, err := r.clicks.Find(ctx, filter.build("createdat"), opts)
+	if err != nil {
+		r.logger.Error("error finding clicks: " + err.Error())
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var click entity.Click
+		if err := cursor.Decode(&click); err != nil {
+			r.logger.Error("error decoding click: " + err.Error())
+			return err
+		}
+
+		if err := fn(&click); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// StreamAggregates calls fn for every daily aggregate matching the filter, oldest first.
+//
+// The aggregates of the retention runs are summed by shortened URL, day
+// and split destination.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - filter: the filter selecting the aggregates.
+// - fn: the function called for every aggregate. Iteration stops on its first error.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *clickRepository) StreamAggregates(ctx context.Context, filter ClickFilter, fn func(entity.ClickAggregate) error) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter.build("day")}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    bson.M{"short": "$short", "day": "$day", "variant": "$variant"},
+			"clicks": bson.M{"$sum": "$clicks"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":     0,
+			"short":   "$_id.short",
+			"day":     "$_id.day",
+			"variant": "$_id.variant",
+			"clicks":  1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "day", Value: 1}, {Key: "short", Value: 1}}}},
+	}
+
+	cursor, err := r.aggregates.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("error finding click aggregates: " + err.Error())
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var aggregate entity.ClickAggregate
+		if err := cursor.Decode(&aggregate); err != nil {
+			r.logger.Error("error decoding click aggregate: " + err.Error())
+			return err
+		}
+
+		if err := fn(aggregate); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// Count returns the total number of clicks of a shortened URL, including aggregated ones.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - short: the shortened URL.
+//
+// Returns:
+// - int64: the number of clicks.
+// - error: an error if the operation failed.
+func (r *clickRepository) Count(ctx context.Context, short string) (int64, error) {
+	raw, err := r.clicks.CountDocuments(ctx, bson.M{"short": short})
+	if err != nil {
+		r.logger.Error("error counting clicks: " + err.Error())
+		return 0, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"short": short}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "clicks": bson.M{"$sum": "$clicks"}}}},
+	}
+
+	cursor, err := r.aggregates.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("error counting aggregated clicks: " + err.Error())
+		return 0, err
+	}
+
+	var totals []struct {
+		Clicks int64 `bson:"clicks"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		r.logger.Error("error decoding aggregated clicks: " + err.Error())
+		return 0, err
+	}
+
+	if len(totals) > 0 {
+		raw += totals[0].Clicks
+	}
+
+	return raw, nil
+}
+
+// CountByShorts returns the number of clicks of several shortened URLs,
+// including aggregated ones.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - filter: the time range of the clicks, its Short is ignored.
+// - shorts: the shortened URLs.
+//
+// Returns:
+// - map[string]int64: the number of clicks by shortened URL, URLs without clicks are left out.
+// - error: an error if the operation failed.
+func (r *clickRepository) CountByShorts(ctx context.Context, filter ClickFilter, shorts []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(shorts))
+
+	sources := []struct {
+		collection *mongo.Collection
+		timeField  string
+		clicks     any
+	}{
+		{collection: r.clicks, timeField: "createdat", clicks: 1},
+		{collection: r.aggregates, timeField: "day", clicks: "$clicks"},
+	}
+
+	for _, source := range sources {
+		match := filter.build(source.timeField)
+		match["short"] = bson.M{"$in": shorts}
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: match}},
+			{{Key: "$group", Value: bson.M{"_id": "$short", "clicks": bson.M{"$sum": source.clicks}}}},
+		}
+
+		cursor, err := source.collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			r.logger.Error("error counting clicks: " + err.Error())
+			return nil, err
+		}
+
+		var totals []struct {
+			Short  string `bson:"_id"`
+			Clicks int64  `bson:"clicks"`
+		}
+		if err := cursor.All(ctx, &totals); err != nil {
+			r.logger.Error("error decoding clicks: " + err.Error())
+			return nil, err
+		}
+
+		for _, total := range totals {
+			counts[total.Short] += total.Clicks
+		}
+	}
+
+	return counts, nil
+}
+
+// CountByVariant returns the number of clicks of a shortened URL by split
+// destination, including aggregated ones.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - short: the shortened URL.
+//
+// Returns:
+// - map[string]int64: the number of clicks by split destination, clicks
+// without one being counted under an empty key.
+// - error: an error if the operation failed.
+func (r *clickRepository) CountByVariant(ctx context.Context, short string) (map[string]int64, error) {
+	counts := map[string]int64{}
+
+	sources := []struct {
+		collection *mongo.Collection
+		clicks     any
+	}{
+		{collection: r.clicks, clicks: 1},
+		{collection: r.aggregates, clicks: "$clicks"},
+	}
+
+	for _, source := range sources {
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"short": short}}},
+			{{Key: "$group", Value: bson.M{"_id": "$variant", "clicks": bson.M{"$sum": source.clicks}}}},
+		}
+
+		cursor, err := source.collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			r.logger.Error("error counting clicks: " + err.Error())
+			return nil, err
+		}
+
+		var totals []struct {
+			Variant string `bson:"_id"`
+			Clicks  int64  `bson:"clicks"`
+		}
+		if err := cursor.All(ctx, &totals); err != nil {
+			r.logger.Error("error decoding clicks: " + err.Error())
+			return nil, err
+		}
+
+		for _, total := range totals {
+			counts[total.Variant] += total.Clicks
+		}
+	}
+
+	return counts, nil
+}
@@ -1,5 +1,22 @@
 package repository
 
+import "time"
+
 const (
-	URLS_COLLECTION = "urls"
+	URLS_COLLECTION               = "urls"
+	CLICKS_COLLECTION             = "clicks"
+	CLICK_AGGREGATES_COLLECTION   = "click_aggregates"
+	OUTBOX_COLLECTION             = "outbox"
+	WEBHOOKS_COLLECTION           = "webhooks"
+	WEBHOOK_DELIVERIES_COLLECTION = "webhook_deliveries"
+	API_KEYS_COLLECTION           = "api_keys"
+	WORKSPACES_COLLECTION         = "workspaces"
+	MEMBERS_COLLECTION            = "workspace_members"
+	INVITATIONS_COLLECTION        = "workspace_invitations"
+	USAGE_COLLECTION              = "usage"
+	LINK_STATES_COLLECTION        = "link_states"
 )
+
+// CLICK_RUN_LEASE is how long a retention run keeps its claimed clicks
+// before another run resumes it.
+const CLICK_RUN_LEASE = time.Hour
@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("document not found")
+	ErrAlreadyExists = errors.New("document already exists")
+)
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IInvitationRepository interface {
+	// Create stores a new invitation.
+	Create(ctx context.Context, invitation *entity.Invitation) error
+
+	// GetByHash returns the invitation with the given token hash.
+	GetByHash(ctx context.Context, hash string) (*entity.Invitation, error)
+
+	// List returns the invitations of a workspace, newest first.
+	List(ctx context.Context, workspaceID string) ([]*entity.Invitation, error)
+
+	// Accept flags a pending invitation as accepted.
+	Accept(ctx context.Context, id string, acceptedAt time.Time) error
+
+	// Delete deletes an invitation of a workspace.
+	Delete(ctx context.Context, workspaceID, id string) error
+}
+
+type invitationRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewInvitationRepository(logger *slog.Logger, database *mongo.Database) IInvitationRepository {
+	return &invitationRepository{logger: logger, collection: database.Collection(INVITATIONS_COLLECTION)}
+}
+
+// Create stores a new invitation.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - invitation: the invitation to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *invitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	if _, err := r.collection.InsertOne(ctx, invitation); err != nil {
+		r.logger.Error("error creating invitation: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetByHash returns the invitation with the given token hash.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - hash: the hex SHA-256 hash of the token.
+//
+// Returns:
+// - *entity.Invitation: the invitation, pending or not.
+// - error: ErrNotFound if no invitation has this hash, or an error if the operation failed.
+func (r *invitationRepository) GetByHash(ctx context.Context, hash string) (*entity.Invitation, error) {
+	var invitation entity.Invitation
+	if err := r.collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&invitation); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error getting invitation: " + err.Error())
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// List returns the invitations of a workspace, newest first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - []*entity.Invitation: the invitations.
+// - error: an error if the operation failed.
+func (r *invitationRepository) List(ctx context.Context, workspaceID string) ([]*entity.Invitation, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"workspaceid": workspaceID}, opts)
+	if err != nil {
+		r.logger.Error("error finding invitations: " + err.Error())
+		return nil, err
+	}
+
+	invitations := []*entity.Invitation{}
+	if err := cursor.All(ctx, &invitations); err != nil {
+		r.logger.Error("error decoding invitations: " + err.Error())
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// Accept flags a pending invitation as accepted.
+//
+// An invitation is accepted once, even when its token is sent twice at the same time.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - id: the ID of the invitation.
+// - acceptedAt: the time of the acceptance.
+//
+// Returns:
+// - error: ErrNotFound if the invitation does not exist or was already accepted, or an error if the operation failed.
+func (r *invitationRepository) Accept(ctx context.Context, id string, acceptedAt time.Time) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "acceptedat": nil},
+		bson.M{"$set": bson.M{"acceptedat": acceptedAt}},
+	)
+	if err != nil {
+		r.logger.Error("error accepting invitation: " + err.Error())
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an invitation of a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+// - id: the ID of the invitation.
+//
+// Returns:
+// - error: ErrNotFound if the workspace has no such invitation, or an error if the operation failed.
+func (r *invitationRepository) Delete(ctx context.Context, workspaceID, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "workspaceid": workspaceID})
+	if err != nil {
+		r.logger.Error("error deleting invitation: " + err.Error())
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ILinkStateRepository interface {
+	// SetExpired records the expiration time link.expired is published for, returning the previous one.
+	SetExpired(ctx context.Context, short, expired string) (string, error)
+
+	// SetHealth records the health of the destination of a link, returning the previous one.
+	SetHealth(ctx context.Context, short, health string) (string, error)
+}
+
+type linkStateRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewLinkStateRepository(logger *slog.Logger, database *mongo.Database) ILinkStateRepository {
+	return &linkStateRepository{logger: logger, collection: database.Collection(LINK_STATES_COLLECTION)}
+}
+
+// SetExpired records the expiration time link.expired is published for.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - short: the shortened URL.
+// - expired: the expiration time of the schedule of the link.
+//
+// Returns:
+// - string: the expiration time recorded before, empty if none, so that
+// only the caller changing it publishes the event.
+// - error: an error if the operation failed.
+func (r *linkStateRepository) SetExpired(ctx context.Context, short, expired string) (string, error) {
+	previous, err := r.set(ctx, short, bson.M{"expired": expired})
+	if err != nil {
+		return "", err
+	}
+
+	return previous.Expired, nil
+}
+
+// SetHealth records the health of the destination of a link.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - short: the shortened URL.
+// - health: entity.DESTINATION_HEALTH_UP or entity.DESTINATION_HEALTH_DOWN.
+//
+// Returns:
+// - string: the health recorded before, empty if never checked, so that
+// only the caller changing it publishes the event.
+// - error: an error if the operation failed.
+func (r *linkStateRepository) SetHealth(ctx context.Context, short, health string) (string, error) {
+	previous, err := r.set(ctx, short, bson.M{"health": health, "checkedat": time.Now()})
+	if err != nil {
+		return "", err
+	}
+
+	return previous.Health, nil
+}
+
+// set updates the state of a link in a single operation, creating it if
+// needed, and returns the state before the update.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - short: the shortened URL.
+// - fields: the fields to set.
+//
+// Returns:
+// - *entity.LinkState: the previous state, empty for a new one.
+// - error: an error if the operation failed.
+func (r *linkStateRepository) set(ctx context.Context, short string, fields bson.M) (*entity.LinkState, error) {
+	var previous entity.LinkState
+
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": short},
+		bson.M{"$set": fields},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before),
+	).Decode(&previous)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		r.logger.Error("error updating link state: " + err.Error())
+		return nil, err
+	}
+
+	return &previous, nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IMemberRepository interface {
+	// Create stores a new member of a workspace.
+	Create(ctx context.Context, member *entity.Member) error
+
+	// Get returns the membership of a user in a workspace.
+	Get(ctx context.Context, workspaceID, userID string) (*entity.Member, error)
+
+	// List returns the members of a workspace.
+	List(ctx context.Context, workspaceID string) ([]*entity.Member, error)
+
+	// ListByUser returns the memberships of a user.
+	ListByUser(ctx context.Context, userID string) ([]*entity.Member, error)
+
+	// CountRole returns the number of members of a workspace with the given role.
+	CountRole(ctx context.Context, workspaceID, role string) (int64, error)
+
+	// UpdateRole changes the role of a member.
+	UpdateRole(ctx context.Context, workspaceID, userID, role string) error
+
+	// Delete removes a member from a workspace.
+	Delete(ctx context.Context, workspaceID, userID string) error
+}
+
+type memberRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewMemberRepository(logger *slog.Logger, database *mongo.Database) IMemberRepository {
+	return &memberRepository{logger: logger, collection: database.Collection(MEMBERS_COLLECTION)}
+}
+
+// Create stores a new member of a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - member: the member to store.
+//
+// Returns:
+// - error: ErrAlreadyExists if the user is already a member, or an error if the operation failed.
+func (r *memberRepository) Create(ctx context.Context, member *entity.Member) error {
+	if _, err := r.collection.InsertOne(ctx, member); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		r.logger.Error("error creating member: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the membership of a user in a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+// - userID: the user.
+//
+// Returns:
+// - *entity.Member: the membership.
+// - error: ErrNotFound if the user is not a member, or an error if the operation failed.
+func (r *memberRepository) Get(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+	var member entity.Member
+	if err := r.collection.FindOne(ctx, bson.M{"workspaceid": workspaceID, "userid": userID}).Decode(&member); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error getting member: " + err.Error())
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// List returns the members of a workspace, oldest first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - []*entity.Member: the members.
+// - error: an error if the operation failed.
+func (r *memberRepository) List(ctx context.Context, workspaceID string) ([]*entity.Member, error) {
+	return r.find(ctx, bson.M{"workspaceid": workspaceID})
+}
+
+// ListByUser returns the memberships of a user, oldest first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - userID: the user.
+//
+// Returns:
+// - []*entity.Member: the memberships.
+// - error: an error if the operation failed.
+func (r *memberRepository) ListByUser(ctx context.Context, userID string) ([]*entity.Member, error) {
+	return r.find(ctx, bson.M{"userid": userID})
+}
+
+// CountRole returns the number of members of a workspace with the given role.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+// - role: the role.
+//
+// Returns:
+// - int64: the number of members.
+// - error: an error if the operation failed.
+func (r *memberRepository) CountRole(ctx context.Context, workspaceID, role string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"workspaceid": workspaceID, "role": role})
+	if err != nil {
+		r.logger.Error("error counting members: " + err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdateRole changes the role of a member.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+// - userID: the user.
+// - role: the new role.
+//
+// Returns:
+// - error: ErrNotFound if the user is not a member, or an error if the operation failed.
+func (r *memberRepository) UpdateRole(ctx context.Context, workspaceID, userID, role string) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"workspaceid": workspaceID, "userid": userID},
+		bson.M{"$set": bson.M{"role": role}},
+	)
+	if err != nil {
+		r.logger.Error("error updating member: " + err.Error())
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a member from a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the ID of the workspace.
+// - userID: the user.
+//
+// Returns:
+// - error: ErrNotFound if the user is not a member, or an error if the operation failed.
+func (r *memberRepository) Delete(ctx context.Context, workspaceID, userID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"workspaceid": workspaceID, "userid": userID})
+	if err != nil {
+		r.logger.Error("error deleting member: " + err.Error())
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// find returns the members matching a filter, oldest first.
+func (r *memberRepository) find(ctx context.Context, filter bson.M) ([]*entity.Member, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("error finding members: " + err.Error())
+		return nil, err
+	}
+
+	members := []*entity.Member{}
+	if err := cursor.All(ctx, &members); err != nil {
+		r.logger.Error("error decoding members: " + err.Error())
+		return nil, err
+	}
+
+	return members, nil
+}
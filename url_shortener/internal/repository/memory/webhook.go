@@ -0,0 +1,183 @@
+// Package memory keeps repositories in memory, for the tests of the
+// services and handlers using them.
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+)
+
+// WebhookStore keeps webhooks and their deliveries in memory.
+//
+// It implements repository.IWebhookRepository, NewDeliveryStore adapts it to
+// repository.IWebhookDeliveryRepository. The maps are exported so that tests
+// can seed and inspect them, and are not safe for concurrent use.
+type WebhookStore struct {
+	Webhooks   map[string]*entity.Webhook
+	Deliveries map[string]*entity.WebhookDelivery
+}
+
+// NewWebhookStore returns a store holding the given webhooks and no delivery.
+//
+// Parameters:
+// - webhooks: the webhooks to store.
+//
+// Returns:
+// - *WebhookStore: the store.
+func NewWebhookStore(webhooks ...*entity.Webhook) *WebhookStore {
+	store := &WebhookStore{Webhooks: map[string]*entity.Webhook{}, Deliveries: map[string]*entity.WebhookDelivery{}}
+	for _, webhook := range webhooks {
+		store.Webhooks[webhook.ID] = webhook
+	}
+
+	return store
+}
+
+// Create stores a new webhook.
+func (s *WebhookStore) Create(ctx context.Context, webhook *entity.Webhook) error {
+	s.Webhooks[webhook.ID] = webhook
+	return nil
+}
+
+// GetByID returns a copy of a webhook by its ID.
+func (s *WebhookStore) GetByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	if webhook, ok := s.Webhooks[id]; ok {
+		copied := *webhook
+		return &copied, nil
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+// List returns copies of the webhooks of an owner, or of every owner.
+func (s *WebhookStore) List(ctx context.Context, ownerID string) ([]*entity.Webhook, error) {
+	webhooks := []*entity.Webhook{}
+	for _, webhook := range s.Webhooks {
+		if ownerID == "" || webhook.OwnerID == ownerID {
+			copied := *webhook
+			webhooks = append(webhooks, &copied)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// Delete deletes a webhook by its ID.
+func (s *WebhookStore) Delete(ctx context.Context, id string) error {
+	if _, ok := s.Webhooks[id]; !ok {
+		return repository.ErrNotFound
+	}
+
+	delete(s.Webhooks, id)
+	return nil
+}
+
+// FindSubscribed returns the webhooks subscribed to the event type for the
+// given short URL, or for every link of the owner.
+func (s *WebhookStore) FindSubscribed(ctx context.Context, eventType, short, ownerID string) ([]*entity.Webhook, error) {
+	webhooks := []*entity.Webhook{}
+	for _, webhook := range s.Webhooks {
+		if !webhook.Subscribes(eventType) {
+			continue
+		}
+		if webhook.Short == short || (webhook.Short == "" && webhook.OwnerID == ownerID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// ListSubscribed returns the webhooks of every owner subscribed to the event type.
+func (s *WebhookStore) ListSubscribed(ctx context.Context, eventType string) ([]*entity.Webhook, error) {
+	webhooks := []*entity.Webhook{}
+	for _, webhook := range s.Webhooks {
+		if webhook.Subscribes(eventType) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// MarkThresholdReached flags the click threshold of a webhook as reached,
+// reporting whether this call did.
+func (s *WebhookStore) MarkThresholdReached(ctx context.Context, id string) (bool, error) {
+	webhook, ok := s.Webhooks[id]
+	if !ok || webhook.ThresholdReached {
+		return false, nil
+	}
+
+	webhook.ThresholdReached = true
+	return true, nil
+}
+
+// DeliveryStore adapts a WebhookStore to repository.IWebhookDeliveryRepository.
+//
+// Deliveries are kept as given, so that tests see the state the services
+// leave them in without Update.
+type DeliveryStore struct {
+	store *WebhookStore
+}
+
+// NewDeliveryStore returns the deliveries of a webhook store.
+//
+// Parameters:
+// - store: the store keeping the deliveries.
+//
+// Returns:
+// - DeliveryStore: the deliveries of the store.
+func NewDeliveryStore(store *WebhookStore) DeliveryStore {
+	return DeliveryStore{store: store}
+}
+
+// Create stores a new delivery. Storing a delivery with an existing ID is a no-op.
+func (s DeliveryStore) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if _, ok := s.store.Deliveries[delivery.ID]; !ok {
+		s.store.Deliveries[delivery.ID] = delivery
+	}
+
+	return nil
+}
+
+// GetByID returns a delivery by its ID.
+func (s DeliveryStore) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	if delivery, ok := s.store.Deliveries[id]; ok {
+		return delivery, nil
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+// ListByWebhook returns the deliveries of a webhook.
+func (s DeliveryStore) ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*entity.WebhookDelivery, error) {
+	deliveries := []*entity.WebhookDelivery{}
+	for _, delivery := range s.store.Deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+// Due returns every pending delivery, whatever its next attempt, so that
+// the tests need not wait for the backoff.
+func (s DeliveryStore) Due(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	due := []*entity.WebhookDelivery{}
+	for _, delivery := range s.store.Deliveries {
+		if delivery.Status == entity.DELIVERY_STATUS_PENDING {
+			due = append(due, delivery)
+		}
+	}
+
+	return due, nil
+}
+
+// Update saves the state of a delivery, which is already the stored one.
+func (s DeliveryStore) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	return nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IOutboxRepository interface {
+	// Add stores events waiting to be delivered, once for every consumer.
+	Add(ctx context.Context, consumers []string, events []entity.Event) error
+
+	// Claim leases up to limit due events of a consumer, oldest first.
+	Claim(ctx context.Context, consumer string, limit int, lease time.Duration) ([]OutboxEntry, error)
+
+	// Remove deletes delivered events of a consumer by their IDs.
+	Remove(ctx context.Context, consumer string, ids []string) error
+
+	// Retry counts a failed attempt of events of a consumer and releases them until the given time.
+	Retry(ctx context.Context, consumer string, ids []string, next time.Time) error
+
+	// DeadLetter stops delivering events of a consumer, keeping them for inspection.
+	DeadLetter(ctx context.Context, consumer string, ids []string) error
+}
+
+// OutboxEntry is an event claimed from the outbox.
+//
+// Fields:
+// - Event: the event.
+// - Attempts: the number of failed attempts to deliver the event.
+type OutboxEntry struct {
+	Event    entity.Event
+	Attempts int
+}
+
+// outboxRecord is the stored form of an event waiting to be delivered to a consumer.
+type outboxRecord struct {
+	ID            string       `bson:"_id"`
+	Consumer      string       `bson:"consumer"`
+	Event         entity.Event `bson:"event"`
+	Attempts      int          `bson:"attempts"`
+	NextAttemptAt time.Time    `bson:"nextattemptat"`
+	LeasedUntil   time.Time    `bson:"leaseduntil"`
+	Dead          bool         `bson:"dead"`
+}
+
+type outboxRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(logger *slog.Logger, database *mongo.Database) IOutboxRepository {
+	return &outboxRepository{logger: logger, collection: database.Collection(OUTBOX_COLLECTION)}
+}
+
+// CreateOutboxIndexes creates the indexes of the outbox collection, if missing.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - database: the database holding the collection.
+//
+// Returns:
+// - error: an error if the index cannot be created.
+func CreateOutboxIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection(OUTBOX_COLLECTION).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "consumer", Value: 1}, {Key: "dead", Value: 1}, {Key: "event.occurredat", Value: 1}},
+	})
+
+	return err
+}
+
+// Add stores events waiting to be delivered, once for every consumer, so
+// that every consumer delivers, retries and gives up on its own.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - consumers: the consumers the events are delivered to.
+// - events: the events to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *outboxRepository) Add(ctx context.Context, consumers []string, events []entity.Event) error {
+	documents := make([]any, 0, len(consumers)*len(events))
+	for _, consumer := range consumers {
+		for _, event := range events {
+			documents = append(documents, outboxRecord{ID: consumer + ":" + event.ID, Consumer: consumer, Event: event})
+		}
+	}
+
+	if len(documents) == 0 {
+		return nil
+	}
+
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		r.logger.Error("error adding events to outbox: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Claim leases up to limit due events of a consumer, oldest first.
+//
+// Every event is leased on its own with FindOneAndUpdate, so relays of
+// several instances never claim the same event. An event whose lease
+// expired, e.g. because its relay stopped, can be claimed again.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - consumer: the consumer of the events.
+// - limit: the maximum number of events to claim.
+// - lease: how long the claimed events are kept from other relays.
+//
+// Returns:
+// - []OutboxEntry: the claimed events.
+// - error: an error if the operation failed.
+func (r *outboxRepository) Claim(ctx context.Context, consumer string, limit int, lease time.Duration) ([]OutboxEntry, error) {
+	now := time.Now()
+
+	filter := bson.M{
+		"consumer":      consumer,
+		"dead":          false,
+		"nextattemptat": bson.M{"$lte": now},
+		"leaseduntil":   bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"leaseduntil": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "event.occurredat", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var entries []OutboxEntry
+	for len(entries) < limit {
+		var record outboxRecord
+		if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&record); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break
+			}
+
+			r.logger.Error("error claiming pending events: " + err.Error())
+			return entries, err
+		}
+
+		entries = append(entries, OutboxEntry{Event: record.Event, Attempts: record.Attempts})
+	}
+
+	return entries, nil
+}
+
+// Remove deletes delivered events of a consumer by their IDs.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - consumer: the consumer of the events.
+// - ids: the IDs of the delivered events.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *outboxRepository) Remove(ctx context.Context, consumer string, ids []string) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"consumer": consumer, "event.id": bson.M{"$in": ids}}); err != nil {
+		r.logger.Error("error removing delivered events: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Retry counts a failed attempt of events of a consumer and releases
+// them until the given time.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - consumer: the consumer of the events.
+// - ids: the IDs of the events.
+// - next: the time of the next attempt.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *outboxRepository) Retry(ctx context.Context, consumer string, ids []string, next time.Time) error {
+	filter := bson.M{"consumer": consumer, "event.id": bson.M{"$in": ids}}
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"nextattemptat": next, "leaseduntil": time.Time{}},
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("error rescheduling events: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeadLetter stops delivering events of a consumer, keeping them in the
+// outbox with dead set for inspection.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - consumer: the consumer of the events.
+// - ids: the IDs of the events.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *outboxRepository) DeadLetter(ctx context.Context, consumer string, ids []string) error {
+	filter := bson.M{"consumer": consumer, "event.id": bson.M{"$in": ids}}
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"dead": true, "leaseduntil": time.Time{}},
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("error dead-lettering events: " + err.Error())
+		return err
+	}
+
+	return nil
+}
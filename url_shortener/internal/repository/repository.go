@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"log/slog"
 
 	"github.com/flew1x/url_shortener_ms/internal/config"
@@ -8,11 +9,47 @@ import (
 )
 
 type Repository struct {
-	UrlRepository IURLRepository
+	UrlRepository        IURLRepository
+	ClickRepository      IClickRepository
+	OutboxRepository     IOutboxRepository
+	WebhookRepository    IWebhookRepository
+	DeliveryRepository   IWebhookDeliveryRepository
+	APIKeyRepository     IAPIKeyRepository
+	WorkspaceRepository  IWorkspaceRepository
+	MemberRepository     IMemberRepository
+	InvitationRepository IInvitationRepository
+	UsageRepository      IUsageRepository
+	LinkStateRepository  ILinkStateRepository
 }
 
 func NewRepository(logger *slog.Logger, config *config.Config, database *mongo.Database) *Repository {
 	return &Repository{
-		UrlRepository: NewURLRepository(logger, config.URLConfig, database),
+		UrlRepository:        NewURLRepository(logger, config.URLConfig, database),
+		ClickRepository:      NewClickRepository(logger, database),
+		OutboxRepository:     NewOutboxRepository(logger, database),
+		WebhookRepository:    NewWebhookRepository(logger, database),
+		DeliveryRepository:   NewWebhookDeliveryRepository(logger, database),
+		APIKeyRepository:     NewAPIKeyRepository(logger, database),
+		WorkspaceRepository:  NewWorkspaceRepository(logger, database),
+		MemberRepository:     NewMemberRepository(logger, database),
+		InvitationRepository: NewInvitationRepository(logger, database),
+		UsageRepository:      NewUsageRepository(logger, database),
+		LinkStateRepository:  NewLinkStateRepository(logger, database),
 	}
 }
+
+// EnsureIndexes creates the indexes the repositories rely on, if missing.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - database: the database of the repositories.
+//
+// Returns:
+// - error: an error if an index cannot be created.
+func EnsureIndexes(ctx context.Context, database *mongo.Database) error {
+	if err := CreateURLIndexes(ctx, database); err != nil {
+		return err
+	}
+
+	return CreateOutboxIndexes(ctx, database)
+}
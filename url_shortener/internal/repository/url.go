@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/flew1x/url_shortener_ms/internal/config"
 	"github.com/flew1x/url_shortener_ms/internal/entity"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type IURLRepository interface {
@@ -20,6 +22,18 @@ type IURLRepository interface {
 	// GetByShort returns a URL from the repository by its short.
 	GetByShort(ctx context.Context, short string) (entity.IURL, error)
 
+	// GetByShorts returns the URLs from the repository matching any of the shorts.
+	GetByShorts(ctx context.Context, shorts []string) ([]entity.IURL, error)
+
+	// ListWithUTM returns the URLs with campaign parameters, optionally of a single owner and origin.
+	ListWithUTM(ctx context.Context, ownerID, origin string) ([]entity.IURL, error)
+
+	// ListByOwner returns the URLs of an owner.
+	ListByOwner(ctx context.Context, ownerID string) ([]entity.IURL, error)
+
+	// ListEnabled returns a page of the URLs that are not disabled, ordered by short.
+	ListEnabled(ctx context.Context, after string, limit int64) ([]entity.IURL, error)
+
 	// DeleteByID deletes a URL from the repository by its ID.
 	Delete(ctx context.Context, short string) error
 
@@ -37,6 +51,27 @@ func NewURLRepository(logger *slog.Logger, config config.IURLConfig, database *m
 	return &urlRepository{logger: logger, config: config, collection: database.Collection(URLS_COLLECTION)}
 }
 
+// CreateURLIndexes creates the indexes of the URLs collection, if missing.
+//
+// The unique index on short makes inserting a URL the only way to claim
+// its code.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - database: the database holding the collection.
+//
+// Returns:
+// - error: an error if the index cannot be created, e.g. because of
+// duplicate shorts stored before it existed.
+func CreateURLIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection(URLS_COLLECTION).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "short", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return err
+}
+
 // Create creates a new URL in the repository.
 //
 // Parameters:
@@ -44,12 +79,15 @@ func NewURLRepository(logger *slog.Logger, config config.IURLConfig, database *m
 // - url: the URL to create in the repository.
 //
 // Returns:
-// - error: an error if the operation failed.
+// - error: ErrAlreadyExists if a URL has the same short, or an error if the operation failed.
 func (l *urlRepository) Create(ctx context.Context, url entity.IURL) error {
 	l.logger.Debug("Creating URL in repository", "origin", url.GetOrigin(), "short", url.GetShort())
 
 	_, err := l.collection.InsertOne(ctx, url)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
 		l.logger.Error("Error creating URL in repository: " + err.Error())
 		return err
 	}
@@ -66,14 +104,18 @@ func (l *urlRepository) Create(ctx context.Context, url entity.IURL) error {
 // - short: the shortened URL to delete.
 //
 // Returns:
-// - error: an error if the operation failed.
+// - error: ErrNotFound if the URL does not exist, or an error if the operation failed.
 func (l *urlRepository) Delete(ctx context.Context, short string) error {
-	_, err := l.collection.DeleteOne(ctx, entity.URL{Short: short})
+	result, err := l.collection.DeleteOne(ctx, bson.M{"short": short})
 	if err != nil {
 		l.logger.Error("error deleting url: " + err.Error())
 		return err
 	}
 
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
 	return nil
 }
 
@@ -85,16 +127,19 @@ func (l *urlRepository) Delete(ctx context.Context, short string) error {
 //
 // Returns:
 // - entity.URL: the URL retrieved from the repository.
-// - error: an error if the operation failed.
+// - error: ErrNotFound if no URL has the origin, or an error if the operation failed.
 func (l *urlRepository) GetByOrigin(ctx context.Context, origin string) (entity.IURL, error) {
-	var url entity.IURL
-	err := l.collection.FindOne(ctx, entity.URL{Origin: origin}).Decode(&url)
+	var url entity.URL
+	err := l.collection.FindOne(ctx, bson.M{"origin": origin}).Decode(&url)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
 		l.logger.Error("error getting url: " + err.Error())
 		return nil, err
 	}
 
-	return url, nil
+	return &url, nil
 }
 
 // GetByShort retrieves a URL from the repository by its short.
@@ -105,21 +150,121 @@ func (l *urlRepository) GetByOrigin(ctx context.Context, origin string) (entity.
 //
 // Returns:
 // - entity.URL: the URL retrieved from the repository.
-// - error: an error if the operation failed.
+// - error: ErrNotFound if the URL does not exist, or an error if the operation failed.
 func (l *urlRepository) GetByShort(ctx context.Context, short string) (entity.IURL, error) {
-	var url entity.IURL
-	filter := bson.M{"short ": short}
+	var url entity.URL
+	filter := bson.M{"short": short}
 	l.logger.Debug("Getting URL by short " + short)
 
 	err := l.collection.FindOne(ctx, filter).Decode(&url)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
 		l.logger.Error("error getting url " + err.Error())
 		return nil, err
 	}
 
 	l.logger.Debug("Retrieved URL " + url.GetOrigin())
 
-	return url, nil
+	return &url, nil
+}
+
+// GetByShorts retrieves the URLs matching any of the given shorts.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - shorts: the shortened URLs to retrieve from the repository.
+//
+// Returns:
+// - []entity.IURL: the URLs found, in no particular order.
+// - error: an error if the operation failed.
+func (l *urlRepository) GetByShorts(ctx context.Context, shorts []string) ([]entity.IURL, error) {
+	return l.find(ctx, bson.M{"short": bson.M{"$in": shorts}})
+}
+
+// ListWithUTM retrieves the URLs with campaign parameters.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ownerID: the owner of the URLs, or empty for every owner.
+// - origin: the original URL, or empty for every origin.
+//
+// Returns:
+// - []entity.IURL: the URLs found.
+// - error: an error if the operation failed.
+func (l *urlRepository) ListWithUTM(ctx context.Context, ownerID, origin string) ([]entity.IURL, error) {
+	filter := bson.M{"utm": bson.M{"$ne": nil}}
+	if ownerID != "" {
+		filter["ownerid"] = ownerID
+	}
+	if origin != "" {
+		filter["origin"] = origin
+	}
+
+	return l.find(ctx, filter)
+}
+
+// ListByOwner retrieves the URLs of an owner.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ownerID: the owner of the URLs.
+//
+// Returns:
+// - []entity.IURL: the URLs found.
+// - error: an error if the operation failed.
+func (l *urlRepository) ListByOwner(ctx context.Context, ownerID string) ([]entity.IURL, error) {
+	return l.find(ctx, bson.M{"ownerid": ownerID})
+}
+
+// ListEnabled retrieves a page of the URLs that are not disabled.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - after: the short of the last URL of the previous page, or empty for the first page.
+// - limit: the maximum number of URLs to return.
+//
+// Returns:
+// - []entity.IURL: the URLs found, ordered by short, fewer than limit on the last page.
+// - error: an error if the operation failed.
+func (l *urlRepository) ListEnabled(ctx context.Context, after string, limit int64) ([]entity.IURL, error) {
+	filter := bson.M{"disabled": bson.M{"$ne": true}}
+	if after != "" {
+		filter["short"] = bson.M{"$gt": after}
+	}
+
+	return l.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "short", Value: 1}}).SetLimit(limit))
+}
+
+// find retrieves the URLs matching a MongoDB query.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - filter: the MongoDB query.
+// - opts: the sort, limit and other options of the query, if any.
+//
+// Returns:
+// - []entity.IURL: the URLs found.
+// - error: an error if the operation failed.
+func (l *urlRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]entity.IURL, error) {
+	cursor, err := l.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		l.logger.Error("error getting urls " + err.Error())
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var urls []entity.IURL
+	for cursor.Next(ctx) {
+		var url entity.URL
+		if err := cursor.Decode(&url); err != nil {
+			return nil, err
+		}
+		urls = append(urls, &url)
+	}
+
+	return urls, cursor.Err()
 }
 
 // Update updates a URL in the repository by its ID.
@@ -129,13 +274,17 @@ func (l *urlRepository) GetByShort(ctx context.Context, short string) (entity.IU
 // - url: the URL to update in the repository.
 //
 // Returns:
-// - error: an error if the operation failed.
+// - error: ErrNotFound if the URL does not exist, or an error if the operation failed.
 func (l *urlRepository) Update(ctx context.Context, url entity.IURL) error {
-	_, err := l.collection.UpdateOne(ctx, entity.URL{Short: url.GetShort()}, url)
+	result, err := l.collection.ReplaceOne(ctx, bson.M{"short": url.GetShort()}, url)
 	if err != nil {
 		l.logger.Error("error updating url: " + err.Error())
 		return err
 	}
 
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
 	return nil
 }
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IUsageRepository interface {
+	// Save stores the counts of usages, keeping the highest counts already stored.
+	Save(ctx context.Context, usages []*entity.Usage) error
+
+	// List returns the usages of an account during the periods starting with a prefix.
+	List(ctx context.Context, account, periodPrefix string) ([]*entity.Usage, error)
+}
+
+type usageRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewUsageRepository(logger *slog.Logger, database *mongo.Database) IUsageRepository {
+	return &usageRepository{logger: logger, collection: database.Collection(USAGE_COLLECTION)}
+}
+
+// Save stores the counts of usages.
+//
+// Counts only grow, so the highest of the stored and the given counts is
+// kept, saving the same usage twice or out of order being harmless.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - usages: the usages.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *usageRepository) Save(ctx context.Context, usages []*entity.Usage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, len(usages))
+	for i, usage := range usages {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": usage.ID}).
+			SetUpdate(bson.M{
+				"$max": bson.M{"links": usage.Links, "redirects": usage.Redirects},
+				"$set": bson.M{"account": usage.Account, "period": usage.Period, "updatedat": now},
+			}).
+			SetUpsert(true)
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		r.logger.Error("error saving usage: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// List returns the usages of an account during the periods starting with
+// a prefix, e.g. a month and its days.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account.
+// - periodPrefix: the start of the periods, e.g. "2006-01".
+//
+// Returns:
+// - []*entity.Usage: the usages, by period.
+// - error: an error if the operation failed.
+func (r *usageRepository) List(ctx context.Context, account, periodPrefix string) ([]*entity.Usage, error) {
+	filter := bson.M{
+		"account": account,
+		"period":  bson.M{"$regex": "^" + regexp.QuoteMeta(periodPrefix)},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "period", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("error finding usage: " + err.Error())
+		return nil, err
+	}
+
+	usages := []*entity.Usage{}
+	if err := cursor.All(ctx, &usages); err != nil {
+		r.logger.Error("error decoding usage: " + err.Error())
+		return nil, err
+	}
+
+	return usages, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IWebhookRepository interface {
+	// Create stores a new webhook.
+	Create(ctx context.Context, webhook *entity.Webhook) error
+
+	// GetByID returns a webhook by its ID.
+	GetByID(ctx context.Context, id string) (*entity.Webhook, error)
+
+	// List returns the webhooks of an owner, or of every owner, newest first.
+	List(ctx context.Context, ownerID string) ([]*entity.Webhook, error)
+
+	// Delete deletes a webhook by its ID.
+	Delete(ctx context.Context, id string) error
+
+	// FindSubscribed returns the webhooks subscribed to the event type for the given short URL of an owner.
+	FindSubscribed(ctx context.Context, eventType, short, ownerID string) ([]*entity.Webhook, error)
+
+	// ListSubscribed returns the webhooks of every owner subscribed to the event type.
+	ListSubscribed(ctx context.Context, eventType string) ([]*entity.Webhook, error)
+
+	// MarkThresholdReached flags the click threshold of a webhook as reached.
+	MarkThresholdReached(ctx context.Context, id string) (bool, error)
+}
+
+type webhookRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository(logger *slog.Logger, database *mongo.Database) IWebhookRepository {
+	return &webhookRepository{logger: logger, collection: database.Collection(WEBHOOKS_COLLECTION)}
+}
+
+// Create stores a new webhook.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - webhook: the webhook to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	if _, err := r.collection.InsertOne(ctx, webhook); err != nil {
+		r.logger.Error("error creating webhook: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetByID returns a webhook by its ID.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - id: the ID of the webhook.
+//
+// Returns:
+// - *entity.Webhook: the webhook.
+// - error: ErrNotFound if the webhook does not exist, or an error if the operation failed.
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error getting webhook: " + err.Error())
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// List returns the webhooks of an owner, or of every owner, newest first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ownerID: the owner of the webhooks, or empty for every owner.
+//
+// Returns:
+// - []*entity.Webhook: the webhooks.
+// - error: an error if the operation failed.
+func (r *webhookRepository) List(ctx context.Context, ownerID string) ([]*entity.Webhook, error) {
+	filter := bson.M{}
+	if ownerID != "" {
+		filter["ownerid"] = ownerID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// Delete deletes a webhook by its ID.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - id: the ID of the webhook.
+//
+// Returns:
+// - error: ErrNotFound if the webhook does not exist, or an error if the operation failed.
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		r.logger.Error("error deleting webhook: " + err.Error())
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FindSubscribed returns the webhooks subscribed to the event type for the given short URL of an owner.
+//
+// Webhooks registered for every link are included if they belong to the
+// owner of the link, so that owners are only told about their own links.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - eventType: the type of the event.
+// - short: the shortened URL the event is about.
+// - ownerID: the owner of the shortened URL.
+//
+// Returns:
+// - []*entity.Webhook: the subscribed webhooks.
+// - error: an error if the operation failed.
+func (r *webhookRepository) FindSubscribed(ctx context.Context, eventType, short, ownerID string) ([]*entity.Webhook, error) {
+	filter := bson.M{
+		"events": eventType,
+		"$or": []bson.M{
+			{"short": short},
+			{"short": "", "ownerid": ownerID},
+		},
+	}
+
+	return r.find(ctx, filter)
+}
+
+// ListSubscribed returns the webhooks of every owner subscribed to the event type.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - eventType: the type of the event.
+//
+// Returns:
+// - []*entity.Webhook: the subscribed webhooks.
+// - error: an error if the operation failed.
+func (r *webhookRepository) ListSubscribed(ctx context.Context, eventType string) ([]*entity.Webhook, error) {
+	return r.find(ctx, bson.M{"events": eventType})
+}
+
+// MarkThresholdReached flags the click threshold of a webhook as reached.
+//
+// Only the first call for a webhook succeeds, so the threshold event
+// fires once even when clicks are recorded concurrently.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - id: the ID of the webhook.
+//
+// Returns:
+// - bool: true if this call flagged the threshold.
+// - error: an error if the operation failed.
+func (r *webhookRepository) MarkThresholdReached(ctx context.Context, id string) (bool, error) {
+	filter := bson.M{"_id": id, "thresholdreached": false}
+	update := bson.M{"$set": bson.M{"thresholdreached": true}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("error marking webhook threshold: " + err.Error())
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// find returns the webhooks matching the filter.
+func (r *webhookRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*entity.Webhook, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		r.logger.Error("error finding webhooks: " + err.Error())
+		return nil, err
+	}
+
+	webhooks := []*entity.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		r.logger.Error("error decoding webhooks: " + err.Error())
+		return nil, err
+	}
+
+	return webhooks, nil
+}
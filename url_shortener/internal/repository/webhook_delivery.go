@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IWebhookDeliveryRepository interface {
+	// Create stores a new delivery. Storing a delivery with an existing ID is a no-op.
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// GetByID returns a delivery by its ID.
+	GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+
+	// ListByWebhook returns the deliveries of a webhook, newest first.
+	ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*entity.WebhookDelivery, error)
+
+	// Due returns up to limit pending deliveries whose next attempt is due.
+	Due(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+
+	// Update saves the state of a delivery.
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
+
+type webhookDeliveryRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(logger *slog.Logger, database *mongo.Database) IWebhookDeliveryRepository {
+	return &webhookDeliveryRepository{logger: logger, collection: database.Collection(WEBHOOK_DELIVERIES_COLLECTION)}
+}
+
+// Create stores a new delivery. Storing a delivery with an existing ID is a no-op.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - delivery: the delivery to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	_, err := r.collection.InsertOne(ctx, delivery)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		r.logger.Error("error creating webhook delivery: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetByID returns a delivery by its ID.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - id: the ID of the delivery.
+//
+// Returns:
+// - *entity.WebhookDelivery: the delivery.
+// - error: ErrNotFound if the delivery does not exist, or an error if the operation failed.
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error getting webhook delivery: " + err.Error())
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// ListByWebhook returns the deliveries of a webhook, newest first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - webhookID: the ID of the webhook.
+// - limit: the maximum number of deliveries to return.
+//
+// Returns:
+// - []*entity.WebhookDelivery: the deliveries.
+// - error: an error if the operation failed.
+func (r *webhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*entity.WebhookDelivery, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdat", Value: -1}}).
+		SetLimit(int64(limit))
+
+	return r.find(ctx, bson.M{"webhookid": webhookID}, opts)
+}
+
+// Due returns up to limit pending deliveries whose next attempt is due.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - now: the current time.
+// - limit: the maximum number of deliveries to return.
+//
+// Returns:
+// - []*entity.WebhookDelivery: the due deliveries, oldest first.
+// - error: an error if the operation failed.
+func (r *webhookDeliveryRepository) Due(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":        entity.DELIVERY_STATUS_PENDING,
+		"nextattemptat": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "nextattemptat", Value: 1}}).
+		SetLimit(int64(limit))
+
+	return r.find(ctx, filter, opts)
+}
+
+// Update saves the state of a delivery.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - delivery: the delivery to save.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"_id": delivery.ID}, delivery); err != nil {
+		r.logger.Error("error updating webhook delivery: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// find returns the deliveries matching the filter.
+func (r *webhookDeliveryRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*entity.WebhookDelivery, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		r.logger.Error("error finding webhook deliveries: " + err.Error())
+		return nil, err
+	}
+
+	deliveries := []*entity.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		r.logger.Error("error decoding webhook deliveries: " + err.Error())
+		return nil, err
+	}
+
+	return deliveries, nil
+}
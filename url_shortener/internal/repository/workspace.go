@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IWorkspaceRepository interface {
+	// Create stores a new workspace.
+	Create(ctx context.Context, workspace *entity.Workspace) error
+
+	// Get returns the workspace with the given ID.
+	Get(ctx context.Context, id string) (*entity.Workspace, error)
+
+	// List returns the workspaces, optionally only those with the given IDs, by name.
+	List(ctx context.Context, ids []string) ([]*entity.Workspace, error)
+}
+
+type workspaceRepository struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewWorkspaceRepository(logger *slog.Logger, database *mongo.Database) IWorkspaceRepository {
+	return &workspaceRepository{logger: logger, collection: database.Collection(WORKSPACES_COLLECTION)}
+}
+
+// Create stores a new workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspace: the workspace to store.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (r *workspaceRepository) Create(ctx context.Context, workspace *entity.Workspace) error {
+	if _, err := r.collection.InsertOne(ctx, workspace); err != nil {
+		r.logger.Error("error creating workspace: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the workspace with the given ID.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - id: the ID of the workspace.
+//
+// Returns:
+// - *entity.Workspace: the workspace.
+// - error: ErrNotFound if the workspace does not exist, or an error if the operation failed.
+func (r *workspaceRepository) Get(ctx context.Context, id string) (*entity.Workspace, error) {
+	var workspace entity.Workspace
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&workspace); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		r.logger.Error("error getting workspace: " + err.Error())
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+// List returns the workspaces, by name.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ids: the IDs of the workspaces, or nil for every workspace.
+//
+// Returns:
+// - []*entity.Workspace: the workspaces.
+// - error: an error if the operation failed.
+func (r *workspaceRepository) List(ctx context.Context, ids []string) ([]*entity.Workspace, error) {
+	filter := bson.M{}
+	if ids != nil {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("error finding workspaces: " + err.Error())
+		return nil, err
+	}
+
+	workspaces := []*entity.Workspace{}
+	if err := cursor.All(ctx, &workspaces); err != nil {
+		r.logger.Error("error decoding workspaces: " + err.Error())
+		return nil, err
+	}
+
+	return workspaces, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+type IAPIKeyService interface {
+	// Create creates an API key with the given scopes, returning its token once.
+	Create(ctx context.Context, name, owner, workspaceID string, scopes []string) (*entity.APIKey, string, error)
+
+	// List returns the API keys, optionally of a single workspace, revoked ones included.
+	List(ctx context.Context, workspaceID string) ([]*entity.APIKey, error)
+
+	// Revoke revokes an API key, optionally only if it belongs to a workspace.
+	Revoke(ctx context.Context, workspaceID, id string) error
+
+	// Authenticate returns the valid API key with the given token.
+	Authenticate(ctx context.Context, token string) (*entity.APIKey, error)
+}
+
+type APIKeyService struct {
+	logger     *slog.Logger
+	repository repository.IAPIKeyRepository
+}
+
+func NewAPIKeyService(logger *slog.Logger, repository repository.IAPIKeyRepository) *APIKeyService {
+	return &APIKeyService{logger: logger, repository: repository}
+}
+
+// Create creates an API key with the given scopes.
+//
+// The token is a random secret, only its hash is stored, so it is returned
+// once and cannot be recovered.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - name: what the key is used for.
+// - owner: the user the links created with the key belong to, empty for the key itself.
+// - workspaceID: the workspace the key acts in, empty for a key outside of workspaces.
+// - scopes: the scopes granted to the key.
+//
+// Returns:
+// - *entity.APIKey: the created key.
+// - string: the token of the key.
+// - error: ErrInvalidAPIKeyName, ErrInvalidOwner, ErrUnknownScope or ErrWorkspaceScope if the key is invalid, or an error if it could not be stored.
+func (s *APIKeyService) Create(ctx context.Context, name, owner, workspaceID string, scopes []string) (*entity.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MAX_API_KEY_NAME_LENGTH || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return nil, "", ErrInvalidAPIKeyName
+	}
+
+	owner = strings.TrimSpace(owner)
+	if len(owner) > MAX_OWNER_ID_LENGTH || strings.IndexFunc(owner, unicode.IsControl) >= 0 || entity.IsReservedOwnerID(owner) {
+		return nil, "", ErrInvalidOwner
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", ErrUnknownScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(entity.Scopes, scope) {
+			return nil, "", ErrUnknownScope
+		}
+		// Keys of a workspace must not reach beyond it.
+		if workspaceID != "" && !slices.Contains(entity.WorkspaceScopes, scope) {
+			return nil, "", ErrWorkspaceScope
+		}
+	}
+
+	token, err := newToken(API_KEY_TOKEN_PREFIX)
+	if err != nil {
+		return nil, "", err
+	}
+
+	scopes = slices.Clone(scopes)
+	slices.Sort(scopes)
+
+	key := entity.NewAPIKey(utils.NewID(), name, owner, workspaceID, token[:API_KEY_SHOWN_PREFIX], hashToken(token), slices.Compact(scopes))
+
+	if err := s.repository.Create(ctx, key); err != nil {
+		s.logger.Error("error creating api key " + err.Error())
+		return nil, "", err
+	}
+
+	s.logger.Info("Created API key", slog.String("id", key.ID), slog.String("name", key.Name))
+
+	return key, token, nil
+}
+
+// List returns the API keys, revoked ones included.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the workspace of the keys, or empty for every key.
+//
+// Returns:
+// - []*entity.APIKey: the keys, newest first.
+// - error: an error if the operation failed.
+func (s *APIKeyService) List(ctx context.Context, workspaceID string) ([]*entity.APIKey, error) {
+	return s.repository.List(ctx, workspaceID)
+}
+
+// Revoke revokes an API key, which is refused from then on.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - workspaceID: the workspace the key must belong to, or empty for any key.
+// - id: the ID of the key.
+//
+// Returns:
+// - error: ErrAPIKeyNotFound if the key does not exist, or an error if the operation failed.
+func (s *APIKeyService) Revoke(ctx context.Context, workspaceID, id string) error {
+	if err := s.repository.Revoke(ctx, workspaceID, id, time.Now()); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+
+	s.logger.Info("Revoked API key", slog.String("id", id))
+
+	return nil
+}
+
+// Authenticate returns the valid API key with the given token.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - token: the token sent by the client.
+//
+// Returns:
+// - *entity.APIKey: the key.
+// - error: ErrInvalidAPIKey if no valid key has this token, or an error if the operation failed.
+func (s *APIKeyService) Authenticate(ctx context.Context, token string) (*entity.APIKey, error) {
+	if !strings.HasPrefix(token, API_KEY_TOKEN_PREFIX) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repository.GetByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if key.IsRevoked() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	return key, nil
+}
+
+// newToken returns a random secret starting with the given prefix.
+func newToken(prefix string) (string, error) {
+	secret := make([]byte, API_KEY_SECRET_SIZE)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return prefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashToken returns the hex SHA-256 hash of a token.
+//
+// Tokens are long random secrets, so a fast hash is enough to protect them
+// and lets keys be looked up by their hash.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+)
+
+type ICampaignService interface {
+	// Stats returns the clicks of the links with campaign parameters, grouped by campaign.
+	Stats(ctx context.Context, ownerID, origin string, from, to time.Time) ([]entity.CampaignStats, error)
+}
+
+type CampaignService struct {
+	logger          *slog.Logger
+	urlRepository   repository.IURLRepository
+	clickRepository repository.IClickRepository
+}
+
+func NewCampaignService(logger *slog.Logger, urlRepository repository.IURLRepository, clickRepository repository.IClickRepository) *CampaignService {
+	return &CampaignService{logger: logger, urlRepository: urlRepository, clickRepository: clickRepository}
+}
+
+// Stats returns the clicks of the links with campaign parameters, grouped
+// by campaign and sorted by clicks, most clicked first.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ownerID: the owner whose links are reported, or empty for every owner.
+// - origin: the original URL whose variants are reported, or empty for every origin.
+// - from: the inclusive lower time bound, or zero for no bound.
+// - to: the exclusive upper time bound, or zero for no bound.
+//
+// Returns:
+// - []entity.CampaignStats: the stats of every campaign.
+// - error: an error if the operation failed.
+func (s *CampaignService) Stats(ctx context.Context, ownerID, origin string, from, to time.Time) ([]entity.CampaignStats, error) {
+	if !to.IsZero() && to.Before(from) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	urls, err := s.urlRepository.ListWithUTM(ctx, ownerID, origin)
+	if err != nil {
+		s.logger.Error("error listing campaign links " + err.Error())
+		return nil, err
+	}
+
+	if len(urls) == 0 {
+		return []entity.CampaignStats{}, nil
+	}
+
+	shorts := make([]string, len(urls))
+	for i, url := range urls {
+		shorts[i] = url.GetShort()
+	}
+
+	clicks, err := s.clickRepository.CountByShorts(ctx, repository.ClickFilter{From: from, To: to}, shorts)
+	if err != nil {
+		s.logger.Error("error counting campaign clicks " + err.Error())
+		return nil, err
+	}
+
+	byCampaign := map[string]*entity.CampaignStats{}
+	for _, url := range urls {
+		campaign := url.GetUTM().Campaign
+
+		stats, ok := byCampaign[campaign]
+		if !ok {
+			stats = &entity.CampaignStats{Campaign: campaign}
+			byCampaign[campaign] = stats
+		}
+
+		stats.Links++
+		stats.Clicks += clicks[url.GetShort()]
+	}
+
+	result := make([]entity.CampaignStats, 0, len(byCampaign))
+	for _, stats := range byCampaign {
+		result = append(result, *stats)
+	}
+
+	slices.SortFunc(result, func(a, b entity.CampaignStats) int {
+		if a.Clicks != b.Clicks {
+			return cmp.Compare(b.Clicks, a.Clicks)
+		}
+		return cmp.Compare(a.Campaign, b.Campaign)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/events"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/anonymizer"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+type IClickService interface {
+	// Record stores a click of the given URL made by the visitor.
+	Record(ctx context.Context, url entity.IURL, visitor entity.Visitor) error
+
+	// Erase deletes all analytics of the given short URL on behalf of the actor.
+	Erase(ctx context.Context, actor Actor, short string) error
+
+	// ApplyRetention aggregates and deletes raw clicks older than the retention period.
+	ApplyRetention(ctx context.Context) error
+
+	// Export streams the clicks selected by the options to the writer on behalf of the actor.
+	Export(ctx context.Context, actor Actor, opts ExportOptions, w io.Writer) error
+
+	// Stats returns the number of clicks of the given short URL, by split destination.
+	Stats(ctx context.Context, actor Actor, short string) (entity.LinkStats, error)
+}
+
+type ClickService struct {
+	logger          *slog.Logger
+	clickRepository repository.IClickRepository
+	urlRepository   repository.IURLRepository
+	anonymizer      anonymizer.IAnonymizer
+	publisher       events.IEventPublisher
+	access          IPolicy
+	config          *config.Config
+}
+
+func NewClickService(
+	logger *slog.Logger,
+	clickRepository repository.IClickRepository,
+	urlRepository repository.IURLRepository,
+	publisher events.IEventPublisher,
+	access IPolicy,
+	config *config.Config,
+) *ClickService {
+	return &ClickService{
+		logger:          logger,
+		clickRepository: clickRepository,
+		urlRepository:   urlRepository,
+		anonymizer:      newAnonymizer(config.PrivacyConfig),
+		publisher:       publisher,
+		access:          access,
+		config:          config,
+	}
+}
+
+// authorize checks that an actor may use a scope on the analytics of a
+// short URL.
+//
+// Admins may also name deleted links, whose analytics are kept. Links of
+// other owners are reported as not found, so that their codes cannot be
+// probed.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - short: the shortened URL.
+// - scope: the scope, e.g. "stats:read".
+//
+// Returns:
+// - error: ErrLinkNotFound if the link does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// use the scope, or an error if the operation failed.
+func (s *ClickService) authorize(ctx context.Context, actor Actor, short, scope string) error {
+	if actor.Admin {
+		return nil
+	}
+
+	url, err := s.urlRepository.GetByShort(ctx, short)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLinkNotFound
+		}
+		return err
+	}
+
+	err = s.access.AuthorizeOwner(ctx, actor, url.GetOwnerID(), scope)
+	if errors.Is(err, ErrNotOwner) {
+		return ErrLinkNotFound
+	}
+
+	return err
+}
+
+// newAnonymizer builds the IP anonymizer selected in the privacy configuration.
+//
+// Parameters:
+// - privacyConfig: the privacy configuration.
+//
+// Returns:
+// - anonymizer.IAnonymizer: the configured anonymizer.
+func newAnonymizer(privacyConfig config.IPrivacyConfig) anonymizer.IAnonymizer {
+	switch mode := privacyConfig.GetIPAnonymizationMode(); mode {
+	case config.IP_ANONYMIZATION_NONE:
+		return anonymizer.NewNoopAnonymizer()
+	case config.IP_ANONYMIZATION_HASH:
+		return anonymizer.NewHashingAnonymizer(privacyConfig.GetIPHashSecret(), privacyConfig.GetIPHashSaltRotation())
+	case config.IP_ANONYMIZATION_TRUNCATE:
+		return anonymizer.NewTruncatingAnonymizer(privacyConfig.GetIPv4PrefixLength(), privacyConfig.GetIPv6PrefixLength())
+	default:
+		panic("unknown IP anonymization mode: " + mode)
+	}
+}
+
+// Record stores a click of the given URL made by the visitor and emits
+// a click event.
+//
+// The IP address of the visitor is anonymized before it is stored. If the
+// visitor opted out of tracking, only the fact of the click is kept.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - url: the URL that was clicked.
+// - visitor: the visitor that followed the shortened URL.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (s *ClickService) Record(ctx context.Context, url entity.IURL, visitor entity.Visitor) error {
+	if visitor.DoNotTrack && s.config.PrivacyConfig.RespectDoNotTrack() {
+		s.logger.Debug("Visitor opted out of tracking", slog.String("short", url.GetShort()))
+		// The split destination says nothing about the visitor and is kept,
+		// so that experiments still count every visit.
+		visitor = entity.Visitor{Variant: visitor.Variant}
+	}
+
+	click := entity.NewClick(
+		url.GetShort(),
+		s.anonymizer.Anonymize(visitor.IP),
+		visitor.UserAgent,
+		stripReferer(visitor.Referer),
+		visitor.Variant,
+	)
+
+	if err := s.clickRepository.Create(ctx, click); err != nil {
+		s.logger.Error("error recording click " + err.Error())
+		return err
+	}
+
+	event := entity.NewEvent(utils.NewID(), entity.EVENT_LINK_CLICKED, url, map[string]any{
+		"ip":         click.GetIP(),
+		"user_agent": click.GetUserAgent(),
+		"referer":    click.GetReferer(),
+		"variant":    click.GetVariant(),
+	})
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("error publishing click event " + err.Error())
+	}
+
+	return nil
+}
+
+// stripReferer removes the path, query and fragment of a referer, which
+// may carry personal data, keeping only its origin.
+//
+// Parameters:
+// - referer: the Referer header value.
+//
+// Returns:
+// - string: the origin of the referer or an empty string.
+func stripReferer(referer string) string {
+	parsed, err := url.Parse(referer)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// Erase deletes all analytics of the given short URL.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor erasing the analytics.
+// - short: the shortened URL whose analytics are deleted.
+//
+// Returns:
+// - error: ErrLinkNotFound if the link does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// write links, or an error if the operation failed.
+func (s *ClickService) Erase(ctx context.Context, actor Actor, short string) error {
+	if err := s.authorize(ctx, actor, short, entity.SCOPE_LINKS_WRITE); err != nil {
+		return err
+	}
+
+	if err := s.clickRepository.DeleteByShort(ctx, short); err != nil {
+		s.logger.Error("error erasing clicks " + err.Error())
+		return err
+	}
+
+	s.logger.Info("Erased analytics", slog.String("short", short))
+
+	return nil
+}
+
+// ApplyRetention aggregates and deletes raw clicks older than the retention period.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (s *ClickService) ApplyRetention(ctx context.Context) error {
+	before := time.Now().Add(-s.config.PrivacyConfig.GetClickRetention())
+
+	deleted, err := s.clickRepository.AggregateBefore(ctx, before)
+	if err != nil {
+		s.logger.Error("error applying click retention " + err.Error())
+		return err
+	}
+
+	s.logger.Debug("Applied click retention", slog.Int64("deleted", deleted), slog.Time("before", before))
+
+	return nil
+}
+
+// Stats returns the number of clicks of the given short URL, including
+// aggregated ones, in total and by split destination.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor reading the statistics.
+// - short: the shortened URL.
+//
+// Returns:
+// - entity.LinkStats: the clicks of the URL.
+// - error: ErrLinkNotFound if the link does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// read statistics, or an error if the operation failed.
+func (s *ClickService) Stats(ctx context.Context, actor Actor, short string) (entity.LinkStats, error) {
+	if err := s.authorize(ctx, actor, short, entity.SCOPE_STATS_READ); err != nil {
+		return entity.LinkStats{}, err
+	}
+
+	byVariant, err := s.clickRepository.CountByVariant(ctx, short)
+	if err != nil {
+		s.logger.Error("error counting clicks " + err.Error())
+		return entity.LinkStats{}, err
+	}
+
+	stats := entity.LinkStats{Short: short}
+	for variant, clicks := range byVariant {
+		stats.Clicks += clicks
+
+		if variant != "" {
+			if stats.Variants == nil {
+				stats.Variants = map[string]int64{}
+			}
+			stats.Variants[variant] = clicks
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/export"
+)
+
+// clickFields maps the exportable raw click fields to their values.
+var clickFields = map[string]func(entity.IClick) any{
+	"short":      func(c entity.IClick) any { return c.GetShort() },
+	"ip":         func(c entity.IClick) any { return c.GetIP() },
+	"user_agent": func(c entity.IClick) any { return c.GetUserAgent() },
+	"referer":    func(c entity.IClick) any { return c.GetReferer() },
+	"created_at": func(c entity.IClick) any { return c.GetCreatedAt() },
+	"variant":    func(c entity.IClick) any { return c.GetVariant() },
+}
+
+// aggregateFields maps the exportable aggregate fields to their values.
+var aggregateFields = map[string]func(entity.ClickAggregate) any{
+	"short":   func(a entity.ClickAggregate) any { return a.Short },
+	"day":     func(a entity.ClickAggregate) any { return a.Day },
+	"clicks":  func(a entity.ClickAggregate) any { return a.Clicks },
+	"variant": func(a entity.ClickAggregate) any { return a.Variant },
+}
+
+// defaultClickFields and defaultAggregateFields are exported when no
+// fields are selected.
+var (
+	defaultClickFields     = []string{"short", "created_at", "ip", "user_agent", "referer"}
+	defaultAggregateFields = []string{"short", "day", "clicks"}
+)
+
+// ExportOptions selects the click data written by an export.
+//
+// Fields:
+// - Short: the shortened URL, or empty to export every link of the owner.
+// - OwnerID: the owner whose links are exported when Short is empty, or
+// empty for admins to export every link.
+// - From: the inclusive lower time bound, or zero for no bound.
+// - To: the exclusive upper time bound, or zero for no bound.
+// - Format: the output format, "csv" or "ndjson".
+// - Fields: the exported fields, or empty for all fields.
+// - Aggregated: whether daily aggregates are exported instead of raw clicks.
+type ExportOptions struct {
+	Short      string
+	OwnerID    string
+	From       time.Time
+	To         time.Time
+	Format     string
+	Fields     []string
+	Aggregated bool
+}
+
+// Validate checks the format and fields of the options and fills in the
+// default fields.
+//
+// Returns:
+// - error: an error if the format or one of the fields is unknown.
+func (o *ExportOptions) Validate() error {
+	if o.Format != export.FORMAT_CSV && o.Format != export.FORMAT_NDJSON {
+		return export.ErrUnknownFormat
+	}
+
+	if !o.To.IsZero() && o.To.Before(o.From) {
+		return ErrInvalidTimeRange
+	}
+
+	if len(o.Fields) == 0 {
+		o.Fields = defaultClickFields
+		if o.Aggregated {
+			o.Fields = defaultAggregateFields
+		}
+		return nil
+	}
+
+	for _, field := range o.Fields {
+		_, rawField := clickFields[field]
+		_, aggregateField := aggregateFields[field]
+		if (o.Aggregated && !aggregateField) || (!o.Aggregated && !rawField) {
+			return ErrUnknownExportField
+		}
+	}
+
+	return nil
+}
+
+// Export streams the clicks selected by the options to the writer.
+//
+// Nothing is written to the writer if the actor may not export the clicks.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor exporting the clicks, an admin to export every link,
+// or an actor allowed to read the statistics of the owner to export its links.
+// - opts: the validated export options.
+// - w: the writer the export is written to.
+//
+// Returns:
+// - error: ErrLinkNotFound if the link does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// read statistics, or an error if the operation failed.
+func (s *ClickService) Export(ctx context.Context, actor Actor, opts ExportOptions, w io.Writer) error {
+	filter := repository.ClickFilter{Short: opts.Short, From: opts.From, To: opts.To}
+
+	if opts.Short != "" {
+		if err := s.authorize(ctx, actor, opts.Short, entity.SCOPE_STATS_READ); err != nil {
+			return err
+		}
+	} else if opts.OwnerID != "" || !actor.Admin {
+		shorts, err := s.ownedShorts(ctx, actor, opts.OwnerID)
+		if err != nil {
+			return err
+		}
+		filter.Shorts = shorts
+	}
+
+	writer, err := export.NewRowWriter(opts.Format, w, opts.Fields)
+	if err != nil {
+		return err
+	}
+
+	if opts.Aggregated {
+		err = s.clickRepository.StreamAggregates(ctx, filter, func(aggregate entity.ClickAggregate) error {
+			values := make([]any, len(opts.Fields))
+			for i, field := range opts.Fields {
+				values[i] = aggregateFields[field](aggregate)
+			}
+			return writer.WriteRow(values)
+		})
+	} else {
+		err = s.clickRepository.Stream(ctx, filter, func(click entity.IClick) error {
+			values := make([]any, len(opts.Fields))
+			for i, field := range opts.Fields {
+				values[i] = clickFields[field](click)
+			}
+			return writer.WriteRow(values)
+		})
+	}
+
+	if err != nil {
+		s.logger.Error("error exporting clicks " + err.Error())
+		return err
+	}
+
+	s.logger.Debug("Exported clicks", slog.String("short", opts.Short), slog.String("owner", opts.OwnerID), slog.Bool("aggregated", opts.Aggregated))
+
+	return writer.Flush()
+}
+
+// ownedShorts returns the shortened URLs of an owner whose statistics the
+// actor may read.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - ownerID: the owner, empty for clients without owner, which own nothing.
+//
+// Returns:
+// - []string: the shortened URLs, empty but not nil if the owner has none.
+// - error: ErrInsufficientScope if there is no owner or the actor may not
+// read its statistics, ErrInsufficientRole if its role in the workspace
+// does not allow it, or an error if the operation failed.
+func (s *ClickService) ownedShorts(ctx context.Context, actor Actor, ownerID string) ([]string, error) {
+	if ownerID == "" {
+		return nil, ErrInsufficientScope
+	}
+
+	if err := s.access.AuthorizeOwner(ctx, actor, ownerID, entity.SCOPE_STATS_READ); err != nil {
+		if errors.Is(err, ErrNotOwner) {
+			return nil, ErrInsufficientScope
+		}
+		return nil, err
+	}
+
+	urls, err := s.urlRepository.ListByOwner(ctx, ownerID)
+	if err != nil {
+		s.logger.Error("error listing exported links " + err.Error())
+		return nil, err
+	}
+
+	shorts := make([]string, len(urls))
+	for i, url := range urls {
+		shorts[i] = url.GetShort()
+	}
+
+	return shorts, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+)
+
+// clickRecord is a click waiting in the queue of the ClickRecorder.
+type clickRecord struct {
+	url     entity.IURL
+	visitor entity.Visitor
+}
+
+// ClickRecorder records clicks, and counts them as redirects towards the
+// quota of the owner of the link, in the background with a fixed number of
+// workers, so that analytics and metering never delay redirects and a
+// burst of redirects does not start a goroutine per click.
+//
+// Clicks are queued up to the size of the queue. Clicks arriving while the
+// queue is full are dropped, the redirect mattering more than its analytics.
+type ClickRecorder struct {
+	logger  *slog.Logger
+	clicks  IClickService
+	usage   IUsageService
+	queue   chan clickRecord
+	workers int
+}
+
+// NewClickRecorder returns a ClickRecorder, which records nothing until Run.
+//
+// Parameters:
+// - logger: the logger object.
+// - clicks: the click service storing the clicks.
+// - usage: the usage service counting the redirects.
+// - queueSize: the number of clicks waiting at most.
+// - workers: the number of clicks recorded at once.
+//
+// Returns:
+// - *ClickRecorder: the recorder.
+func NewClickRecorder(logger *slog.Logger, clicks IClickService, usage IUsageService, queueSize, workers int) *ClickRecorder {
+	return &ClickRecorder{
+		logger:  logger,
+		clicks:  clicks,
+		usage:   usage,
+		queue:   make(chan clickRecord, queueSize),
+		workers: workers,
+	}
+}
+
+// Record queues a click without waiting.
+//
+// Parameters:
+// - url: the URL that was clicked.
+// - visitor: the client that clicked.
+//
+// Returns:
+// - bool: false if the queue is full and the click was dropped.
+func (r *ClickRecorder) Record(url entity.IURL, visitor entity.Visitor) bool {
+	select {
+	case r.queue <- clickRecord{url: url, visitor: visitor}:
+		return true
+	default:
+		r.logger.Warn("Click queue full, click dropped", slog.String("short", url.GetShort()))
+		return false
+	}
+}
+
+// Run records queued clicks until the context is canceled, then records
+// the clicks still queued and returns.
+//
+// Clicks are stored with a context that is not canceled with ctx, so that
+// the clicks drained on shutdown are not lost. Cancel ctx only once
+// nothing calls Record anymore, e.g. after the HTTP server stopped.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+func (r *ClickRecorder) Run(ctx context.Context) {
+	recordCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx, recordCtx)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// work records queued clicks until ctx is canceled and the queue is empty.
+//
+// Parameters:
+// - ctx: the context.Context stopping the worker.
+// - recordCtx: the context.Context for storing the clicks.
+func (r *ClickRecorder) work(ctx, recordCtx context.Context) {
+	for {
+		select {
+		case click := <-r.queue:
+			r.record(recordCtx, click)
+		case <-ctx.Done():
+			for {
+				select {
+				case click := <-r.queue:
+					r.record(recordCtx, click)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// record stores a click and counts the redirect, logging the failures.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - click: the click.
+func (r *ClickRecorder) record(ctx context.Context, click clickRecord) {
+	if err := r.clicks.Record(ctx, click.url, click.visitor); err != nil {
+		r.logger.Error("Failed to record click", slog.String("short", click.url.GetShort()), slog.String("err", err.Error()))
+	}
+
+	if err := r.usage.Record(ctx, click.url.GetOwnerID(), entity.USAGE_REDIRECTS); err != nil {
+		r.logger.Error("Failed to count redirect", slog.String("short", click.url.GetShort()), slog.String("err", err.Error()))
+	}
+}
@@ -1,5 +1,68 @@
 package service
 
+import "time"
+
 const (
 	SYMBOLS = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	MAX_CODE_LENGTH = 64
+	MAX_UTM_LENGTH  = 200
+
+	MAX_TARGETING_RULES = 20
+	MAX_LANGUAGE_RULES  = 20
+
+	MIN_SPLIT_DESTINATIONS = 2
+	MAX_SPLIT_DESTINATIONS = 10
+	MAX_SPLIT_WEIGHT       = 10000
+
+	MAX_SCHEDULE_CHANGES = 20
+
+	MAX_APP_URI_LENGTH = 2048
+
+	MAX_DISABLED_REASON_LENGTH = 200
+
+	MAX_API_KEY_NAME_LENGTH = 100
+	MAX_OWNER_ID_LENGTH     = 200
+
+	MAX_WORKSPACE_NAME_LENGTH = 100
+)
+
+const (
+	API_KEY_TOKEN_PREFIX = "usk_"
+	API_KEY_SECRET_SIZE  = 32
+	API_KEY_SHOWN_PREFIX = 12
+
+	INVITATION_TOKEN_PREFIX = "usi_"
+	INVITATION_TTL          = 7 * 24 * time.Hour
+)
+
+// MAX_GENERATE_CODE_ATTEMPTS is the number of codes generated for a link before giving up, when they are taken.
+const MAX_GENERATE_CODE_ATTEMPTS = 5
+
+// CLICK_QUEUE_SIZE is the number of clicks waiting to be recorded at most, further clicks being dropped.
+const CLICK_QUEUE_SIZE = 4096
+
+// CLICK_RECORD_WORKERS is the number of clicks recorded at once.
+const CLICK_RECORD_WORKERS = 8
+
+// USAGE_FLUSH_BATCH_SIZE is the number of usage counts saved at once.
+const USAGE_FLUSH_BATCH_SIZE = 500
+
+// THREAT_RESCAN_BATCH_SIZE is the number of links checked against the threat lists at once.
+const THREAT_RESCAN_BATCH_SIZE = 500
+
+// MONITOR_BATCH_SIZE is the number of links checked for expiration and destination health at once.
+const MONITOR_BATCH_SIZE = 500
+
+const (
+	WEBHOOK_CONTENT_TYPE     = "application/json"
+	WEBHOOK_ID_HEADER        = "X-Webhook-Id"
+	WEBHOOK_EVENT_HEADER     = "X-Webhook-Event"
+	WEBHOOK_DELIVERY_HEADER  = "X-Webhook-Delivery"
+	WEBHOOK_TIMESTAMP_HEADER = "X-Webhook-Timestamp"
+	WEBHOOK_SIGNATURE_HEADER = "X-Webhook-Signature"
+	WEBHOOK_SIGNATURE_PREFIX = "sha256="
+
+	WEBHOOK_DELIVERY_LOG_LIMIT = 100
+	WEBHOOK_RESPONSE_LIMIT     = 64 << 10
 )
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/useragent"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+// unsafeAppSchemes are the URI schemes refused for app URIs, as they run
+// code or read data in the browser instead of opening an app.
+var unsafeAppSchemes = []string{"javascript", "data", "vbscript", "file", "blob", "about"}
+
+// ValidateDeepLink checks the apps opened by a link.
+//
+// Parameters:
+// - deepLink: the deep link.
+//
+// Returns:
+// - error: ErrInvalidDeepLink if no app URI is set, a store page is set
+// without the app URI of its platform, an app URI is too long or has no
+// scheme or an unsafe one, or a store page is not a valid URL.
+func ValidateDeepLink(deepLink entity.DeepLink) error {
+	if deepLink.IOS == "" && deepLink.Android == "" {
+		return ErrInvalidDeepLink
+	}
+
+	if (deepLink.IOSStore != "" && deepLink.IOS == "") || (deepLink.AndroidStore != "" && deepLink.Android == "") {
+		return ErrInvalidDeepLink
+	}
+
+	for _, uri := range []string{deepLink.IOS, deepLink.Android} {
+		if uri != "" && !validAppURI(uri) {
+			return ErrInvalidDeepLink
+		}
+	}
+
+	for _, store := range []string{deepLink.IOSStore, deepLink.AndroidStore} {
+		if store == "" {
+			continue
+		}
+		if err := utils.ValidateOrigin(store); err != nil {
+			return ErrInvalidDeepLink
+		}
+	}
+
+	return nil
+}
+
+// validAppURI reports whether the URI can be used to open an app.
+func validAppURI(uri string) bool {
+	if len(uri) > MAX_APP_URI_LENGTH {
+		return false
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme == "" {
+		return false
+	}
+
+	return !slices.Contains(unsafeAppSchemes, strings.ToLower(parsed.Scheme))
+}
+
+// SelectApp returns the app of the link opened on the device of the visitor.
+//
+// Parameters:
+// - url: the link.
+// - visitor: the client following the link.
+//
+// Returns:
+// - entity.AppTarget: the URI opening the app and its store page.
+// - bool: false if the link opens no app on the operating system of the visitor.
+func (s *TargetingService) SelectApp(url entity.IURL, visitor entity.Visitor) (entity.AppTarget, bool) {
+	deepLink := url.GetDeepLink()
+	if deepLink == nil {
+		return entity.AppTarget{}, false
+	}
+
+	switch useragent.Parse(visitor.UserAgent).OS {
+	case useragent.OS_IOS:
+		return entity.AppTarget{URI: deepLink.IOS, Store: deepLink.IOSStore}, deepLink.IOS != ""
+	case useragent.OS_ANDROID:
+		return entity.AppTarget{URI: deepLink.Android, Store: deepLink.AndroidStore}, deepLink.Android != ""
+	default:
+		return entity.AppTarget{}, false
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+)
+
+type IDestinationPolicy interface {
+	// Check returns an error if links may not lead to the destination.
+	Check(destination string) error
+}
+
+// DestinationPolicies refuses the destinations refused by any of its policies.
+type DestinationPolicies []IDestinationPolicy
+
+// Check checks a destination against every policy in turn.
+//
+// Parameters:
+// - destination: the URL or URI.
+//
+// Returns:
+// - error: the refusal of the first policy refusing the destination.
+func (p DestinationPolicies) Check(destination string) error {
+	for _, policy := range p {
+		if err := policy.Check(destination); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkDestinations checks every destination a link may lead to against
+// the policy: its origin, the destinations of its targeting rules, split
+// and schedule, and the apps and stores of its deep link.
+//
+// Parameters:
+// - policy: the destination policy.
+// - url: the link.
+//
+// Returns:
+// - error: the refusal of the first destination refused by the policy.
+func checkDestinations(policy IDestinationPolicy, url entity.IURL) error {
+	for _, destination := range destinations(url) {
+		if destination == "" {
+			continue
+		}
+		if err := policy.Check(destination); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// destinations lists the destinations a link may lead to.
+func destinations(url entity.IURL) []string {
+	list := []string{url.GetOrigin()}
+
+	for _, rule := range url.GetRules() {
+		list = append(list, rule.Destination)
+	}
+	for _, rule := range url.GetLanguages() {
+		list = append(list, rule.Destination)
+	}
+	if split := url.GetSplit(); split != nil {
+		for _, destination := range split.Destinations {
+			list = append(list, destination.Destination)
+		}
+	}
+	if schedule := url.GetSchedule(); schedule != nil {
+		list = append(list, schedule.Pending)
+		for _, change := range schedule.Changes {
+			list = append(list, change.Destination)
+		}
+	}
+	if deepLink := url.GetDeepLink(); deepLink != nil {
+		list = append(list, deepLink.IOS, deepLink.Android, deepLink.IOSStore, deepLink.AndroidStore)
+	}
+
+	return list
+}
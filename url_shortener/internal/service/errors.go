@@ -3,5 +3,49 @@ package service
 import "errors"
 
 var (
-	ErrNotValidURL = errors.New("not valid URL")
+	ErrNotValidURL           = errors.New("not valid URL")
+	ErrUnknownExportField    = errors.New("unknown export field")
+	ErrInvalidTimeRange      = errors.New("invalid time range")
+	ErrUnknownEventType      = errors.New("unknown event type")
+	ErrInvalidClickThreshold = errors.New("click threshold requires a link and a positive value")
+	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrWebhookRejected       = errors.New("webhook responded with a non-2xx status")
+	ErrInvalidWebhookURL     = errors.New("webhook URL must be https on a public host")
+	ErrInvalidRedirectCode   = errors.New("redirect code must be 301, 302, 307 or 308")
+	ErrInvalidCode           = errors.New("code must be letters, digits, '-' or '_', optionally split by '/'")
+	ErrCodeTaken             = errors.New("code is already taken")
+	ErrLinkNotFound          = errors.New("link not found")
+	ErrInvalidUTM            = errors.New("utm requires source, medium and campaign of printable characters")
+	ErrInvalidTargetingRule  = errors.New("targeting rules need a known os, device or browser and a valid destination")
+	ErrInvalidLanguageRule   = errors.New("language rules need a distinct BCP 47 language tag and a valid destination")
+	ErrInvalidSplit          = errors.New("split needs 2 to 10 destinations with distinct ids, valid URLs and weights of 0 to 10000 with a positive total")
+	ErrInvalidSchedule       = errors.New("schedule needs a known timezone, valid times in order and valid URLs")
+	ErrLinkNotYetActive      = errors.New("link is not yet available")
+	ErrLinkExpired           = errors.New("link has expired")
+	ErrLinkDisabled          = errors.New("link has been disabled")
+	ErrInvalidDisabledReason = errors.New("disabled reason must be at most 200 printable characters")
+	ErrLinkLocked            = errors.New("link was disabled by an admin and can only be enabled by one")
+	ErrInvalidDeepLink       = errors.New("deep links need an app URI with a safe scheme and valid store URLs")
+	ErrInvalidAPIKey         = errors.New("API key is missing, unknown or revoked")
+	ErrInvalidAPIKeyName     = errors.New("API key name must be 1 to 100 printable characters")
+	ErrUnknownScope          = errors.New("API keys need at least one known scope")
+	ErrAPIKeyNotFound        = errors.New("API key not found")
+	ErrInvalidOwner          = errors.New("owner must be at most 200 printable characters, not starting with workspace: or key:")
+	ErrInvalidToken          = errors.New("bearer token is malformed, expired or not signed by the identity provider")
+	ErrWorkspaceScope        = errors.New("workspace API keys can only be granted links:read, links:write and stats:read")
+	ErrWorkspaceNotFound     = errors.New("workspace not found")
+	ErrInsufficientRole      = errors.New("your role in the workspace does not allow this operation")
+	ErrInsufficientScope     = errors.New("your credentials are not granted the scope of this operation")
+	ErrNotOwner              = errors.New("resource belongs to another owner")
+	ErrInvalidWorkspaceName  = errors.New("workspace name must be 1 to 100 printable characters")
+	ErrUserRequired          = errors.New("only users can own and join workspaces")
+	ErrUnknownRole           = errors.New("role must be owner, admin, editor or viewer")
+	ErrInvalidInvitee        = errors.New("invitee must be 1 to 200 printable characters")
+	ErrAlreadyMember         = errors.New("user is already a member of the workspace")
+	ErrMemberNotFound        = errors.New("member not found")
+	ErrLastOwner             = errors.New("a workspace must keep an owner")
+	ErrInvitationNotFound    = errors.New("invitation not found")
+	ErrInvalidInvitation     = errors.New("invitation is unknown, expired, already accepted or meant for another user")
+	ErrQuotaExceeded         = errors.New("quota of the plan exceeded")
 )
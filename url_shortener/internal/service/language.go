@@ -0,0 +1,74 @@
+package service
+
+import (
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+	"golang.org/x/text/language"
+)
+
+// ValidateLanguages checks the language rules of a link.
+//
+// Parameters:
+// - rules: the language rules.
+//
+// Returns:
+// - error: ErrInvalidLanguageRule if there are too many rules, a language
+// tag is malformed or repeated, or a destination is not a valid URL.
+func ValidateLanguages(rules []entity.LanguageRule) error {
+	if len(rules) > MAX_LANGUAGE_RULES {
+		return ErrInvalidLanguageRule
+	}
+
+	seen := make(map[language.Tag]bool, len(rules))
+	for _, rule := range rules {
+		tag, err := language.Parse(rule.Language)
+		if err != nil || seen[tag] {
+			return ErrInvalidLanguageRule
+		}
+		seen[tag] = true
+
+		if err := utils.ValidateOrigin(rule.Destination); err != nil {
+			return ErrInvalidLanguageRule
+		}
+	}
+
+	return nil
+}
+
+// matchLanguages returns the destination of the language rule best
+// matching an Accept-Language header.
+//
+// Quality values are honored, and the matcher understands related
+// languages, e.g. a client asking for "de-AT" is sent to a "de" rule and
+// one asking for "nb" to a "no" rule.
+//
+// Parameters:
+// - rules: the language rules.
+// - acceptLanguage: the Accept-Language header of the client.
+//
+// Returns:
+// - string: the destination of the best matching rule.
+// - bool: false if no rule matches.
+func matchLanguages(rules []entity.LanguageRule, acceptLanguage string) (string, bool) {
+	if len(rules) == 0 || acceptLanguage == "" {
+		return "", false
+	}
+
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return "", false
+	}
+
+	supported := make([]language.Tag, len(rules))
+	for i, rule := range rules {
+		// Tags were validated when the rules were saved.
+		supported[i] = language.Make(rule.Language)
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if confidence == language.No {
+		return "", false
+	}
+
+	return rules[index].Destination, true
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+// LinkOptions holds the per-link settings chosen when a link is created.
+//
+// Fields:
+// - Code: the custom code of the link, or empty for a generated one.
+// - Prefix: whether the link also matches longer paths.
+// - RedirectCode: the HTTP status used to redirect, or zero for the configured default.
+// - QueryPolicy: how the visited query is passed to the origin.
+// - QueryPrecedence: which side wins merge conflicts.
+// - QueryAllowlist: the parameter names passed to the origin, empty for all.
+// - UTM: the campaign parameters added to the origin, nil for none.
+// - Rules: the ordered targeting rules.
+// - Languages: the language rules.
+// - Split: the weighted destinations replacing the origin, nil for none.
+// - Schedule: the activation window and planned destinations, nil for none.
+// - Interstitial: whether visitors are shown a preview page before continuing.
+// - DeepLink: the apps opened on mobile devices, nil for none.
+type LinkOptions struct {
+	Code            string
+	Prefix          bool
+	RedirectCode    int
+	QueryPolicy     string
+	QueryPrecedence string
+	QueryAllowlist  []string
+	UTM             *entity.UTM
+	Rules           []entity.TargetingRule
+	Languages       []entity.LanguageRule
+	Split           *entity.Split
+	Schedule        *entity.Schedule
+	Interstitial    bool
+	DeepLink        *entity.DeepLink
+}
+
+// LinkOptionsOf returns the settings of an existing link, so that a variant
+// of it can be created.
+//
+// Parameters:
+// - url: the link.
+//
+// Returns:
+// - LinkOptions: the settings of the link, without its code.
+func LinkOptionsOf(url entity.IURL) LinkOptions {
+	copied := entity.CopyURL(url)
+
+	return LinkOptions{
+		Prefix:          copied.Prefix,
+		RedirectCode:    copied.RedirectCode,
+		QueryPolicy:     copied.QueryPolicy,
+		QueryPrecedence: copied.QueryPrecedence,
+		QueryAllowlist:  copied.QueryAllowlist,
+		UTM:             copied.UTM,
+		Rules:           copied.Rules,
+		Languages:       copied.Languages,
+		Split:           copied.Split,
+		Schedule:        copied.Schedule,
+		Interstitial:    copied.Interstitial,
+		DeepLink:        copied.DeepLink,
+	}
+}
+
+// IsZero reports whether no custom code and no per-link setting was chosen.
+func (o LinkOptions) IsZero() bool {
+	url := &entity.URL{}
+	o.apply(url)
+
+	return o.Code == "" && isPlain(url)
+}
+
+// Validate checks the per-link settings.
+//
+// Returns:
+// - error: an error if a setting is not valid.
+func (o LinkOptions) Validate() error {
+	url := &entity.URL{}
+	o.apply(url)
+
+	return validateSettings(url)
+}
+
+// apply copies the settings onto the given URL.
+func (o LinkOptions) apply(url *entity.URL) {
+	url.RedirectCode = o.RedirectCode
+	url.QueryPolicy = o.QueryPolicy
+	url.QueryPrecedence = o.QueryPrecedence
+	url.QueryAllowlist = slices.Clone(o.QueryAllowlist)
+	url.Prefix = o.Prefix
+	url.UTM = nil
+	if o.UTM != nil && !o.UTM.IsZero() {
+		utm := *o.UTM
+		url.UTM = &utm
+	}
+	url.Rules = slices.Clone(o.Rules)
+	url.Languages = slices.Clone(o.Languages)
+	url.Split = nil
+	if o.Split != nil {
+		url.Split = &entity.Split{Sticky: o.Split.Sticky, Destinations: slices.Clone(o.Split.Destinations)}
+	}
+	url.Interstitial = o.Interstitial
+	url.DeepLink = nil
+	if o.DeepLink != nil && !o.DeepLink.IsZero() {
+		deepLink := *o.DeepLink
+		url.DeepLink = &deepLink
+	}
+	url.Schedule = nil
+	if o.Schedule != nil && !o.Schedule.IsZero() {
+		schedule := *o.Schedule
+		schedule.Changes = slices.Clone(o.Schedule.Changes)
+		url.Schedule = &schedule
+	}
+}
+
+// validateSettings checks the per-link settings of a URL.
+//
+// Parameters:
+// - url: the URL to check.
+//
+// Returns:
+// - error: an error if a setting is not valid.
+func validateSettings(url entity.IURL) error {
+	reason := url.GetDisabledReason()
+	if len(reason) > MAX_DISABLED_REASON_LENGTH || strings.IndexFunc(reason, unicode.IsControl) >= 0 {
+		return ErrInvalidDisabledReason
+	}
+
+	if err := ValidateRedirectCode(url.GetRedirectCode()); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateQueryPolicy(url.GetQueryPolicy(), url.GetQueryPrecedence()); err != nil {
+		return err
+	}
+
+	if utm := url.GetUTM(); utm != nil {
+		if err := ValidateUTM(*utm); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateRules(url.GetRules()); err != nil {
+		return err
+	}
+
+	if err := ValidateLanguages(url.GetLanguages()); err != nil {
+		return err
+	}
+
+	if split := url.GetSplit(); split != nil {
+		if err := ValidateSplit(*split); err != nil {
+			return err
+		}
+	}
+
+	if schedule := url.GetSchedule(); schedule != nil {
+		if err := ValidateSchedule(*schedule); err != nil {
+			return err
+		}
+	}
+
+	if deepLink := url.GetDeepLink(); deepLink != nil {
+		return ValidateDeepLink(*deepLink)
+	}
+
+	return nil
+}
+
+// ValidateUTM checks campaign parameters.
+//
+// Source, medium and campaign are required, as analytics tools drop
+// campaigns missing one of them.
+//
+// Parameters:
+// - utm: the campaign parameters.
+//
+// Returns:
+// - error: ErrInvalidUTM if a required parameter is missing or a value is
+// too long or contains control characters.
+func ValidateUTM(utm entity.UTM) error {
+	if utm.Source == "" || utm.Medium == "" || utm.Campaign == "" {
+		return ErrInvalidUTM
+	}
+
+	for _, value := range []string{utm.Source, utm.Medium, utm.Campaign, utm.Term, utm.Content} {
+		if len(value) > MAX_UTM_LENGTH || strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return ErrInvalidUTM
+		}
+	}
+
+	return nil
+}
+
+// ValidateRedirectCode checks that the code is a supported redirect status.
+//
+// Parameters:
+// - code: the HTTP status, zero meaning the configured default.
+//
+// Returns:
+// - error: ErrInvalidRedirectCode if the code is not 301, 302, 307 or 308.
+func ValidateRedirectCode(code int) error {
+	switch code {
+	case 0, http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return nil
+	default:
+		return ErrInvalidRedirectCode
+	}
+}
+
+// validCode matches custom codes: segments of letters, digits, '-' or '_'
+// separated by '/'.
+var validCode = regexp.MustCompile(`^[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*$`)
+
+// ValidateCode checks a custom code.
+//
+// Parameters:
+// - code: the custom code.
+// - maxDepth: the maximum number of path segments.
+//
+// Returns:
+// - error: ErrInvalidCode if the code is malformed, too long or too deep.
+func ValidateCode(code string, maxDepth int) error {
+	if len(code) > MAX_CODE_LENGTH || !validCode.MatchString(code) || strings.Count(code, "/") >= maxDepth {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// isPlain reports whether the URL has no per-link settings.
+//
+// Only plain URLs are shared between requests shortening the same origin,
+// so that nobody receives a link configured by someone else.
+func isPlain(url entity.IURL) bool {
+	return url.GetRedirectCode() == 0 && !url.IsPrefix() &&
+		url.GetUTM() == nil && len(url.GetRules()) == 0 && len(url.GetLanguages()) == 0 && url.GetSplit() == nil &&
+		url.GetSchedule() == nil && !url.IsInterstitial() && url.GetDeepLink() == nil && !url.IsDisabled() &&
+		(url.GetQueryPolicy() == "" || url.GetQueryPolicy() == utils.QUERY_POLICY_DROP)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+// Resolve returns the URL a visited path leads to.
+//
+// The path matches a link whose code equals it, or the prefix link with
+// the longest code the path starts with. All candidate codes are looked up
+// in the cache at once, the database is only asked about the codes the
+// cache knows nothing about, and codes that do not exist are cached as
+// missing.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - path: the visited path after the short link prefix, e.g. "docs/install".
+//
+// Returns:
+// - entity.IURL: the matching URL.
+// - string: the remainder of the path to append to the origin.
+// - error: ErrLinkNotFound if no link matches, ErrLinkDisabled if the
+// matching link, still returned, is disabled, or an error if the operation failed.
+func (l *URLService) Resolve(ctx context.Context, path string) (entity.IURL, string, error) {
+	codes, rests := utils.SplitCodePath(path, l.config.URLConfig.GetPrefixMaxDepth())
+	if len(codes) == 0 {
+		return nil, "", ErrLinkNotFound
+	}
+
+	shorts := make([]string, len(codes))
+	for i, code := range codes {
+		shortURL := l.BuildShortURL(code)
+		shorts[i] = shortURL.String()
+	}
+
+	found, err := l.cache.GetByShortUrls(ctx, shorts)
+	if err != nil {
+		l.logger.Error("error getting URLs from cache " + err.Error())
+		found = map[string]entity.IURL{}
+	}
+
+	var unknown []string
+	for _, short := range shorts {
+		if _, ok := found[short]; !ok {
+			unknown = append(unknown, short)
+		}
+	}
+
+	if len(unknown) > 0 {
+		if err := l.loadShorts(ctx, unknown, found); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for i, short := range shorts {
+		url := found[short]
+		if url == nil {
+			continue
+		}
+
+		if rests[i] == "" || url.IsPrefix() {
+			l.logger.Debug("Resolved path", slog.String("path", path), slog.String("short", short))
+			if url.IsDisabled() {
+				return url, rests[i], ErrLinkDisabled
+			}
+			return url, rests[i], nil
+		}
+	}
+
+	return nil, "", ErrLinkNotFound
+}
+
+// loadShorts loads the given short URLs from the repository into found
+// and caches the result, including the short URLs that do not exist.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - shorts: the short URLs to load.
+// - found: the URLs by short URL, nil for the ones that do not exist.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (l *URLService) loadShorts(ctx context.Context, shorts []string, found map[string]entity.IURL) error {
+	urls, err := l.urlRepository.GetByShorts(ctx, shorts)
+	if err != nil {
+		l.logger.Error("error getting URLs from repository " + err.Error())
+		return err
+	}
+
+	for _, url := range urls {
+		found[url.GetShort()] = url
+
+		if err := l.cache.SetByShortUrl(ctx, url); err != nil {
+			l.logger.Error("error setting URL in cache " + err.Error())
+		}
+	}
+
+	var missing []string
+	for _, short := range shorts {
+		if _, ok := found[short]; !ok {
+			found[short] = nil
+			missing = append(missing, short)
+		}
+	}
+
+	if len(missing) > 0 {
+		if err := l.cache.SetMissing(ctx, missing); err != nil {
+			l.logger.Error("error setting missing URLs in cache " + err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/events"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+type IMonitorService interface {
+	// Scan publishes link.expired and destination.health_changed for the links that changed since they were last scanned.
+	Scan(ctx context.Context) error
+}
+
+type MonitorService struct {
+	logger              *slog.Logger
+	urlRepository       repository.IURLRepository
+	linkStateRepository repository.ILinkStateRepository
+	webhookRepository   repository.IWebhookRepository
+	schedules           IScheduleService
+	publisher           events.IEventPublisher
+	client              *http.Client
+}
+
+// NewMonitorService returns a MonitorService publishing the expiration of
+// links and the health changes of their destinations.
+//
+// Parameters:
+// - logger: the logger object.
+// - urlRepository: the repository of the links scanned.
+// - linkStateRepository: the repository of what was last seen of the links.
+// - webhookRepository: the repository of the webhooks, whose subscriptions select the destinations checked.
+// - schedules: the service telling whether a link expired and its destination in effect.
+// - publisher: the publisher of the events.
+// - config: the webhook configuration, whose timeout bounds a health check.
+//
+// Returns:
+// - *MonitorService: the service.
+func NewMonitorService(
+	logger *slog.Logger,
+	urlRepository repository.IURLRepository,
+	linkStateRepository repository.ILinkStateRepository,
+	webhookRepository repository.IWebhookRepository,
+	schedules IScheduleService,
+	publisher events.IEventPublisher,
+	config config.IWebhookConfig,
+) *MonitorService {
+	return &MonitorService{
+		logger:              logger,
+		urlRepository:       urlRepository,
+		linkStateRepository: linkStateRepository,
+		webhookRepository:   webhookRepository,
+		schedules:           schedules,
+		publisher:           publisher,
+		client:              newPublicClient(config.GetTimeout()),
+	}
+}
+
+// Scan checks the links that are not disabled, in batches.
+//
+// link.expired is published once for every expiration time a link passed.
+// The destinations of the links with a webhook subscribed to
+// destination.health_changed are requested, and the event published when
+// their health changed since the previous check, or when they are down at
+// the first check.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if the links or webhooks cannot be listed, or a state
+// cannot be saved or an event published.
+func (s *MonitorService) Scan(ctx context.Context) error {
+	watched, err := s.watched(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for after := ""; ; {
+		urls, err := s.urlRepository.ListEnabled(ctx, after, MONITOR_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, url := range urls {
+			if err := s.inspect(ctx, url, now, watched); err != nil {
+				return err
+			}
+		}
+
+		if len(urls) < MONITOR_BATCH_SIZE {
+			break
+		}
+		after = urls[len(urls)-1].GetShort()
+	}
+
+	return nil
+}
+
+// subscriptions holds the links and the owners whose links have a webhook
+// subscribed to an event type.
+type subscriptions struct {
+	shorts map[string]bool
+	owners map[string]bool
+}
+
+// includes reports whether the link has a subscribed webhook.
+func (w subscriptions) includes(url entity.IURL) bool {
+	return w.shorts[url.GetShort()] || w.owners[url.GetOwnerID()]
+}
+
+// watched returns the links whose destinations are checked, so that only
+// destinations someone is told about are requested.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - subscriptions: the links and owners subscribed to destination.health_changed.
+// - error: an error if the webhooks cannot be listed.
+func (s *MonitorService) watched(ctx context.Context) (subscriptions, error) {
+	webhooks, err := s.webhookRepository.ListSubscribed(ctx, entity.EVENT_DESTINATION_HEALTH_CHANGED)
+	if err != nil {
+		return subscriptions{}, err
+	}
+
+	watched := subscriptions{shorts: map[string]bool{}, owners: map[string]bool{}}
+	for _, webhook := range webhooks {
+		if webhook.Short != "" {
+			watched.shorts[webhook.Short] = true
+		} else {
+			watched.owners[webhook.OwnerID] = true
+		}
+	}
+
+	return watched, nil
+}
+
+// inspect publishes the expiration of a link, or checks its destination in
+// effect while it is active.
+func (s *MonitorService) inspect(ctx context.Context, url entity.IURL, now time.Time, watched subscriptions) error {
+	state, err := s.schedules.Evaluate(url, now)
+	switch {
+	case errors.Is(err, ErrLinkExpired):
+		return s.expire(ctx, url)
+	case err != nil:
+		// Links that are not yet active have no destination to check.
+		return nil
+	case !watched.includes(url):
+		return nil
+	}
+
+	return s.checkHealth(ctx, url, state.Origin)
+}
+
+// expire publishes link.expired unless it was already published for the
+// expiration time of the link.
+func (s *MonitorService) expire(ctx context.Context, url entity.IURL) error {
+	notAfter := url.GetSchedule().NotAfter
+
+	previous, err := s.linkStateRepository.SetExpired(ctx, url.GetShort(), notAfter)
+	if err != nil || previous == notAfter {
+		return err
+	}
+
+	return s.publish(ctx, entity.EVENT_LINK_EXPIRED, url, map[string]any{"not_after": notAfter})
+}
+
+// checkHealth requests the destination and publishes
+// destination.health_changed if its health changed.
+func (s *MonitorService) checkHealth(ctx context.Context, url entity.IURL, destination string) error {
+	health, data := s.probe(ctx, destination)
+
+	previous, err := s.linkStateRepository.SetHealth(ctx, url.GetShort(), health)
+	if err != nil {
+		return err
+	}
+
+	// The first check of a destination only reports it when it is down.
+	if health == previous || (previous == "" && health == entity.DESTINATION_HEALTH_UP) {
+		return nil
+	}
+
+	data["destination"] = destination
+	data["health"] = health
+	data["previous_health"] = previous
+
+	return s.publish(ctx, entity.EVENT_DESTINATION_HEALTH_CHANGED, url, data)
+}
+
+// probe requests a destination with HEAD, or GET if HEAD is not supported.
+//
+// A destination is down if it cannot be reached, or answers 404, 410 or a
+// 5xx status. Redirects are not followed and count as up.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - destination: the URL of the destination.
+//
+// Returns:
+// - string: entity.DESTINATION_HEALTH_UP or entity.DESTINATION_HEALTH_DOWN.
+// - map[string]any: the status of the response, or the error of the request.
+func (s *MonitorService) probe(ctx context.Context, destination string) (string, map[string]any) {
+	status, err := s.request(ctx, http.MethodHead, destination)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = s.request(ctx, http.MethodGet, destination)
+	}
+
+	if err != nil {
+		return entity.DESTINATION_HEALTH_DOWN, map[string]any{"error": err.Error()}
+	}
+
+	data := map[string]any{"status": status}
+	if status == http.StatusNotFound || status == http.StatusGone || status >= http.StatusInternalServerError {
+		return entity.DESTINATION_HEALTH_DOWN, data
+	}
+
+	return entity.DESTINATION_HEALTH_UP, data
+}
+
+// request sends a request to a destination without reading the response body.
+func (s *MonitorService) request(ctx context.Context, method, destination string) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, method, destination, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	response.Body.Close()
+
+	return response.StatusCode, nil
+}
+
+// publish emits an event about a link.
+func (s *MonitorService) publish(ctx context.Context, eventType string, url entity.IURL, data map[string]any) error {
+	event := entity.NewEvent(utils.NewID(), eventType, url, data)
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("error publishing event "+eventType, slog.String("short", url.GetShort()), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+)
+
+// Actor is the client on whose behalf an operation is made.
+//
+// Fields:
+// - UserID: the user, empty for clients not acting for a user.
+// - OwnerID: the owner of the resources the client creates, e.g. "workspace:<id>" within a workspace.
+// - WorkspaceID: the workspace the client acts in, empty outside of workspaces.
+// - Scopes: the scopes granted to the client, narrowed to its role within a workspace.
+// - Admin: true for clients granted the admin scope, who act as an owner of every workspace and resource.
+type Actor struct {
+	UserID      string
+	OwnerID     string
+	WorkspaceID string
+	Scopes      []string
+	Admin       bool
+}
+
+// SystemActor is the actor of the operations the service makes on its
+// own, such as disabling links found to be malicious.
+var SystemActor = Actor{Admin: true}
+
+// Allows reports whether the actor is granted the given scope.
+func (a Actor) Allows(scope string) bool {
+	return a.Admin || slices.Contains(a.Scopes, scope)
+}
+
+type IPolicy interface {
+	// Role returns the role of an actor in a workspace.
+	Role(ctx context.Context, actor Actor, workspaceID string) (string, error)
+
+	// Authorize returns the role of an actor in a workspace if it allows a scope or an action.
+	Authorize(ctx context.Context, actor Actor, workspaceID, permission string) (string, error)
+
+	// AuthorizeOwner checks that an actor may use a scope on the resources of an owner.
+	AuthorizeOwner(ctx context.Context, actor Actor, ownerID, scope string) error
+}
+
+// Policy decides what the members of workspaces are allowed, from their roles.
+type Policy struct {
+	logger     *slog.Logger
+	workspaces repository.IWorkspaceRepository
+	members    repository.IMemberRepository
+}
+
+func NewPolicy(logger *slog.Logger, workspaces repository.IWorkspaceRepository, members repository.IMemberRepository) *Policy {
+	return &Policy{logger: logger, workspaces: workspaces, members: members}
+}
+
+// Role returns the role of an actor in a workspace.
+//
+// Workspaces the actor is not a member of are reported as not found, so
+// that their existence is not disclosed to other teams.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - string: the role, "owner" for admins.
+// - error: ErrWorkspaceNotFound if the actor is not a member, or an error if the operation failed.
+func (p *Policy) Role(ctx context.Context, actor Actor, workspaceID string) (string, error) {
+	if actor.Admin {
+		if _, err := p.workspaces.Get(ctx, workspaceID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return "", ErrWorkspaceNotFound
+			}
+			return "", err
+		}
+		return entity.ROLE_OWNER, nil
+	}
+
+	if actor.UserID == "" {
+		return "", ErrWorkspaceNotFound
+	}
+
+	member, err := p.members.Get(ctx, workspaceID, actor.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", ErrWorkspaceNotFound
+		}
+		return "", err
+	}
+
+	return member.Role, nil
+}
+
+// Authorize returns the role of an actor in a workspace if it allows a
+// scope or an action.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - permission: the scope or action, e.g. "links:write" or "members:manage".
+//
+// Returns:
+// - string: the role.
+// - error: ErrWorkspaceNotFound if the actor is not a member, ErrInsufficientRole
+// if the role does not allow the permission, or an error if the operation failed.
+func (p *Policy) Authorize(ctx context.Context, actor Actor, workspaceID, permission string) (string, error) {
+	role, err := p.Role(ctx, actor, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	if !entity.RoleAllows(role, permission) {
+		return "", ErrInsufficientRole
+	}
+
+	return role, nil
+}
+
+// AuthorizeOwner checks that an actor may use a scope on the resources,
+// links and webhooks, of an owner.
+//
+// Admins may use every resource. Other actors need the scope, and may use
+// the resources of their own owner. Users acting outside of a workspace
+// may also use the resources of the workspaces whose role allows the scope.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - ownerID: the owner of the resource.
+// - scope: the scope, e.g. "links:write".
+//
+// Returns:
+// - error: ErrInsufficientScope if the actor is not granted the scope,
+// ErrInsufficientRole if its role in the workspace owning the resource does
+// not allow it, ErrNotOwner if the resource belongs to another owner, or an
+// error if the operation failed.
+func (p *Policy) AuthorizeOwner(ctx context.Context, actor Actor, ownerID, scope string) error {
+	if actor.Admin {
+		return nil
+	}
+
+	if !actor.Allows(scope) {
+		return ErrInsufficientScope
+	}
+
+	if ownerID == actor.OwnerID {
+		return nil
+	}
+
+	// Clients acting in a workspace never reach the resources of another one.
+	workspaceID, ok := entity.WorkspaceOfOwner(ownerID)
+	if !ok || actor.WorkspaceID != "" {
+		return ErrNotOwner
+	}
+
+	if _, err := p.Authorize(ctx, actor, workspaceID, scope); err != nil {
+		if errors.Is(err, ErrWorkspaceNotFound) {
+			return ErrNotOwner
+		}
+		return err
+	}
+
+	return nil
+}
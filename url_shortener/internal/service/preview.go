@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/cache"
+	"github.com/flew1x/url_shortener_ms/pkg/pagetitle"
+)
+
+type IPreviewService interface {
+	// Title returns the title of the page at the destination, or empty if it cannot be read.
+	Title(ctx context.Context, destination string) string
+}
+
+type PreviewService struct {
+	logger  *slog.Logger
+	fetcher *pagetitle.Fetcher
+	titles  cache.ITitleCache
+	ttl     time.Duration
+}
+
+func NewPreviewService(logger *slog.Logger, fetcher *pagetitle.Fetcher, titles cache.ITitleCache, ttl time.Duration) *PreviewService {
+	return &PreviewService{logger: logger, fetcher: fetcher, titles: titles, ttl: ttl}
+}
+
+// Title returns the title of the page at the destination.
+//
+// Titles are cached for the configured time, so that the destination is
+// only requested once in a while however often the preview is shown. The
+// preview is still shown when the page cannot be read, so failures are
+// only logged, and cached too so that a slow destination does not slow
+// down every preview.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - destination: the URL of the page.
+//
+// Returns:
+// - string: the title of the page, or empty if it has none or cannot be read.
+func (s *PreviewService) Title(ctx context.Context, destination string) string {
+	if title, err := s.titles.Get(ctx, destination); err == nil {
+		return title
+	}
+
+	title, err := s.fetcher.Fetch(ctx, destination)
+	if err != nil {
+		s.logger.Debug("error reading page title "+err.Error(), slog.String("destination", destination))
+
+		// The visitor left, the destination may still answer the next one.
+		if ctx.Err() != nil {
+			return ""
+		}
+		title = ""
+	}
+
+	if err := s.titles.Set(ctx, destination, title, s.ttl); err != nil {
+		s.logger.Error("error caching page title " + err.Error())
+	}
+
+	return title
+}
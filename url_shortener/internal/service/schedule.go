@@ -0,0 +1,191 @@
+package service
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+// scheduleLayouts are the accepted layouts of local schedule times, tried
+// after RFC 3339.
+var scheduleLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+type IScheduleService interface {
+	// Evaluate returns the state of the schedule of the link at the given time.
+	Evaluate(url entity.IURL, now time.Time) (ScheduleState, error)
+}
+
+// ScheduleState is the state of the schedule of a link at a given time.
+//
+// Fields:
+// - Origin: the destination in effect, the origin of the link when no change has started.
+// - ActiveAt: the time the link becomes available, set when it is not yet.
+type ScheduleState struct {
+	Origin   string
+	ActiveAt time.Time
+}
+
+type ScheduleService struct {
+	logger    *slog.Logger
+	config    config.IURLConfig
+	locations sync.Map
+}
+
+func NewScheduleService(logger *slog.Logger, config config.IURLConfig) *ScheduleService {
+	return &ScheduleService{logger: logger, config: config}
+}
+
+// ValidateSchedule checks the activation window and planned destinations
+// of a link.
+//
+// Parameters:
+// - schedule: the schedule.
+//
+// Returns:
+// - error: ErrInvalidSchedule if the timezone is unknown, a time cannot be
+// parsed, the link would expire before its activation, there are too many
+// changes or they are out of order, or a destination is not a valid URL.
+func ValidateSchedule(schedule entity.Schedule) error {
+	location := time.UTC
+	if schedule.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(schedule.Timezone); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+
+	notBefore, err := parseScheduleTime(schedule.NotBefore, location)
+	if err != nil {
+		return ErrInvalidSchedule
+	}
+
+	notAfter, err := parseScheduleTime(schedule.NotAfter, location)
+	if err != nil || (!notBefore.IsZero() && !notAfter.IsZero() && !notAfter.After(notBefore)) {
+		return ErrInvalidSchedule
+	}
+
+	if schedule.Pending != "" {
+		if err := utils.ValidateOrigin(schedule.Pending); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+
+	if len(schedule.Changes) > MAX_SCHEDULE_CHANGES {
+		return ErrInvalidSchedule
+	}
+
+	var previous time.Time
+	for _, change := range schedule.Changes {
+		from, err := parseScheduleTime(change.From, location)
+		if err != nil || from.IsZero() || !from.After(previous) {
+			return ErrInvalidSchedule
+		}
+		previous = from
+
+		if err := utils.ValidateOrigin(change.Destination); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+
+	return nil
+}
+
+// Evaluate returns the state of the schedule of the link at the given time.
+//
+// Parameters:
+// - url: the link.
+// - now: the time of the visit.
+//
+// Returns:
+// - ScheduleState: the destination in effect, and the activation time
+// before the link is available.
+// - error: ErrLinkNotYetActive before the activation time, ErrLinkExpired
+// after the expiration time.
+func (s *ScheduleService) Evaluate(url entity.IURL, now time.Time) (ScheduleState, error) {
+	state := ScheduleState{Origin: url.GetOrigin()}
+
+	schedule := url.GetSchedule()
+	if schedule == nil {
+		return state, nil
+	}
+
+	location := s.location(schedule.Timezone)
+
+	// Schedules are validated when saved, times failing to parse are unset.
+	if notBefore, _ := parseScheduleTime(schedule.NotBefore, location); now.Before(notBefore) {
+		state.ActiveAt = notBefore
+		return state, ErrLinkNotYetActive
+	}
+
+	if notAfter, _ := parseScheduleTime(schedule.NotAfter, location); !notAfter.IsZero() && !now.Before(notAfter) {
+		return state, ErrLinkExpired
+	}
+
+	for _, change := range schedule.Changes {
+		from, err := parseScheduleTime(change.From, location)
+		if err != nil || now.Before(from) {
+			break
+		}
+		state.Origin = change.Destination
+	}
+
+	return state, nil
+}
+
+// location returns the timezone of a schedule, loading it once.
+//
+// Parameters:
+// - timezone: the IANA name of the timezone, empty for the configured default.
+//
+// Returns:
+// - *time.Location: the timezone, UTC if it cannot be loaded.
+func (s *ScheduleService) location(timezone string) *time.Location {
+	if timezone == "" {
+		timezone = s.config.GetScheduleTimezone()
+	}
+
+	if location, ok := s.locations.Load(timezone); ok {
+		return location.(*time.Location)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		s.logger.Error("error loading schedule timezone "+err.Error(), slog.String("timezone", timezone))
+		location = time.UTC
+	}
+	s.locations.Store(timezone, location)
+
+	return location
+}
+
+// parseScheduleTime parses a schedule time.
+//
+// Parameters:
+// - value: an RFC 3339 timestamp or a local date-time, empty for none.
+// - location: the timezone of local date-times.
+//
+// Returns:
+// - time.Time: the parsed time, zero for an empty value.
+// - error: an error if the value cannot be parsed.
+func parseScheduleTime(value string, location *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return parsed, nil
+	}
+
+	for _, layout := range scheduleLayouts {
+		if parsed, err = time.ParseInLocation(layout, value, location); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, err
+}
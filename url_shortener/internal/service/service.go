@@ -5,15 +5,92 @@ import (
 
 	"github.com/flew1x/url_shortener_ms/internal/cache"
 	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/events"
 	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/geoip"
+	"github.com/flew1x/url_shortener_ms/pkg/jwks"
+	"github.com/flew1x/url_shortener_ms/pkg/pagetitle"
+	"github.com/flew1x/url_shortener_ms/pkg/safebrowsing"
 )
 
 type Service struct {
 	UrlShortener IURLService
+	Clicks       IClickService
+	Webhooks     IWebhookService
+	Campaigns    ICampaignService
+	Targeting    ITargetingService
+	Schedules    IScheduleService
+	Previews     IPreviewService
+	APIKeys      IAPIKeyService
+	Tokens       ITokenService
+	Workspaces   IWorkspaceService
+	Usage        IUsageService
+	Threats      IThreatService
+	Monitor      IMonitorService
 }
 
-func NewService(logger *slog.Logger, repository *repository.Repository, cache *cache.Cache, config *config.Config) *Service {
+func NewService(
+	logger *slog.Logger,
+	repository *repository.Repository,
+	cache *cache.Cache,
+	config *config.Config,
+	locator geoip.ILocator,
+	keys *jwks.KeySet,
+	destinationPolicy IDestinationPolicy,
+	threats *safebrowsing.Database,
+) *Service {
+	publisher := events.NewOutboxPublisher(logger, repository.OutboxRepository, events.OUTBOX_CONSUMER_SINK, events.OUTBOX_CONSUMER_WEBHOOKS)
+	apiKeys := NewAPIKeyService(logger, repository.APIKeyRepository)
+	usage := NewUsageService(logger, config.UsageConfig, cache.UsageCache, repository.UsageRepository)
+
+	if threats != nil {
+		destinationPolicy = DestinationPolicies{destinationPolicy, threats}
+	}
+	access := NewPolicy(logger, repository.WorkspaceRepository, repository.MemberRepository)
+	urls := NewURLService(logger, repository.UrlRepository, cache.UrlCache, publisher, usage, destinationPolicy, access, config)
+	schedules := NewScheduleService(logger, config.URLConfig)
+
 	return &Service{
-		UrlShortener: NewURLService(logger, repository.UrlRepository, cache.UrlCache, config),
+		UrlShortener: urls,
+		Clicks:       NewClickService(logger, repository.ClickRepository, repository.UrlRepository, publisher, access, config),
+		Webhooks: NewWebhookService(
+			logger,
+			repository.WebhookRepository,
+			repository.DeliveryRepository,
+			repository.ClickRepository,
+			repository.UrlRepository,
+			access,
+			config.WebhookConfig,
+		),
+		Campaigns: NewCampaignService(logger, repository.UrlRepository, repository.ClickRepository),
+		Targeting: NewTargetingService(logger, locator),
+		Schedules: schedules,
+		Previews: NewPreviewService(
+			logger,
+			pagetitle.NewFetcher(config.URLConfig.GetPreviewTitleTimeout()),
+			cache.TitleCache,
+			config.URLConfig.GetPreviewTitleCacheTTL(),
+		),
+		APIKeys: apiKeys,
+		Tokens:  NewTokenService(logger, config.AuthConfig, keys),
+		Workspaces: NewWorkspaceService(
+			logger,
+			access,
+			repository.WorkspaceRepository,
+			repository.MemberRepository,
+			repository.InvitationRepository,
+			apiKeys,
+		),
+		Usage:   usage,
+		Threats: NewThreatService(logger, config.ThreatListConfig, threats, repository.UrlRepository, urls),
+		Monitor: NewMonitorService(
+			logger,
+			repository.UrlRepository,
+			repository.LinkStateRepository,
+			repository.WebhookRepository,
+			schedules,
+			publisher,
+			config.WebhookConfig,
+		),
 	}
 }
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// keyStore keeps the API keys of the tests in memory, by the hash of their token.
+type keyStore struct {
+	keys map[string]*entity.APIKey
+}
+
+func (s *keyStore) Create(ctx context.Context, key *entity.APIKey) error {
+	s.keys[key.Hash] = key
+	return nil
+}
+
+func (s *keyStore) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	if key, ok := s.keys[hash]; ok {
+		copied := *key
+		return &copied, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s *keyStore) List(ctx context.Context, workspaceID string) ([]*entity.APIKey, error) {
+	keys := []*entity.APIKey{}
+	for _, key := range s.keys {
+		if workspaceID == "" || key.WorkspaceID == workspaceID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *keyStore) Revoke(ctx context.Context, workspaceID, id string, revokedAt time.Time) error {
+	for _, key := range s.keys {
+		if key.ID == id && (workspaceID == "" || key.WorkspaceID == workspaceID) {
+			key.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	store := &keyStore{keys: map[string]*entity.APIKey{}}
+	keys := service.NewAPIKeyService(slog.Default(), store)
+	ctx := context.Background()
+
+	key, token, err := keys.Create(ctx, "ci", "alice", "", []string{entity.SCOPE_LINKS_READ})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	stored, ok := store.keys[hex.EncodeToString(sum[:])]
+	if !ok {
+		t.Fatalf("the key is not stored by the hash of its token")
+	}
+	if stored.Prefix == token || !strings.HasPrefix(token, stored.Prefix) {
+		t.Errorf("stored prefix %q, want the start of the token only", stored.Prefix)
+	}
+
+	authenticated, err := keys.Authenticate(ctx, token)
+	if err != nil {
+		t.Fatalf("authenticate with the token: %v", err)
+	}
+	if authenticated.ID != key.ID {
+		t.Errorf("authenticated key %q, want %q", authenticated.ID, key.ID)
+	}
+
+	for name, token := range map[string]string{
+		"unknown token":    token + "x",
+		"stored hash":      stored.Hash,
+		"without prefix":   strings.TrimPrefix(token, service.API_KEY_TOKEN_PREFIX),
+		"empty credential": "",
+	} {
+		if _, err := keys.Authenticate(ctx, token); !errors.Is(err, service.ErrInvalidAPIKey) {
+			t.Errorf("%s: err = %v, want ErrInvalidAPIKey", name, err)
+		}
+	}
+
+	if err := keys.Revoke(ctx, "", key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Authenticate(ctx, token); !errors.Is(err, service.ErrInvalidAPIKey) {
+		t.Errorf("revoked key: err = %v, want ErrInvalidAPIKey", err)
+	}
+	if err := keys.Revoke(ctx, "", "missing"); !errors.Is(err, service.ErrAPIKeyNotFound) {
+		t.Errorf("revoke a missing key: err = %v, want ErrAPIKeyNotFound", err)
+	}
+}
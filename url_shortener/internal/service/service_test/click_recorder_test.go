@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// clickLog counts the clicks recorded in the click recorder tests.
+type clickLog struct {
+	service.IClickService
+	mu     sync.Mutex
+	shorts []string
+}
+
+func (l *clickLog) Record(ctx context.Context, url entity.IURL, visitor entity.Visitor) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.shorts = append(l.shorts, url.GetShort())
+	return nil
+}
+
+func TestClickRecorderDrain(t *testing.T) {
+	clicks := &clickLog{}
+	counts := newUsageCounts()
+	usage := service.NewUsageService(slog.Default(), usageConfig{}, counts, savedUsage{})
+	recorder := service.NewClickRecorder(slog.Default(), clicks, usage, 2, 1)
+
+	for _, short := range []string{"a", "b", "c"} {
+		recorder.Record(&entity.URL{Short: short, OwnerID: "jane"}, entity.Visitor{})
+	}
+
+	// Shutting down before the recorder runs still records the queued clicks.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder.Run(ctx)
+
+	if len(clicks.shorts) != 2 || clicks.shorts[0] != "a" || clicks.shorts[1] != "b" {
+		t.Errorf("recorded %v, want the 2 clicks queued before the queue was full", clicks.shorts)
+	}
+
+	_, month := entity.UsagePeriods(time.Now())
+	if got := counts.counts["jane "+month].Redirects; got != 2 {
+		t.Errorf("counted %d redirects, want the 2 clicks recorded", got)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// clickStore streams one click of every link of the export tests matching the filter.
+type clickStore struct {
+	repository.IClickRepository
+	shorts []string
+}
+
+func (s clickStore) Stream(ctx context.Context, filter repository.ClickFilter, fn func(entity.IClick) error) error {
+	for _, short := range s.shorts {
+		if filter.Short != "" && short != filter.Short || filter.Shorts != nil && !slices.Contains(filter.Shorts, short) {
+			continue
+		}
+		if err := fn(&entity.Click{Short: short}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportOwnedLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	privacy := mocks.NewMockIPrivacyConfig(ctrl)
+	privacy.EXPECT().GetIPAnonymizationMode().Return(config.IP_ANONYMIZATION_NONE)
+
+	urls := urlStore{urls: map[string]entity.IURL{
+		"a1": &entity.URL{Short: "a1", OwnerID: "alice"},
+		"a2": &entity.URL{Short: "a2", OwnerID: "alice"},
+		"b1": &entity.URL{Short: "b1", OwnerID: "bob"},
+	}}
+	clicks := service.NewClickService(slog.Default(), clickStore{shorts: []string{"a1", "a2", "b1"}}, urls, nil,
+		service.NewPolicy(slog.Default(), nil, nil), &config.Config{PrivacyConfig: privacy})
+
+	alice := service.Actor{UserID: "alice", OwnerID: "alice", Scopes: []string{entity.SCOPE_STATS_READ}}
+	writer := service.Actor{UserID: "alice", OwnerID: "alice", Scopes: []string{entity.SCOPE_LINKS_WRITE}}
+
+	tests := []struct {
+		name   string
+		actor  service.Actor
+		owner  string
+		shorts []string
+		err    error
+	}{
+		{name: "own links", actor: alice, owner: "alice", shorts: []string{"a1", "a2"}},
+		{name: "links of another owner", actor: alice, owner: "bob", err: service.ErrInsufficientScope},
+		{name: "every link", actor: alice, err: service.ErrInsufficientScope},
+		{name: "without stats scope", actor: writer, owner: "alice", err: service.ErrInsufficientScope},
+		{name: "admin", actor: service.Actor{Admin: true}, shorts: []string{"a1", "a2", "b1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := service.ExportOptions{OwnerID: tt.owner, Format: "csv", Fields: []string{"short"}}
+
+			var body strings.Builder
+			err := clicks.Export(context.Background(), tt.actor, opts, &body)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Export() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if body.Len() > 0 {
+					t.Errorf("refused export wrote %q", body.String())
+				}
+				return
+			}
+
+			rows := strings.Fields(body.String())
+			if !slices.Equal(rows[1:], tt.shorts) {
+				t.Errorf("exported %v, want %v", rows[1:], tt.shorts)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+func TestSelectLanguage(t *testing.T) {
+	targeting := service.NewTargetingService(slog.Default(), nil)
+
+	url := &entity.URL{Origin: "https://example.com", Languages: []entity.LanguageRule{
+		{Language: "en", Destination: "https://example.com/en"},
+		{Language: "de", Destination: "https://example.com/de"},
+		{Language: "no", Destination: "https://example.com/no"},
+		{Language: "fr", Destination: "https://example.com/fr"},
+	}}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "exact", acceptLanguage: "fr", want: "https://example.com/fr"},
+		{name: "first preferred", acceptLanguage: "de, fr", want: "https://example.com/de"},
+		{name: "highest quality", acceptLanguage: "fr;q=0.5, de;q=0.9", want: "https://example.com/de"},
+		{name: "unlisted first", acceptLanguage: "ja, fr;q=0.8", want: "https://example.com/fr"},
+		{name: "region", acceptLanguage: "de-AT", want: "https://example.com/de"},
+		{name: "region with quality", acceptLanguage: "en-GB;q=0.4, de-CH;q=0.7", want: "https://example.com/de"},
+		{name: "related language", acceptLanguage: "nb", want: "https://example.com/no"},
+		{name: "no match", acceptLanguage: "ja, zh-CN;q=0.9", want: "https://example.com"},
+		{name: "no header", acceptLanguage: "", want: "https://example.com"},
+		{name: "malformed header", acceptLanguage: "!!;q=x", want: "https://example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := targeting.SelectDestination(url, entity.Visitor{AcceptLanguage: tt.acceptLanguage})
+			if got.URL != tt.want {
+				t.Errorf("SelectDestination(%q) = %q, want %q", tt.acceptLanguage, got.URL, tt.want)
+			}
+		})
+	}
+
+	split := splitOf(1, 0)
+	url.Split = &split
+	if got := targeting.SelectDestination(url, entity.Visitor{AcceptLanguage: "ja"}); got.Variant != "control" {
+		t.Errorf("no matching language picks variant %q, want the split's control", got.Variant)
+	}
+	if got := targeting.SelectDestination(url, entity.Visitor{AcceptLanguage: "de-AT"}); got.URL != "https://example.com/de" || got.Variant != "" {
+		t.Errorf("matching language = %+v, want the de rule before the split", got)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/internal/repository/memory"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// linkStore lists the links of the monitor tests.
+type linkStore struct {
+	repository.IURLRepository
+	urls []entity.IURL
+}
+
+func (s linkStore) ListEnabled(ctx context.Context, after string, limit int64) ([]entity.IURL, error) {
+	return s.urls, nil
+}
+
+// linkStates keeps the link states of the monitor tests in memory.
+type linkStates map[string]*entity.LinkState
+
+func (s linkStates) state(short string) *entity.LinkState {
+	if _, ok := s[short]; !ok {
+		s[short] = &entity.LinkState{Short: short}
+	}
+	return s[short]
+}
+
+func (s linkStates) SetExpired(ctx context.Context, short, expired string) (string, error) {
+	state := s.state(short)
+	previous := state.Expired
+	state.Expired = expired
+	return previous, nil
+}
+
+func (s linkStates) SetHealth(ctx context.Context, short, health string) (string, error) {
+	state := s.state(short)
+	previous := state.Health
+	state.Health = health
+	return previous, nil
+}
+
+// eventLog collects the events published in the monitor tests.
+type eventLog struct{ events []entity.Event }
+
+func (l *eventLog) Publish(ctx context.Context, events ...entity.Event) error {
+	l.events = append(l.events, events...)
+	return nil
+}
+
+func TestMonitorScan(t *testing.T) {
+	expired := &entity.URL{
+		Short:    "http://localhost/s/sale",
+		Origin:   "https://shop.example/sale",
+		OwnerID:  "alice",
+		Schedule: &entity.Schedule{Timezone: "UTC", NotAfter: "2024-01-01T00:00:00Z"},
+	}
+	// The destination is not public, so the check fails without leaving the host.
+	down := &entity.URL{Short: "http://localhost/s/intranet", Origin: "https://127.0.0.1/", OwnerID: "alice"}
+	unwatched := &entity.URL{Short: "http://localhost/s/other", Origin: "https://127.0.0.1/", OwnerID: "bob"}
+
+	webhooks := memory.NewWebhookStore(entity.NewWebhook("w1", "alice", "https://crm.example/hook", "s3cret", []string{entity.EVENT_DESTINATION_HEALTH_CHANGED}, "", 0))
+	states := linkStates{}
+	published := &eventLog{}
+
+	monitor := service.NewMonitorService(
+		slog.Default(),
+		linkStore{urls: []entity.IURL{expired, down, unwatched}},
+		states,
+		webhooks,
+		service.NewScheduleService(slog.Default(), nil),
+		published,
+		webhookConfig{},
+	)
+
+	for scan := 1; scan <= 2; scan++ {
+		if err := monitor.Scan(context.Background()); err != nil {
+			t.Fatalf("scan %d: Scan() error = %v", scan, err)
+		}
+	}
+
+	if len(published.events) != 2 {
+		t.Fatalf("Scan() published %d events, want link.expired and destination.health_changed once: %+v", len(published.events), published.events)
+	}
+
+	expiredEvent, healthEvent := published.events[0], published.events[1]
+	if expiredEvent.Type != entity.EVENT_LINK_EXPIRED || expiredEvent.Short != expired.Short || expiredEvent.OwnerID != "alice" {
+		t.Errorf("first event = %+v, want link.expired of %s", expiredEvent, expired.Short)
+	}
+	if healthEvent.Type != entity.EVENT_DESTINATION_HEALTH_CHANGED || healthEvent.Short != down.Short || healthEvent.Data["health"] != entity.DESTINATION_HEALTH_DOWN {
+		t.Errorf("second event = %+v, want destination.health_changed to down for %s", healthEvent, down.Short)
+	}
+	if _, ok := states[unwatched.Short]; ok {
+		t.Error("Scan() checked a destination without a subscribed webhook")
+	}
+
+	// A new expiration time is published again once passed.
+	expired.Schedule.NotAfter = "2024-06-01T00:00:00Z"
+	if err := monitor.Scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(published.events) != 3 || published.events[2].Type != entity.EVENT_LINK_EXPIRED {
+		t.Errorf("after a new expiration Scan() published %+v, want a second link.expired", published.events[2:])
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+func TestEvaluateSchedule(t *testing.T) {
+	schedules := service.NewScheduleService(slog.Default(), nil)
+
+	url := &entity.URL{
+		Short:  "http://localhost/s/launch",
+		Origin: "https://example.com/teaser",
+		Schedule: &entity.Schedule{
+			Timezone:  "Europe/Berlin",
+			NotBefore: "2024-03-01T09:00",
+			NotAfter:  "2025-01-01T00:00:00Z",
+			Changes: []entity.ScheduledDestination{
+				{From: "2024-03-31T09:00", Destination: "https://example.com/product"},
+				{From: "2024-10-01", Destination: "https://example.com/archive"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		now    string
+		origin string
+		err    error
+	}{
+		{name: "pending", now: "2024-03-01T07:59:00Z", err: service.ErrLinkNotYetActive},
+		{name: "teaser", now: "2024-03-01T08:00:00Z", origin: "https://example.com/teaser"},
+		{name: "teaser before dst local time", now: "2024-03-31T06:59:00Z", origin: "https://example.com/teaser"},
+		{name: "product in summer time", now: "2024-03-31T07:00:00Z", origin: "https://example.com/product"},
+		{name: "archive", now: "2024-09-30T22:00:00Z", origin: "https://example.com/archive"},
+		{name: "expired", now: "2025-01-01T00:00:00Z", err: service.ErrLinkExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.now)
+
+			state, err := schedules.Evaluate(url, now)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Evaluate() error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && state.Origin != tt.origin {
+				t.Errorf("Evaluate() origin = %q, want %q", state.Origin, tt.origin)
+			}
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule entity.Schedule
+		valid    bool
+	}{
+		{name: "window", schedule: entity.Schedule{NotBefore: "2024-03-01", NotAfter: "2024-04-01T12:00"}, valid: true},
+		{name: "unknown timezone", schedule: entity.Schedule{Timezone: "Mars/Olympus", NotBefore: "2024-03-01"}},
+		{name: "reversed window", schedule: entity.Schedule{NotBefore: "2024-04-01", NotAfter: "2024-03-01"}},
+		{name: "unparsable time", schedule: entity.Schedule{NotAfter: "next week"}},
+		{name: "unordered changes", schedule: entity.Schedule{Changes: []entity.ScheduledDestination{
+			{From: "2024-04-01", Destination: "https://example.com/a"},
+			{From: "2024-03-01", Destination: "https://example.com/b"},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateSchedule(tt.schedule)
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidateSchedule() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
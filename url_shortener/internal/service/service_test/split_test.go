@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// splitOf returns a split between the variants control and new with their weights.
+func splitOf(control, variant int) entity.Split {
+	return entity.Split{Destinations: []entity.WeightedDestination{
+		{ID: "control", Destination: "https://example.com/landing", Weight: control},
+		{ID: "new", Destination: "https://example.com/landing-v2", Weight: variant},
+	}}
+}
+
+func TestValidateSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		split entity.Split
+		err   error
+	}{
+		{name: "weighted", split: splitOf(80, 20)},
+		{name: "one without traffic", split: splitOf(0, 1)},
+		{name: "largest weights", split: splitOf(service.MAX_SPLIT_WEIGHT, service.MAX_SPLIT_WEIGHT)},
+		{name: "overflowing weights", split: splitOf(math.MaxInt, math.MaxInt), err: service.ErrInvalidSplit},
+		{name: "weight too large", split: splitOf(service.MAX_SPLIT_WEIGHT+1, 1), err: service.ErrInvalidSplit},
+		{name: "negative weight", split: splitOf(-1, 2), err: service.ErrInvalidSplit},
+		{name: "no traffic", split: splitOf(0, 0), err: service.ErrInvalidSplit},
+		{name: "one destination", split: entity.Split{Destinations: splitOf(1, 1).Destinations[:1]}, err: service.ErrInvalidSplit},
+		{name: "repeated id", split: entity.Split{Destinations: []entity.WeightedDestination{
+			{ID: "a", Destination: "https://example.com/1", Weight: 1},
+			{ID: "a", Destination: "https://example.com/2", Weight: 1},
+		}}, err: service.ErrInvalidSplit},
+		{name: "malformed id", split: entity.Split{Destinations: []entity.WeightedDestination{
+			{ID: "a b", Destination: "https://example.com/1", Weight: 1},
+			{ID: "c", Destination: "https://example.com/2", Weight: 1},
+		}}, err: service.ErrInvalidSplit},
+		{name: "invalid destination", split: entity.Split{Destinations: []entity.WeightedDestination{
+			{ID: "a", Destination: "not a url", Weight: 1},
+			{ID: "b", Destination: "https://example.com/2", Weight: 1},
+		}}, err: service.ErrInvalidSplit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.ValidateSplit(tt.split); !errors.Is(err, tt.err) {
+				t.Errorf("ValidateSplit() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestChooseVariant(t *testing.T) {
+	targeting := service.NewTargetingService(slog.Default(), nil)
+
+	choose := func(split entity.Split, sticky bool, assigned string) string {
+		split.Sticky = sticky
+		url := &entity.URL{Origin: "https://example.com", Split: &split}
+		return targeting.SelectDestination(url, entity.Visitor{Variant: assigned}).Variant
+	}
+
+	for i := 0; i < 100; i++ {
+		if got := choose(splitOf(0, 1), false, ""); got != "new" {
+			t.Fatalf("variant = %q, want new, the only one with traffic", got)
+		}
+	}
+	if got := choose(splitOf(1, 0), true, "control"); got != "control" {
+		t.Errorf("sticky variant = %q, want the assigned control", got)
+	}
+	if got := choose(splitOf(1, 0), true, "new"); got != "control" {
+		t.Errorf("sticky variant without traffic = %q, want a new draw, control", got)
+	}
+	if got := choose(splitOf(1, 0), true, "removed"); got != "control" {
+		t.Errorf("sticky variant removed = %q, want a new draw, control", got)
+	}
+	if got := choose(splitOf(0, 1), false, "control"); got != "new" {
+		t.Errorf("assigned variant of a split that is not sticky = %q, want a new draw, new", got)
+	}
+	if got := choose(splitOf(0, 0), false, ""); got != "control" {
+		t.Errorf("variant of a split without traffic = %q, want the first one", got)
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		counts[choose(splitOf(3, 1), false, "")]++
+	}
+	if counts["control"] < 1300 || counts["control"] > 1700 {
+		t.Errorf("control drawn %d times of 2000, want about 1500 for a weight of 3 to 1", counts["control"])
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/pkg/jwks"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenConfig is the authentication configuration of the token tests.
+type tokenConfig struct{}
+
+func (tokenConfig) GetEnabled() bool                      { return true }
+func (tokenConfig) GetJWKS() string                       { return "testdata/jwks.json" }
+func (tokenConfig) GetJWKSRefreshInterval() time.Duration { return time.Hour }
+func (tokenConfig) GetJWTIssuer() string                  { return "https://sso.example.com" }
+func (tokenConfig) GetJWTAudience() string                { return "url-shortener" }
+func (tokenConfig) GetJWTAlgorithms() []string            { return []string{"RS256", "ES256"} }
+func (tokenConfig) GetJWTLeeway() time.Duration           { return time.Minute }
+func (tokenConfig) GetJWTSubjectClaim() string            { return "email" }
+func (tokenConfig) GetJWTScopesClaim() string             { return "scope" }
+func (tokenConfig) GetJWTDefaultScopes() []string         { return []string{"links:read"} }
+
+// signingKey reads a private key of the JWKS fixture.
+func signingKey(t *testing.T, path string) interface{} {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return key
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	config := tokenConfig{}
+	tokens := service.NewTokenService(slog.Default(), config, jwks.NewKeySet(config.GetJWKS(), config.GetJWKSRefreshInterval()))
+
+	rsaKey := signingKey(t, "testdata/rsa.pem")
+	ecKey := signingKey(t, "testdata/ec.pem")
+
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   "https://sso.example.com",
+			"aud":   []string{"url-shortener", "other"},
+			"sub":   "248289761001",
+			"email": "jane@example.com",
+			"scope": "openid links:write unknown:scope",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+		}
+	}
+	with := func(name string, value interface{}) jwt.MapClaims {
+		claims := valid()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		kid    string
+		key    interface{}
+		claims jwt.MapClaims
+		err    error
+	}{
+		{name: "rsa", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: valid()},
+		{name: "ec", method: jwt.SigningMethodES256, kid: "ec-1", key: ecKey, claims: valid()},
+		{name: "scopes list", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("scope", []string{"links:write"})},
+		{name: "unknown key", method: jwt.SigningMethodRS256, kid: "rsa-2", key: rsaKey, claims: valid(), err: service.ErrInvalidToken},
+		{name: "key of another id", method: jwt.SigningMethodES256, kid: "rsa-1", key: ecKey, claims: valid(), err: service.ErrInvalidToken},
+		{name: "algorithm not accepted", method: jwt.SigningMethodRS512, kid: "rsa-1", key: rsaKey, claims: valid(), err: service.ErrInvalidToken},
+		{name: "other issuer", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("iss", "https://evil.example.com"), err: service.ErrInvalidToken},
+		{name: "other audience", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("aud", "other"), err: service.ErrInvalidToken},
+		{name: "expired", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("exp", now.Add(-2*time.Minute).Unix()), err: service.ErrInvalidToken},
+		{name: "no expiration", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("exp", nil), err: service.ErrInvalidToken},
+		{name: "no subject", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("email", nil), err: service.ErrInvalidToken},
+		{name: "workspace subject", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("email", "workspace:acme"), err: service.ErrInvalidToken},
+		{name: "API key subject", method: jwt.SigningMethodRS256, kid: "rsa-1", key: rsaKey, claims: with("email", "key:k1"), err: service.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.NewWithClaims(tt.method, tt.claims)
+			token.Header["kid"] = tt.kid
+
+			signed, err := token.SignedString(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			identity, err := tokens.Authenticate(context.Background(), signed)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			if identity.Subject != "jane@example.com" {
+				t.Errorf("Authenticate() subject = %q, want the email claim", identity.Subject)
+			}
+			if want := []string{"links:read", "links:write"}; !slices.Equal(identity.Scopes, want) {
+				t.Errorf("Authenticate() scopes = %v, want %v", identity.Scopes, want)
+			}
+		})
+	}
+}
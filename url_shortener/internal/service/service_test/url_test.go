@@ -1,7 +1,18 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"testing"
+
+	"github.com/flew1x/url_shortener_ms/internal/cache"
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+	"github.com/flew1x/url_shortener_ms/mocks"
+	"go.uber.org/mock/gomock"
 )
 
 func TestBuildShortURL(t *testing.T) {}
@@ -13,3 +24,215 @@ func TestGetShortLink(t *testing.T) {}
 func TestDeleteShortLink(t *testing.T) {}
 
 func TestGetAllShortLinks(t *testing.T) {}
+
+// urlStore keeps the links of the URL tests in memory.
+type urlStore struct {
+	repository.IURLRepository
+	urls map[string]entity.IURL
+}
+
+func (s urlStore) GetByShort(ctx context.Context, short string) (entity.IURL, error) {
+	if url, ok := s.urls[short]; ok {
+		return url, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s urlStore) Create(ctx context.Context, url entity.IURL) error {
+	if _, ok := s.urls[url.GetShort()]; ok {
+		return repository.ErrAlreadyExists
+	}
+	s.urls[url.GetShort()] = url
+	return nil
+}
+
+func (s urlStore) Delete(ctx context.Context, short string) error {
+	if _, ok := s.urls[short]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(s.urls, short)
+	return nil
+}
+
+func (s urlStore) ListByOwner(ctx context.Context, ownerID string) ([]entity.IURL, error) {
+	urls := []entity.IURL{}
+	for _, url := range s.urls {
+		if url.GetOwnerID() == ownerID {
+			urls = append(urls, url)
+		}
+	}
+	return urls, nil
+}
+
+func (s urlStore) Update(ctx context.Context, url entity.IURL) error {
+	s.urls[url.GetShort()] = url
+	return nil
+}
+
+// urlCache is the cache of the URL tests, which caches nothing.
+type urlCache struct{ cache.IUrlCache }
+
+func (urlCache) GetByShortUrl(ctx context.Context, shortUrl string) (entity.IURL, error) {
+	return nil, repository.ErrNotFound
+}
+
+func (urlCache) SetByShortUrl(ctx context.Context, url entity.IURL) error {
+	return nil
+}
+
+func (urlCache) SetByLongUrl(ctx context.Context, url entity.IURL) error {
+	return nil
+}
+
+func (urlCache) GetByLongUrl(ctx context.Context, ownerID, longUrl string) (entity.IURL, error) {
+	return nil, repository.ErrNotFound
+}
+
+func TestUpdateDisabledBy(t *testing.T) {
+	const short = "http://localhost/s/promo"
+
+	owner := service.Actor{UserID: "alice", OwnerID: "alice", Scopes: []string{entity.SCOPE_LINKS_WRITE}}
+	admin := service.Actor{Admin: true}
+
+	tests := []struct {
+		name     string
+		by       string
+		actor    service.Actor
+		disabled bool
+		reason   string
+		want     string
+		err      error
+	}{
+		{name: "owner disables", actor: owner, disabled: true, reason: "Paused", want: entity.DISABLED_BY_OWNER},
+		{name: "admin disables", actor: admin, disabled: true, reason: "Abuse", want: entity.DISABLED_BY_ADMIN},
+		{name: "owner enables own", by: entity.DISABLED_BY_OWNER, actor: owner},
+		{name: "owner cannot enable admin", by: entity.DISABLED_BY_ADMIN, actor: owner, err: service.ErrLinkLocked},
+		{name: "owner cannot enable threat", by: entity.DISABLED_BY_THREAT, actor: owner, err: service.ErrLinkLocked},
+		{name: "owner cannot reword threat", by: entity.DISABLED_BY_THREAT, actor: owner, disabled: true, reason: "Fine", err: service.ErrLinkLocked},
+		{name: "owner edits locked link", by: entity.DISABLED_BY_THREAT, actor: owner, disabled: true, reason: "Malware", want: entity.DISABLED_BY_THREAT},
+		{name: "admin enables threat", by: entity.DISABLED_BY_THREAT, actor: admin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := &entity.URL{Short: short, Origin: "https://shop.example/promo", OwnerID: "alice"}
+			if tt.by != "" {
+				previous.Disabled, previous.DisabledReason, previous.DisabledBy = true, "Malware", tt.by
+			}
+			store := urlStore{urls: map[string]entity.IURL{short: previous}}
+			urls := service.NewURLService(slog.Default(), store, urlCache{}, &eventLog{}, nil, service.DestinationPolicies{}, service.NewPolicy(slog.Default(), nil, nil), &config.Config{})
+
+			updated := entity.CopyURL(previous)
+			updated.Origin = "https://shop.example/spring"
+			updated.Disabled, updated.DisabledReason = tt.disabled, tt.reason
+
+			stored, err := urls.Update(context.Background(), tt.actor, updated)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if store.urls[short] != previous {
+					t.Error("Update() changed a locked link")
+				}
+				return
+			}
+
+			if got := stored.GetDisabledBy(); got != tt.want {
+				t.Errorf("Update() disabled by = %q, want %q", got, tt.want)
+			}
+			if got := store.urls[short].GetDisabledBy(); got != tt.want {
+				t.Errorf("stored disabled by = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// urlConfig returns the configuration of the URL tests, serving short URLs from http://localhost.
+func urlConfig(ctrl *gomock.Controller) *config.Config {
+	server := mocks.NewMockIServerConfig(ctrl)
+	server.EXPECT().GetScheme().Return("http").AnyTimes()
+	server.EXPECT().GetBindIP().Return("localhost").AnyTimes()
+
+	urls := mocks.NewMockIURLConfig(ctrl)
+	urls.EXPECT().LengthShortURL().Return(8).AnyTimes()
+
+	return &config.Config{ServerConfig: server, URLConfig: urls}
+}
+
+func TestCreateDedupPerOwner(t *testing.T) {
+	const origin = "https://shop.example/promo"
+
+	ctrl := gomock.NewController(t)
+	existing := &entity.URL{Short: "http://localhost/s/promo", Origin: origin, OwnerID: "alice"}
+	store := urlStore{urls: map[string]entity.IURL{existing.Short: existing}}
+
+	cache := mocks.NewMockIUrlCache(ctrl)
+	cache.EXPECT().GetByLongUrl(gomock.Any(), "alice", origin).Return(existing, nil)
+	cache.EXPECT().GetByLongUrl(gomock.Any(), "bob", origin).Return(nil, repository.ErrNotFound)
+	cache.EXPECT().SetByLongUrl(gomock.Any(), gomock.Any()).Return(nil)
+	cache.EXPECT().SetByShortUrl(gomock.Any(), gomock.Any()).Return(nil)
+
+	usage := service.NewUsageService(slog.Default(), usageConfig{}, newUsageCounts(), savedUsage{})
+	urls := service.NewURLService(slog.Default(), store, cache, &eventLog{}, usage, service.DestinationPolicies{}, service.NewPolicy(slog.Default(), nil, nil), urlConfig(ctrl))
+
+	writer := func(owner string) service.Actor {
+		return service.Actor{UserID: owner, OwnerID: owner, Scopes: []string{entity.SCOPE_LINKS_WRITE}}
+	}
+
+	short, err := urls.Create(context.Background(), writer("alice"), origin, service.LinkOptions{})
+	if err != nil || short != existing.Short {
+		t.Fatalf("Create() by the owner = %q, %v, want the existing link %q", short, err, existing.Short)
+	}
+
+	short, err = urls.Create(context.Background(), writer("bob"), origin, service.LinkOptions{})
+	if err != nil {
+		t.Fatalf("Create() by another owner error = %v", err)
+	}
+	if short == existing.Short {
+		t.Fatal("Create() by another owner returned the link of alice")
+	}
+	if created, ok := store.urls[short]; !ok || created.GetOwnerID() != "bob" {
+		t.Errorf("Create() by another owner stored %v, want a link of bob", created)
+	}
+}
+
+func TestGetNotOwner(t *testing.T) {
+	link := &entity.URL{Short: "http://localhost/s/promo", Origin: "https://shop.example/promo", OwnerID: "alice"}
+	store := urlStore{urls: map[string]entity.IURL{link.Short: link}}
+	urls := service.NewURLService(slog.Default(), store, urlCache{}, &eventLog{}, nil, service.DestinationPolicies{}, service.NewPolicy(slog.Default(), nil, nil), &config.Config{})
+
+	reader := func(owner string) service.Actor {
+		return service.Actor{UserID: owner, OwnerID: owner, Scopes: []string{entity.SCOPE_LINKS_READ}}
+	}
+
+	if _, err := urls.Get(context.Background(), reader("bob"), link.Short, entity.SCOPE_LINKS_READ); !errors.Is(err, service.ErrLinkNotFound) {
+		t.Errorf("Get() by another owner error = %v, want ErrLinkNotFound", err)
+	}
+	if _, err := urls.Get(context.Background(), reader("alice"), "http://localhost/s/unknown", entity.SCOPE_LINKS_READ); !errors.Is(err, service.ErrLinkNotFound) {
+		t.Errorf("Get() of an unknown link error = %v, want ErrLinkNotFound", err)
+	}
+	if got, err := urls.Get(context.Background(), reader("alice"), link.Short, entity.SCOPE_LINKS_READ); err != nil || got != link {
+		t.Errorf("Get() by the owner = %v, %v, want the link", got, err)
+	}
+}
+
+func TestDeleteForgetsCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	link := &entity.URL{Short: "http://localhost/s/promo", Origin: "https://shop.example/promo", OwnerID: "alice"}
+	store := urlStore{urls: map[string]entity.IURL{link.Short: link}}
+
+	cache := mocks.NewMockIUrlCache(ctrl)
+	cache.EXPECT().DeleteByShortUrl(gomock.Any(), link.Short).Return(nil)
+	cache.EXPECT().GetByLongUrl(gomock.Any(), "alice", link.Origin).Return(link, nil)
+	cache.EXPECT().DeleteByLongUrl(gomock.Any(), "alice", link.Origin).Return(nil)
+
+	urls := service.NewURLService(slog.Default(), store, cache, &eventLog{}, nil, service.DestinationPolicies{}, service.NewPolicy(slog.Default(), nil, nil), &config.Config{})
+
+	owner := service.Actor{UserID: "alice", OwnerID: "alice", Scopes: []string{entity.SCOPE_LINKS_WRITE}}
+	if err := urls.Delete(context.Background(), owner, link.Short); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := store.urls[link.Short]; ok {
+		t.Error("Delete() kept the link")
+	}
+}
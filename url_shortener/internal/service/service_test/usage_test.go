@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// usageConfig is the usage configuration of the usage tests.
+type usageConfig struct{}
+
+func (usageConfig) GetEnabled() bool                { return true }
+func (usageConfig) GetFlushInterval() time.Duration { return time.Minute }
+func (usageConfig) GetDefaultPlan() string          { return "free" }
+func (usageConfig) GetPlans() map[string]config.UsagePlan {
+	return map[string]config.UsagePlan{
+		"free":      {DailyLinks: 2, MonthlyLinks: 3},
+		"unlimited": {},
+	}
+}
+func (usageConfig) GetAccountPlans() []config.AccountPlan {
+	return []config.AccountPlan{{Account: "workspace:acme", Plan: "unlimited"}}
+}
+
+// usageCounts keeps the counts of the usage tests in memory.
+type usageCounts struct {
+	counts map[string]*entity.Usage
+	seeded map[string]bool
+	err    error
+}
+
+func newUsageCounts() *usageCounts {
+	return &usageCounts{counts: map[string]*entity.Usage{}, seeded: map[string]bool{}}
+}
+
+func (u *usageCounts) Increment(ctx context.Context, account, metric string, periods []string) error {
+	for _, period := range periods {
+		usage, ok := u.counts[account+" "+period]
+		if !ok {
+			usage = entity.NewUsage(account, period)
+			u.counts[usage.ID] = usage
+		}
+		if metric == entity.USAGE_LINKS {
+			usage.Links++
+		} else {
+			usage.Redirects++
+		}
+	}
+	return nil
+}
+
+func (u *usageCounts) Get(ctx context.Context, account string, periods []string) ([]*entity.Usage, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	usages := []*entity.Usage{}
+	for _, period := range periods {
+		if usage, ok := u.counts[account+" "+period]; ok {
+			usages = append(usages, usage)
+		} else {
+			usages = append(usages, entity.NewUsage(account, period))
+		}
+	}
+	return usages, nil
+}
+
+func (u *usageCounts) Unseeded(ctx context.Context, account string, periods []string) ([]string, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	unseeded := []string{}
+	for _, period := range periods {
+		if !u.seeded[account+" "+period] {
+			unseeded = append(unseeded, period)
+		}
+	}
+	return unseeded, nil
+}
+
+func (u *usageCounts) Seed(ctx context.Context, usages []*entity.Usage) error {
+	for _, saved := range usages {
+		usage, ok := u.counts[saved.ID]
+		if !ok {
+			usage = entity.NewUsage(saved.Account, saved.Period)
+			u.counts[usage.ID] = usage
+		}
+		usage.Merge(saved)
+		u.seeded[saved.ID] = true
+	}
+	return nil
+}
+
+func (u *usageCounts) PopDirty(ctx context.Context, count int64) ([]*entity.Usage, error) {
+	return nil, nil
+}
+
+func (u *usageCounts) MarkDirty(ctx context.Context, usages []*entity.Usage) error {
+	return nil
+}
+
+// savedUsage keeps the saved counts of the usage tests in memory.
+type savedUsage []*entity.Usage
+
+func (s savedUsage) Save(ctx context.Context, usages []*entity.Usage) error {
+	return nil
+}
+
+func (s savedUsage) List(ctx context.Context, account, periodPrefix string) ([]*entity.Usage, error) {
+	usages := []*entity.Usage{}
+	for _, usage := range s {
+		if usage.Account == account && strings.HasPrefix(usage.Period, periodPrefix) {
+			usages = append(usages, usage)
+		}
+	}
+	return usages, nil
+}
+
+func TestUsageQuota(t *testing.T) {
+	ctx := context.Background()
+	counts := newUsageCounts()
+	usage := service.NewUsageService(slog.Default(), usageConfig{}, counts, savedUsage{})
+
+	create := func(account string) error {
+		if err := usage.Check(ctx, account, entity.USAGE_LINKS); err != nil {
+			return err
+		}
+		return usage.Record(ctx, account, entity.USAGE_LINKS)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := create("jane"); err != nil {
+			t.Fatalf("link %d: Check() error = %v, want nil", i+1, err)
+		}
+	}
+
+	var quotaError *service.QuotaError
+	err := create("jane")
+	if !errors.As(err, &quotaError) || !errors.Is(err, service.ErrQuotaExceeded) {
+		t.Fatalf("third link: Check() error = %v, want a QuotaError", err)
+	}
+	if quotaError.Window != entity.USAGE_WINDOW_DAY || quotaError.Limit != 2 || !quotaError.ResetAt.After(time.Now()) {
+		t.Errorf("third link: QuotaError = %+v, want the daily quota of 2 resetting tomorrow", quotaError)
+	}
+
+	if err := usage.Check(ctx, "jane", entity.USAGE_REDIRECTS); err != nil {
+		t.Errorf("redirect: Check() error = %v, want nil for a metric without quota", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := create("workspace:acme"); err != nil {
+			t.Fatalf("account plan: Check() error = %v, want nil for an unlimited plan", err)
+		}
+	}
+
+	if err := create(""); err != nil {
+		t.Errorf("no account: Check() error = %v, want nil", err)
+	}
+
+	counts.err = errors.New("connection refused")
+	if err := usage.Check(ctx, "jane", entity.USAGE_LINKS); err != nil {
+		t.Errorf("Redis down: Check() error = %v, want nil", err)
+	}
+}
+
+func TestUsageQuotaSeeded(t *testing.T) {
+	ctx := context.Background()
+	day, month := entity.UsagePeriods(time.Now())
+
+	// Redis lost the counts of the day, which were saved before.
+	saved := entity.NewUsage("jane", day)
+	saved.Links = 2
+	counts := newUsageCounts()
+	usage := service.NewUsageService(slog.Default(), usageConfig{}, counts, savedUsage{saved})
+
+	if err := usage.Check(ctx, "jane", entity.USAGE_LINKS); !errors.Is(err, service.ErrQuotaExceeded) {
+		t.Fatalf("Check() error = %v, want the saved daily quota used up", err)
+	}
+	if got := counts.counts["jane "+day].Links; got != 2 {
+		t.Errorf("seeded day links = %d, want 2", got)
+	}
+	if !counts.seeded["jane "+month] {
+		t.Error("month never saved was not seeded")
+	}
+}
+
+func TestUsageRecordSeeded(t *testing.T) {
+	ctx := context.Background()
+	day, month := entity.UsagePeriods(time.Now())
+
+	// Redis lost the counts of an unlimited account, which were saved before.
+	saved := entity.NewUsage("workspace:acme", month)
+	saved.Redirects = 100
+	counts := newUsageCounts()
+	usage := service.NewUsageService(slog.Default(), usageConfig{}, counts, savedUsage{saved})
+
+	if err := usage.Record(ctx, "workspace:acme", entity.USAGE_REDIRECTS); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	if got := counts.counts["workspace:acme "+month].Redirects; got != 101 {
+		t.Errorf("month redirects = %d, want the 100 saved and the one recorded", got)
+	}
+	if got := counts.counts["workspace:acme "+day].Redirects; got != 1 {
+		t.Errorf("day redirects = %d, want 1", got)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository/memory"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// webhookConfig is the webhook configuration of the webhook tests.
+type webhookConfig struct{}
+
+func (webhookConfig) GetTimeout() time.Duration          { return time.Second }
+func (webhookConfig) GetMaxAttempts() int                { return 5 }
+func (webhookConfig) GetInitialBackoff() time.Duration   { return 30 * time.Second }
+func (webhookConfig) GetMaxBackoff() time.Duration       { return 2 * time.Minute }
+func (webhookConfig) GetDispatchInterval() time.Duration { return time.Second }
+func (webhookConfig) GetBatchSize() int                  { return 10 }
+func (webhookConfig) GetMonitorInterval() time.Duration  { return time.Minute }
+
+func newWebhookService(store *memory.WebhookStore) *service.WebhookService {
+	return service.NewWebhookService(slog.Default(), store, memory.NewDeliveryStore(store), nil, nil, service.NewPolicy(slog.Default(), nil, nil), webhookConfig{})
+}
+
+// webhookManager returns an actor managing the webhooks of an owner.
+func webhookManager(ownerID string) service.Actor {
+	return service.Actor{UserID: ownerID, OwnerID: ownerID, Scopes: []string{entity.SCOPE_WEBHOOKS}}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	want := "sha256=e584ea1bae10bbbc105c193bee45ee128f39b60803189b2673b3e878b94997c8"
+
+	if got := service.SignWebhookPayload("s3cret", "1700000000", `{"id":"e1"}`); got != want {
+		t.Errorf("SignWebhookPayload() = %q, want %q", got, want)
+	}
+	if got := service.SignWebhookPayload("s3cret", "1700000001", `{"id":"e1"}`); got == want {
+		t.Error("SignWebhookPayload() ignores the timestamp")
+	}
+	if got := service.SignWebhookPayload("other", "1700000000", `{"id":"e1"}`); got == want {
+		t.Error("SignWebhookPayload() ignores the secret")
+	}
+}
+
+func TestRegisterWebhookURL(t *testing.T) {
+	tests := []struct {
+		url string
+		err error
+	}{
+		{url: "https://crm.example/hooks/links"},
+		{url: "https://93.184.216.34:8443/hook"},
+		{url: "http://crm.example/hooks/links", err: service.ErrInvalidWebhookURL},
+		{url: "https://localhost/hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://api.localhost./hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://127.0.0.1/hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://10.1.2.3/hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://169.254.169.254/latest/meta-data", err: service.ErrInvalidWebhookURL},
+		{url: "https://[::1]/hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://[fd00::1]/hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://100.64.0.1/hook", err: service.ErrInvalidWebhookURL},
+		{url: "https://[64:ff9b::a00:1]/hook", err: service.ErrInvalidWebhookURL},
+	}
+
+	webhooks := newWebhookService(memory.NewWebhookStore())
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			_, err := webhooks.Register(context.Background(), webhookManager("alice"), tt.url, []string{entity.EVENT_LINK_CREATED}, "", 0)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Register() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	// The endpoint is not public, so every attempt is refused by the
+	// delivery client without leaving the host.
+	store := memory.NewWebhookStore(entity.NewWebhook("w1", "alice", "https://127.0.0.1/hook", "s3cret", []string{entity.EVENT_LINK_CLICKED}, "", 0))
+	delivery := entity.NewWebhookDelivery("d1", "w1", entity.Event{ID: "e1", Type: entity.EVENT_LINK_CLICKED}, `{"id":"e1"}`)
+	store.Deliveries[delivery.ID] = delivery
+
+	webhooks := newWebhookService(store)
+
+	for attempt, want := range []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 2 * time.Minute} {
+		if err := webhooks.DeliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if delivery.Attempts != attempt+1 || delivery.Status != entity.DELIVERY_STATUS_PENDING {
+			t.Fatalf("after attempt %d: attempts = %d, status = %q", attempt+1, delivery.Attempts, delivery.Status)
+		}
+		if got := delivery.NextAttemptAt.Sub(delivery.UpdatedAt); got != want {
+			t.Errorf("after attempt %d: backoff = %v, want %v", attempt+1, got, want)
+		}
+		if !strings.Contains(delivery.LastError, "not public") {
+			t.Errorf("after attempt %d: last error = %q, want the address refused", attempt+1, delivery.LastError)
+		}
+	}
+
+	if err := webhooks.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Attempts != 5 || delivery.Status != entity.DELIVERY_STATUS_FAILED {
+		t.Errorf("after the last attempt: attempts = %d, status = %q, want 5 and failed", delivery.Attempts, delivery.Status)
+	}
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	store := memory.NewWebhookStore(
+		entity.NewWebhook("w1", "alice", "https://crm.example/hook", "s3cret", []string{entity.EVENT_LINK_CLICKED}, "", 0),
+		entity.NewWebhook("w2", "alice", "https://crm.example/other", "s3cret", []string{entity.EVENT_LINK_CLICKED}, "", 0),
+	)
+	original := entity.NewWebhookDelivery("d1", "w1", entity.Event{ID: "e1", Type: entity.EVENT_LINK_CLICKED}, `{"id":"e1"}`)
+	original.Status = entity.DELIVERY_STATUS_FAILED
+	original.Attempts = 5
+	store.Deliveries[original.ID] = original
+
+	webhooks := newWebhookService(store)
+	ctx := context.Background()
+
+	replay, err := webhooks.Replay(ctx, webhookManager("alice"), "w1", "d1")
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if replay.ID == original.ID || replay.ReplayOf != original.ID || replay.Payload != original.Payload || replay.EventID != original.EventID {
+		t.Errorf("Replay() = %+v, want a new delivery of the original payload", replay)
+	}
+	if replay.Status != entity.DELIVERY_STATUS_PENDING || replay.Attempts != 0 {
+		t.Errorf("Replay() status = %q, attempts = %d, want a pending delivery", replay.Status, replay.Attempts)
+	}
+	if _, ok := store.Deliveries[replay.ID]; !ok {
+		t.Error("Replay() did not store the new delivery")
+	}
+	if original.Status != entity.DELIVERY_STATUS_FAILED || original.Attempts != 5 {
+		t.Error("Replay() changed the original delivery")
+	}
+
+	if _, err := webhooks.Replay(ctx, webhookManager("alice"), "w2", "d1"); !errors.Is(err, service.ErrDeliveryNotFound) {
+		t.Errorf("Replay() of a delivery of another webhook error = %v, want ErrDeliveryNotFound", err)
+	}
+	if _, err := webhooks.Replay(ctx, webhookManager("alice"), "w1", "unknown"); !errors.Is(err, service.ErrDeliveryNotFound) {
+		t.Errorf("Replay() of an unknown delivery error = %v, want ErrDeliveryNotFound", err)
+	}
+	if _, err := webhooks.Replay(ctx, webhookManager("bob"), "w1", "d1"); !errors.Is(err, service.ErrWebhookNotFound) {
+		t.Errorf("Replay() by another owner error = %v, want ErrWebhookNotFound", err)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/internal/service"
+)
+
+// workspaceStore keeps the workspaces and members of the workspace tests in memory.
+type workspaceStore struct {
+	workspaces map[string]*entity.Workspace
+	members    map[string]*entity.Member
+}
+
+func newWorkspaceStore(members ...*entity.Member) *workspaceStore {
+	store := &workspaceStore{
+		workspaces: map[string]*entity.Workspace{"acme": entity.NewWorkspace("acme", "Acme", "olivia")},
+		members:    map[string]*entity.Member{},
+	}
+	for _, member := range members {
+		store.members[member.ID] = member
+	}
+	return store
+}
+
+func (s *workspaceStore) Create(ctx context.Context, workspace *entity.Workspace) error {
+	s.workspaces[workspace.ID] = workspace
+	return nil
+}
+
+func (s *workspaceStore) Get(ctx context.Context, id string) (*entity.Workspace, error) {
+	if workspace, ok := s.workspaces[id]; ok {
+		return workspace, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s *workspaceStore) List(ctx context.Context, ids []string) ([]*entity.Workspace, error) {
+	return nil, nil
+}
+
+// memberStore adapts workspaceStore to repository.IMemberRepository.
+type memberStore struct{ *workspaceStore }
+
+func (s memberStore) Create(ctx context.Context, member *entity.Member) error {
+	if _, ok := s.members[member.ID]; ok {
+		return repository.ErrAlreadyExists
+	}
+	s.members[member.ID] = member
+	return nil
+}
+
+func (s memberStore) Get(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+	if member, ok := s.members[workspaceID+" "+userID]; ok {
+		return member, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s memberStore) List(ctx context.Context, workspaceID string) ([]*entity.Member, error) {
+	return nil, nil
+}
+
+func (s memberStore) ListByUser(ctx context.Context, userID string) ([]*entity.Member, error) {
+	return nil, nil
+}
+
+func (s memberStore) CountRole(ctx context.Context, workspaceID, role string) (int64, error) {
+	var count int64
+	for _, member := range s.members {
+		if member.WorkspaceID == workspaceID && member.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s memberStore) UpdateRole(ctx context.Context, workspaceID, userID, role string) error {
+	member, err := s.Get(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	member.Role = role
+	return nil
+}
+
+func (s memberStore) Delete(ctx context.Context, workspaceID, userID string) error {
+	if _, err := s.Get(ctx, workspaceID, userID); err != nil {
+		return err
+	}
+	delete(s.members, workspaceID+" "+userID)
+	return nil
+}
+
+func TestWorkspaceRoles(t *testing.T) {
+	user := func(id string) service.Actor { return service.Actor{UserID: id} }
+
+	tests := []struct {
+		name    string
+		actor   service.Actor
+		userID  string
+		role    string
+		err     error
+		members []*entity.Member
+	}{
+		{name: "admin promotes viewer", actor: user("adam"), userID: "vera", role: entity.ROLE_EDITOR},
+		{name: "editor cannot manage members", actor: user("eve"), userID: "vera", role: entity.ROLE_EDITOR, err: service.ErrInsufficientRole},
+		{name: "admin cannot grant owner", actor: user("adam"), userID: "vera", role: entity.ROLE_OWNER, err: service.ErrInsufficientRole},
+		{name: "admin cannot demote owner", actor: user("adam"), userID: "olivia", role: entity.ROLE_VIEWER, err: service.ErrInsufficientRole},
+		{name: "last owner keeps the role", actor: user("olivia"), userID: "olivia", role: entity.ROLE_ADMIN, err: service.ErrLastOwner},
+		{name: "owner grants owner", actor: user("olivia"), userID: "adam", role: entity.ROLE_OWNER},
+		{name: "outsider sees no workspace", actor: user("mallory"), userID: "vera", role: entity.ROLE_ADMIN, err: service.ErrWorkspaceNotFound},
+		{name: "operator acts as owner", actor: service.Actor{Admin: true}, userID: "olivia", role: entity.ROLE_ADMIN,
+			members: []*entity.Member{entity.NewMember("acme", "otto", entity.ROLE_OWNER)}},
+		{name: "unknown role", actor: user("olivia"), userID: "vera", role: "superuser", err: service.ErrUnknownRole},
+		{name: "unknown member", actor: user("olivia"), userID: "mallory", role: entity.ROLE_VIEWER, err: service.ErrMemberNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newWorkspaceStore(append([]*entity.Member{
+				entity.NewMember("acme", "olivia", entity.ROLE_OWNER),
+				entity.NewMember("acme", "adam", entity.ROLE_ADMIN),
+				entity.NewMember("acme", "eve", entity.ROLE_EDITOR),
+				entity.NewMember("acme", "vera", entity.ROLE_VIEWER),
+			}, tt.members...)...)
+			members := memberStore{store}
+			workspaces := service.NewWorkspaceService(slog.Default(), service.NewPolicy(slog.Default(), store, members), store, members, nil, nil)
+
+			err := workspaces.SetRole(context.Background(), tt.actor, "acme", tt.userID, tt.role)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SetRole() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			if member, _ := members.Get(context.Background(), "acme", tt.userID); member.Role != tt.role {
+				t.Errorf("SetRole() role = %q, want %q", member.Role, tt.role)
+			}
+		})
+	}
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	acme := entity.WorkspaceOwnerID("acme")
+	scopes := []string{entity.SCOPE_LINKS_READ, entity.SCOPE_LINKS_WRITE}
+	user := func(id string) service.Actor { return service.Actor{UserID: id, OwnerID: id, Scopes: scopes} }
+
+	tests := []struct {
+		name    string
+		actor   service.Actor
+		ownerID string
+		scope   string
+		err     error
+	}{
+		{name: "owner writes own link", actor: user("alice"), ownerID: "alice", scope: entity.SCOPE_LINKS_WRITE},
+		{name: "owner without scope", actor: user("alice"), ownerID: "alice", scope: entity.SCOPE_STATS_READ, err: service.ErrInsufficientScope},
+		{name: "other owner", actor: user("alice"), ownerID: "bob", scope: entity.SCOPE_LINKS_READ, err: service.ErrNotOwner},
+		{name: "editor writes workspace link", actor: user("eve"), ownerID: acme, scope: entity.SCOPE_LINKS_WRITE},
+		{name: "viewer cannot write workspace link", actor: user("vera"), ownerID: acme, scope: entity.SCOPE_LINKS_WRITE, err: service.ErrInsufficientRole},
+		{name: "outsider sees no workspace link", actor: user("mallory"), ownerID: acme, scope: entity.SCOPE_LINKS_READ, err: service.ErrNotOwner},
+		{name: "workspace key stays in its workspace", actor: service.Actor{OwnerID: entity.WorkspaceOwnerID("other"), WorkspaceID: "other", Scopes: scopes},
+			ownerID: acme, scope: entity.SCOPE_LINKS_READ, err: service.ErrNotOwner},
+		{name: "admin uses every link", actor: service.SystemActor, ownerID: "bob", scope: entity.SCOPE_LINKS_WRITE},
+	}
+
+	store := newWorkspaceStore(
+		entity.NewMember("acme", "eve", entity.ROLE_EDITOR),
+		entity.NewMember("acme", "vera", entity.ROLE_VIEWER),
+	)
+	policy := service.NewPolicy(slog.Default(), store, memberStore{store})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := policy.AuthorizeOwner(context.Background(), tt.actor, tt.ownerID, tt.scope); !errors.Is(err, tt.err) {
+				t.Errorf("AuthorizeOwner() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
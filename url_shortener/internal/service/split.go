@@ -0,0 +1,84 @@
+package service
+
+import (
+	"math/rand/v2"
+	"regexp"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+// validVariantID matches the names of split destinations.
+var validVariantID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// ValidateSplit checks the weighted destinations of a link.
+//
+// Parameters:
+// - split: the weighted destinations.
+//
+// Returns:
+// - error: ErrInvalidSplit if the number of destinations is out of range,
+// an id is malformed or repeated, a weight is out of 0 to MAX_SPLIT_WEIGHT,
+// all weights are zero or a destination is not a valid URL.
+func ValidateSplit(split entity.Split) error {
+	if len(split.Destinations) < MIN_SPLIT_DESTINATIONS || len(split.Destinations) > MAX_SPLIT_DESTINATIONS {
+		return ErrInvalidSplit
+	}
+
+	seen := make(map[string]bool, len(split.Destinations))
+	total := 0
+	for _, destination := range split.Destinations {
+		if !validVariantID.MatchString(destination.ID) || seen[destination.ID] || destination.Weight < 0 || destination.Weight > MAX_SPLIT_WEIGHT {
+			return ErrInvalidSplit
+		}
+		seen[destination.ID] = true
+		total += destination.Weight
+
+		if err := utils.ValidateOrigin(destination.Destination); err != nil {
+			return ErrInvalidSplit
+		}
+	}
+
+	if total == 0 {
+		return ErrInvalidSplit
+	}
+
+	return nil
+}
+
+// chooseVariant picks a destination of a split for a visitor.
+//
+// A sticky split keeps the destination the visitor was assigned to, as
+// long as it still exists and receives traffic. Otherwise a destination is
+// drawn at random in proportion to the weights, the first destination
+// being used for splits stored without a positive total weight.
+//
+// Parameters:
+// - split: the weighted destinations, at least one.
+// - assigned: the destination the visitor was assigned to, or empty.
+//
+// Returns:
+// - entity.WeightedDestination: the chosen destination.
+func chooseVariant(split *entity.Split, assigned string) entity.WeightedDestination {
+	total := 0
+	for _, destination := range split.Destinations {
+		if split.Sticky && destination.ID == assigned && destination.Weight > 0 {
+			return destination
+		}
+		total += destination.Weight
+	}
+
+	if total <= 0 {
+		return split.Destinations[0]
+	}
+
+	n := rand.IntN(total)
+	for _, destination := range split.Destinations {
+		if n < destination.Weight {
+			return destination
+		}
+		n -= destination.Weight
+	}
+
+	return split.Destinations[len(split.Destinations)-1]
+}
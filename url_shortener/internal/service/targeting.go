@@ -0,0 +1,184 @@
+package service
+
+import (
+	"log/slog"
+	"regexp"
+	"slices"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/geoip"
+	"github.com/flew1x/url_shortener_ms/pkg/useragent"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+type ITargetingService interface {
+	// SelectDestination returns the destination of the link for the visitor.
+	SelectDestination(url entity.IURL, visitor entity.Visitor) entity.Target
+
+	// SelectApp returns the app of the link opened on the device of the visitor.
+	SelectApp(url entity.IURL, visitor entity.Visitor) (entity.AppTarget, bool)
+}
+
+type TargetingService struct {
+	logger  *slog.Logger
+	locator geoip.ILocator
+}
+
+func NewTargetingService(logger *slog.Logger, locator geoip.ILocator) *TargetingService {
+	return &TargetingService{logger: logger, locator: locator}
+}
+
+// targetingValues lists the values accepted by each rule condition.
+var (
+	targetingOS = []string{
+		useragent.OS_IOS, useragent.OS_ANDROID, useragent.OS_WINDOWS,
+		useragent.OS_MACOS, useragent.OS_LINUX, useragent.OS_CHROMEOS,
+	}
+	targetingDevices = []string{
+		useragent.DEVICE_MOBILE, useragent.DEVICE_TABLET, useragent.DEVICE_DESKTOP, useragent.DEVICE_BOT,
+	}
+	targetingBrowsers = []string{
+		useragent.BROWSER_CHROME, useragent.BROWSER_FIREFOX, useragent.BROWSER_SAFARI,
+		useragent.BROWSER_EDGE, useragent.BROWSER_OPERA, useragent.BROWSER_SAMSUNG,
+	}
+)
+
+// validCountry and validRegion match ISO 3166-1 alpha-2 and ISO 3166-2 codes.
+var (
+	validCountry = regexp.MustCompile(`^[A-Z]{2}$`)
+	validRegion  = regexp.MustCompile(`^[A-Z]{2}-[A-Z0-9]{1,3}$`)
+)
+
+// ValidateRules checks the targeting rules of a link.
+//
+// Parameters:
+// - rules: the ordered targeting rules.
+//
+// Returns:
+// - error: ErrInvalidTargetingRule if there are too many rules, a rule has
+// no condition or an unknown one, or its destination is not a valid URL.
+func ValidateRules(rules []entity.TargetingRule) error {
+	if len(rules) > MAX_TARGETING_RULES {
+		return ErrInvalidTargetingRule
+	}
+
+	for _, rule := range rules {
+		if rule.OS == "" && rule.Device == "" && rule.Browser == "" && rule.Country == "" && rule.Region == "" {
+			return ErrInvalidTargetingRule
+		}
+
+		if !validCondition(targetingOS, rule.OS) ||
+			!validCondition(targetingDevices, rule.Device) ||
+			!validCondition(targetingBrowsers, rule.Browser) {
+			return ErrInvalidTargetingRule
+		}
+
+		if (rule.Country != "" && !validCountry.MatchString(rule.Country)) ||
+			(rule.Region != "" && !validRegion.MatchString(rule.Region)) {
+			return ErrInvalidTargetingRule
+		}
+
+		if err := utils.ValidateOrigin(rule.Destination); err != nil {
+			return ErrInvalidTargetingRule
+		}
+	}
+
+	return nil
+}
+
+// validCondition reports whether a condition is empty or one of the accepted values.
+func validCondition(accepted []string, value string) bool {
+	return value == "" || slices.Contains(accepted, value)
+}
+
+// SelectDestination returns the destination of the link for the visitor.
+//
+// The first matching targeting rule wins. Rules are evaluated in their
+// order, whatever their conditions, so a rule combining a country and a
+// device placed before a rule with only the country takes precedence over
+// it. The client is only located when a rule has a geographic condition,
+// and a client that cannot be located matches no such rule.
+//
+// Without a matching targeting rule, the language rule best matching the
+// preferences of the visitor is used. Without a matching language rule,
+// the split of the link picks a destination, and the origin is used for
+// links without a split.
+//
+// Parameters:
+// - url: the link.
+// - visitor: the client following the link, with the split destination it
+// was assigned to before.
+//
+// Returns:
+// - entity.Target: the URL to redirect to and the split destination chosen.
+func (s *TargetingService) SelectDestination(url entity.IURL, visitor entity.Visitor) entity.Target {
+	if destination, ok := s.matchRules(url.GetRules(), visitor); ok {
+		return entity.Target{URL: destination}
+	}
+
+	if destination, ok := matchLanguages(url.GetLanguages(), visitor.AcceptLanguage); ok {
+		return entity.Target{URL: destination}
+	}
+
+	if split := url.GetSplit(); split != nil && len(split.Destinations) > 0 {
+		variant := chooseVariant(split, visitor.Variant)
+		return entity.Target{URL: variant.Destination, Variant: variant.ID}
+	}
+
+	return entity.Target{URL: url.GetOrigin()}
+}
+
+// matchRules returns the destination of the first targeting rule matching the visitor.
+//
+// Parameters:
+// - rules: the ordered targeting rules.
+// - visitor: the client following the link.
+//
+// Returns:
+// - string: the destination of the matching rule.
+// - bool: false if no rule matches.
+func (s *TargetingService) matchRules(rules []entity.TargetingRule, visitor entity.Visitor) (string, bool) {
+	if len(rules) == 0 {
+		return "", false
+	}
+
+	agent := useragent.Parse(visitor.UserAgent)
+
+	var location *geoip.Location
+	for _, rule := range rules {
+		if (rule.Country != "" || rule.Region != "") && location == nil {
+			location = s.locate(visitor.IP)
+		}
+
+		if matchCondition(rule.OS, agent.OS) &&
+			matchCondition(rule.Device, agent.Device) &&
+			matchCondition(rule.Browser, agent.Browser) &&
+			(rule.Country == "" || rule.Country == location.Country) &&
+			(rule.Region == "" || rule.Region == location.Region) {
+			return rule.Destination, true
+		}
+	}
+
+	return "", false
+}
+
+// locate returns the location of the client, empty if it cannot be located.
+//
+// Parameters:
+// - ip: the address of the client.
+//
+// Returns:
+// - *geoip.Location: the location of the client.
+func (s *TargetingService) locate(ip string) *geoip.Location {
+	location, err := s.locator.Locate(ip)
+	if err != nil {
+		s.logger.Error("error locating client " + err.Error())
+	}
+
+	return &location
+}
+
+// matchCondition reports whether a condition is empty or equals the value.
+func matchCondition(condition, value string) bool {
+	return condition == "" || condition == value
+}
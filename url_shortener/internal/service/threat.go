@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/safebrowsing"
+)
+
+type IThreatService interface {
+	// Update applies the threat list update file if it changed since it was last applied, reporting whether the lists changed.
+	Update(ctx context.Context) (bool, error)
+
+	// Rescan checks the links that are not disabled against the threat lists, disabling the confirmed malicious ones.
+	Rescan(ctx context.Context) error
+}
+
+type ThreatService struct {
+	logger        *slog.Logger
+	config        config.IThreatListConfig
+	database      *safebrowsing.Database
+	urlRepository repository.IURLRepository
+	urls          IURLService
+}
+
+// NewThreatService returns a ThreatService keeping the threat lists up to
+// date from the configured update file.
+//
+// Parameters:
+// - logger: the logger object.
+// - config: the threat list configuration.
+// - database: the threat lists, nil if destinations are not checked.
+// - urlRepository: the repository of the links rescanned.
+// - urls: the service disabling the malicious links.
+//
+// Returns:
+// - *ThreatService: the service, doing nothing without threat lists.
+func NewThreatService(
+	logger *slog.Logger,
+	config config.IThreatListConfig,
+	database *safebrowsing.Database,
+	urlRepository repository.IURLRepository,
+	urls IURLService,
+) *ThreatService {
+	return &ThreatService{
+		logger:        logger,
+		config:        config,
+		database:      database,
+		urlRepository: urlRepository,
+		urls:          urls,
+	}
+}
+
+// Update applies the threat list update file if it changed since it was
+// last applied, and saves the lists to the state file if one is configured.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - bool: true if the lists changed.
+// - error: an error if the file cannot be read or applied, the previous
+// lists being kept, or if the lists cannot be saved.
+func (s *ThreatService) Update(ctx context.Context) (bool, error) {
+	if s.database == nil {
+		return false, nil
+	}
+
+	changed, err := s.database.ApplyFile(s.config.GetUpdatePath())
+	if err != nil || !changed {
+		return false, err
+	}
+
+	s.logger.Info("Threat lists updated", slog.Int("prefixes", s.database.Len()))
+
+	if path := s.config.GetStatePath(); path != "" {
+		return true, s.database.Save(path)
+	}
+
+	return true, nil
+}
+
+// Rescan checks every destination of the links that are not disabled
+// against the threat lists, in batches, and disables the links leading to
+// a listed destination, so that links that became malicious after their
+// creation stop redirecting.
+//
+// Only destinations whose full hash is listed disable their link. A hash
+// prefix alone, which safe URLs may share, is only logged for review.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if the links cannot be listed or a link cannot be disabled.
+func (s *ThreatService) Rescan(ctx context.Context) error {
+	if s.database == nil {
+		return nil
+	}
+
+	disabled, flagged := 0, 0
+	for after := ""; ; {
+		urls, err := s.urlRepository.ListEnabled(ctx, after, THREAT_RESCAN_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, url := range urls {
+			var threat *safebrowsing.Threat
+			if err := checkDestinations(s.database, url); !errors.As(err, &threat) {
+				continue
+			}
+
+			if !threat.Confirmed {
+				s.logger.Warn("Link destination matches a threat list prefix, left enabled",
+					slog.String("short", url.GetShort()),
+					slog.String("destination", threat.Destination),
+					slog.String("threat_type", threat.ThreatType),
+				)
+				flagged++
+				continue
+			}
+
+			reason := "Disabled automatically, a destination is listed as " + safebrowsing.Describe(threat.ThreatType) + "."
+			if err := s.urls.Disable(ctx, SystemActor, url.GetShort(), entity.DISABLED_BY_THREAT, reason); err != nil && !errors.Is(err, ErrLinkNotFound) {
+				return err
+			}
+
+			s.logger.Warn("Link disabled, destination listed as a threat",
+				slog.String("short", url.GetShort()),
+				slog.String("destination", threat.Destination),
+				slog.String("threat_type", threat.ThreatType),
+			)
+			disabled++
+		}
+
+		if len(urls) < THREAT_RESCAN_BATCH_SIZE {
+			break
+		}
+		after = urls[len(urls)-1].GetShort()
+	}
+
+	if disabled > 0 || flagged > 0 {
+		s.logger.Info("Threat rescan done", slog.Int("disabled", disabled), slog.Int("flagged", flagged))
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/pkg/jwks"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type ITokenService interface {
+	// Authenticate returns the user identified by a bearer JWT.
+	Authenticate(ctx context.Context, token string) (TokenIdentity, error)
+}
+
+// TokenIdentity is the user identified by a JWT.
+//
+// Fields:
+// - Subject: the value of the configured subject claim.
+// - Scopes: the known scopes of the scopes claim and the default scopes.
+type TokenIdentity struct {
+	Subject string
+	Scopes  []string
+}
+
+type TokenService struct {
+	logger *slog.Logger
+	config config.IAuthConfig
+	keys   *jwks.KeySet
+	parser *jwt.Parser
+}
+
+// NewTokenService returns a TokenService verifying JWTs with the given keys.
+//
+// Parameters:
+// - logger: the logger object.
+// - config: the authentication configuration.
+// - keys: the keys of the identity provider, nil to refuse every JWT.
+//
+// Returns:
+// - *TokenService: the service.
+func NewTokenService(logger *slog.Logger, config config.IAuthConfig, keys *jwks.KeySet) *TokenService {
+	service := &TokenService{logger: logger, config: config, keys: keys}
+
+	if keys != nil {
+		service.parser = jwt.NewParser(
+			jwt.WithValidMethods(config.GetJWTAlgorithms()),
+			jwt.WithIssuer(config.GetJWTIssuer()),
+			jwt.WithAudience(config.GetJWTAudience()),
+			jwt.WithLeeway(config.GetJWTLeeway()),
+			jwt.WithExpirationRequired(),
+		)
+	}
+
+	return service
+}
+
+// Authenticate returns the user identified by a bearer JWT.
+//
+// The token must be signed by a key of the JWKS with an accepted
+// algorithm, be issued by the configured issuer for the configured
+// audience, and be within its validity period. Its subject must not start
+// with the owner prefix of workspaces or API keys.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - token: the compact JWT sent by the client.
+//
+// Returns:
+// - TokenIdentity: the user.
+// - error: ErrInvalidToken if the token is refused.
+func (s *TokenService) Authenticate(ctx context.Context, token string) (TokenIdentity, error) {
+	if s.keys == nil {
+		return TokenIdentity{}, ErrInvalidToken
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := s.parser.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		// Refused tokens are expected, only an unreadable JWKS is an error.
+		if errors.Is(err, jwt.ErrTokenUnverifiable) && !errors.Is(err, jwks.ErrKeyNotFound) {
+			s.logger.Error("error reading JWKS " + err.Error())
+		}
+		return TokenIdentity{}, ErrInvalidToken
+	}
+
+	subject, _ := claims[s.config.GetJWTSubjectClaim()].(string)
+	if subject == "" {
+		return TokenIdentity{}, ErrInvalidToken
+	}
+
+	// The subject becomes the owner ID of the user, which must not name the
+	// links of a workspace or of an API key.
+	if entity.IsReservedOwnerID(subject) {
+		s.logger.Warn("Token refused, its subject is a reserved owner ID", slog.String("subject", subject))
+		return TokenIdentity{}, ErrInvalidToken
+	}
+
+	return TokenIdentity{Subject: subject, Scopes: s.scopes(claims)}, nil
+}
+
+// scopes returns the known scopes of the scopes claim and the default scopes.
+//
+// Parameters:
+// - claims: the claims of the token.
+//
+// Returns:
+// - []string: the sorted scopes, without duplicates.
+func (s *TokenService) scopes(claims jwt.MapClaims) []string {
+	var claimed []string
+	switch value := claims[s.config.GetJWTScopesClaim()].(type) {
+	case string:
+		claimed = strings.Fields(value)
+	case []interface{}:
+		for _, item := range value {
+			if scope, ok := item.(string); ok {
+				claimed = append(claimed, scope)
+			}
+		}
+	}
+
+	scopes := slices.Clone(s.config.GetJWTDefaultScopes())
+	for _, scope := range claimed {
+		if slices.Contains(entity.Scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	slices.Sort(scopes)
+
+	return slices.Compact(scopes)
+}
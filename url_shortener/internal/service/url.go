@@ -2,19 +2,26 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/url"
+	"unicode/utf8"
 
 	"github.com/flew1x/url_shortener_ms/internal/cache"
 	"github.com/flew1x/url_shortener_ms/internal/config"
 	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/events"
 	"github.com/flew1x/url_shortener_ms/internal/repository"
 	"github.com/flew1x/url_shortener_ms/pkg/utils"
 )
 
 type IURLService interface {
-	// Create creates a new URL in the repository.
-	Create(ctx context.Context, originUrl string) (shortUrl string, err error)
+	// Create creates a new URL of the actor in the repository.
+	Create(ctx context.Context, actor Actor, originUrl string, options LinkOptions) (shortUrl string, err error)
+
+	// Get returns a URL by its short if the actor may use a scope on it.
+	Get(ctx context.Context, actor Actor, short, scope string) (entity.IURL, error)
 
 	// GetByOrigin returns a URL from the repository by its origin.
 	GetByOrigin(ctx context.Context, origin string) (entity.IURL, error)
@@ -22,11 +29,18 @@ type IURLService interface {
 	// GetByShort returns a URL from the repository by its short.
 	GetByShort(ctx context.Context, short string) (entity.IURL, error)
 
+	// Resolve returns the URL a visited path leads to and the remainder of
+	// the path to append to its origin.
+	Resolve(ctx context.Context, path string) (entity.IURL, string, error)
+
 	// DeleteByID deletes a URL from the repository by its ID.
-	Delete(ctx context.Context, short string) error
+	Delete(ctx context.Context, actor Actor, short string) error
 
 	// Update updates a URL in the repository by its ID.
-	Update(ctx context.Context, url entity.IURL) error
+	Update(ctx context.Context, actor Actor, url entity.IURL) (entity.IURL, error)
+
+	// Disable stops a URL from redirecting, showing the reason to its visitors.
+	Disable(ctx context.Context, actor Actor, short, by, reason string) error
 
 	// BuildShortURL builds the short URL from the given short ID.
 	BuildShortURL(short string) url.URL
@@ -36,11 +50,74 @@ type URLService struct {
 	logger        *slog.Logger
 	urlRepository repository.IURLRepository
 	cache         cache.IUrlCache
+	publisher     events.IEventPublisher
+	usage         IUsageService
+	policy        IDestinationPolicy
+	access        IPolicy
 	config        *config.Config
 }
 
-func NewURLService(logger *slog.Logger, urlRepository repository.IURLRepository, cache cache.IUrlCache, config *config.Config) *URLService {
-	return &URLService{logger: logger, urlRepository: urlRepository, cache: cache, config: config}
+func NewURLService(
+	logger *slog.Logger,
+	urlRepository repository.IURLRepository,
+	cache cache.IUrlCache,
+	publisher events.IEventPublisher,
+	usage IUsageService,
+	policy IDestinationPolicy,
+	access IPolicy,
+	config *config.Config,
+) *URLService {
+	return &URLService{
+		logger:        logger,
+		urlRepository: urlRepository,
+		cache:         cache,
+		publisher:     publisher,
+		usage:         usage,
+		policy:        policy,
+		access:        access,
+		config:        config,
+	}
+}
+
+// publish emits an event about the given URL.
+//
+// Events are stored in the outbox, a failure is logged but does not fail
+// the operation that already succeeded.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - eventType: the type of the event.
+// - url: the URL the event is about.
+func (l *URLService) publish(ctx context.Context, eventType string, url entity.IURL) {
+	event := entity.NewEvent(utils.NewID(), eventType, url, nil)
+
+	if err := l.publisher.Publish(ctx, event); err != nil {
+		l.logger.Error("error publishing event "+eventType, slog.String("short", url.GetShort()), slog.String("err", err.Error()))
+	}
+}
+
+// authorize checks that an actor may use a scope on a URL.
+//
+// URLs of other owners are reported as not found, so that their codes
+// cannot be probed.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - url: the URL.
+// - scope: the scope, e.g. "links:write".
+//
+// Returns:
+// - error: ErrLinkNotFound if the URL belongs to another owner,
+// ErrInsufficientScope or ErrInsufficientRole if the actor may not use the
+// scope, or an error if the operation failed.
+func (l *URLService) authorize(ctx context.Context, actor Actor, url entity.IURL, scope string) error {
+	err := l.access.AuthorizeOwner(ctx, actor, url.GetOwnerID(), scope)
+	if errors.Is(err, ErrNotOwner) {
+		return ErrLinkNotFound
+	}
+
+	return err
 }
 
 // generateShortUrl generates a random short URL of the given length.
@@ -88,47 +165,83 @@ func (l *URLService) BuildShortURL(short string) url.URL {
 
 // Create creates a new URL entry in the repository and returns its short URL.
 //
+// The URL belongs to the owner of the actor. An existing URL of the same
+// owner without settings is returned instead for an origin without
+// settings, URLs are never shared between owners. Only new links count
+// towards the quota of the owner.
+//
 // Parameters:
 // - ctx: the context.Context for the function.
+// - actor: the actor creating the URL, without owner when authentication is disabled.
 // - originURL: the original URL to be shortened.
+// - options: the per-link settings of the new URL.
 //
 // Returns:
 // - shortURL: the shortened URL.
-// - err: an error if the URL is not valid or if there was an issue creating the short URL.
-func (s *URLService) Create(ctx context.Context, originURL string) (shortURL string, err error) {
+// - err: ErrInsufficientScope if the actor may not write links, a
+// urlpolicy.Violation if a destination is refused, a QuotaError if the
+// owner has used up its quota of links, or an error if the URL is not
+// valid or if there was an issue creating the short URL.
+func (s *URLService) Create(ctx context.Context, actor Actor, originURL string, options LinkOptions) (shortURL string, err error) {
+	ownerID := actor.OwnerID
+	if err = s.access.AuthorizeOwner(ctx, actor, ownerID, entity.SCOPE_LINKS_WRITE); err != nil {
+		return "", err
+	}
+
 	// Validate the origin URL
 	if err = utils.ValidateOrigin(originURL); err != nil {
 		s.logger.Error("Error validating origin URL " + err.Error())
 		return "", err
 	}
 
+	// Validate the per-link settings
+	if err = options.Validate(); err != nil {
+		return "", err
+	}
+
+	// Refuse destinations blocked by the destination policy, before an
+	// existing link to them could be returned
+	destinations := &entity.URL{Origin: originURL}
+	options.apply(destinations)
+	if err = checkDestinations(s.policy, destinations); err != nil {
+		return "", err
+	}
+
 	// Log the origin URL
 	s.logger.Debug("Creating URL ", slog.Any("origin", originURL))
 
-	// Check if the URL is present in the cache
-	if cachedURL, err := s.cache.GetByLongUrl(ctx, originURL); err == nil {
-		s.logger.Debug("URL found in cache ", slog.Any("url", cachedURL.GetShort()))
-		return cachedURL.GetShort(), nil
+	// Check if the URL is present in the cache. Links with their own
+	// settings are never shared.
+	if options.IsZero() {
+		if cachedURL, err := s.cache.GetByLongUrl(ctx, ownerID, originURL); err == nil {
+			s.logger.Debug("URL found in cache ", slog.Any("url", cachedURL.GetShort()))
+			return cachedURL.GetShort(), nil
+		}
 	}
 
-	// Generate a new short URL
-	shortGeneratedURL := s.generateShortUrl(s.config.URLConfig.LengthShortURL())
+	// Refuse new links once the owner has used up its quota
+	if err = s.usage.Check(ctx, ownerID, entity.USAGE_LINKS); err != nil {
+		return "", err
+	}
 
-	// Log the generated short URL
-	s.logger.Debug("Generated short URL ", slog.Any("short", shortGeneratedURL.String()))
+	// Save the URL in the repository under its custom or a generated code
+	urlObject, err := s.insert(ctx, ownerID, originURL, options)
+	if err != nil {
+		return "", err
+	}
 
-	urlObject := entity.NewURL(shortGeneratedURL.String(), originURL)
+	s.publish(ctx, entity.EVENT_LINK_CREATED, urlObject)
 
-	// Save the URL in the repository
-	if err := s.urlRepository.Create(ctx, urlObject); err != nil {
-		s.logger.Error("Error creating URL " + err.Error())
-		return "", err
+	if err := s.usage.Record(ctx, ownerID, entity.USAGE_LINKS); err != nil {
+		s.logger.Error("Error counting link " + err.Error())
 	}
 
 	// Set the URL in the cache by long URL
-	if err := s.cache.SetByLongUrl(ctx, urlObject); err != nil {
-		s.logger.Error("Error setting URL in cache by long URL " + err.Error())
-		return "", err
+	if isPlain(urlObject) {
+		if err := s.cache.SetByLongUrl(ctx, urlObject); err != nil {
+			s.logger.Error("Error setting URL in cache by long URL " + err.Error())
+			return "", err
+		}
 	}
 
 	// Set the URL in the cache by short URL
@@ -141,6 +254,57 @@ func (s *URLService) Create(ctx context.Context, originURL string) (shortURL str
 	return urlObject.GetShort(), nil
 }
 
+// insert stores a new URL under its custom code, or under a generated
+// code that is generated again while it is taken.
+//
+// Codes are only claimed by the insert, the unique index on short deciding
+// between concurrent requests for the same code.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - ownerID: the owner of the URL.
+// - originURL: the original URL.
+// - options: the validated per-link settings, with the custom code if any.
+//
+// Returns:
+// - *entity.URL: the stored URL.
+// - error: ErrInvalidCode or ErrCodeTaken for a custom code, or an error if
+// no free code was generated or the operation failed.
+func (s *URLService) insert(ctx context.Context, ownerID, originURL string, options LinkOptions) (*entity.URL, error) {
+	if options.Code != "" {
+		if err := ValidateCode(options.Code, s.config.URLConfig.GetPrefixMaxDepth()); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		shortURL := s.generateShortUrl(s.config.URLConfig.LengthShortURL())
+		if options.Code != "" {
+			shortURL = s.BuildShortURL(options.Code)
+		}
+
+		urlObject := entity.CopyURL(entity.NewURL(shortURL.String(), originURL))
+		urlObject.OwnerID = ownerID
+		options.apply(urlObject)
+
+		err := s.urlRepository.Create(ctx, urlObject)
+		switch {
+		case err == nil:
+			s.logger.Debug("Created short URL ", slog.Any("short", urlObject.GetShort()))
+			return urlObject, nil
+		case !errors.Is(err, repository.ErrAlreadyExists):
+			s.logger.Error("Error creating URL " + err.Error())
+			return nil, err
+		case options.Code != "":
+			return nil, ErrCodeTaken
+		case attempt == MAX_GENERATE_CODE_ATTEMPTS:
+			return nil, fmt.Errorf("no free code after %d attempts", attempt)
+		}
+
+		s.logger.Warn("Generated code is taken, generating another one", slog.String("short", urlObject.GetShort()))
+	}
+}
+
 // GetByOrigin retrieves a URL from the repository by its origin.
 //
 // Parameters:
@@ -149,7 +313,7 @@ func (s *URLService) Create(ctx context.Context, originURL string) (shortURL str
 //
 // Returns:
 // - entity.URL: the URL retrieved from the repository.
-// - error: an error if the operation failed.
+// - error: ErrLinkNotFound if no URL has the origin, or an error if the operation failed.
 func (l *URLService) GetByOrigin(ctx context.Context, origin string) (entity.IURL, error) {
 	if err := utils.ValidateOrigin(origin); err != nil {
 		return nil, err
@@ -157,6 +321,9 @@ func (l *URLService) GetByOrigin(ctx context.Context, origin string) (entity.IUR
 
 	url, err := l.urlRepository.GetByOrigin(ctx, origin)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrLinkNotFound
+		}
 		l.logger.Error("error getting url " + err.Error())
 		return nil, err
 	}
@@ -164,6 +331,32 @@ func (l *URLService) GetByOrigin(ctx context.Context, origin string) (entity.IUR
 	return url, nil
 }
 
+// Get retrieves a URL by its short if the actor may use a scope on it.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - short: the shortened URL to retrieve.
+// - scope: the scope the actor needs, e.g. "links:read".
+//
+// Returns:
+// - entity.IURL: the URL.
+// - error: ErrLinkNotFound if the URL does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// use the scope, or an error if the operation failed.
+func (l *URLService) Get(ctx context.Context, actor Actor, short, scope string) (entity.IURL, error) {
+	url, err := l.GetByShort(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.authorize(ctx, actor, url, scope); err != nil {
+		return nil, err
+	}
+
+	return url, nil
+}
+
 // GetByShortID retrieves a URL from the repository or cache by its short.
 //
 // Parameters:
@@ -172,7 +365,7 @@ func (l *URLService) GetByOrigin(ctx context.Context, origin string) (entity.IUR
 //
 // Returns:
 // - entity.URL: the URL retrieved from the repository or cache.
-// - error: an error if the operation failed.
+// - error: ErrLinkNotFound if the URL does not exist, or an error if the operation failed.
 func (l *URLService) GetByShort(ctx context.Context, shortID string) (entity.IURL, error) {
 	// Log the beginning of the function
 	l.logger.Debug("GetByShort function started ")
@@ -198,6 +391,9 @@ func (l *URLService) GetByShort(ctx context.Context, shortID string) (entity.IUR
 	// Get the URL from the repository
 	repoURL, err := l.urlRepository.GetByShort(ctx, shortID)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrLinkNotFound
+		}
 		l.logger.Error("error getting URL from repository " + err.Error())
 		return nil, err
 	}
@@ -211,10 +407,12 @@ func (l *URLService) GetByShort(ctx context.Context, shortID string) (entity.IUR
 		return nil, err
 	}
 
-	err = l.cache.SetByLongUrl(ctx, repoURL)
-	if err != nil {
-		l.logger.Error("error setting URL in cache " + err.Error())
-		return nil, err
+	if isPlain(repoURL) {
+		err = l.cache.SetByLongUrl(ctx, repoURL)
+		if err != nil {
+			l.logger.Error("error setting URL in cache " + err.Error())
+			return nil, err
+		}
 	}
 
 	// Log the end of the function
@@ -225,38 +423,258 @@ func (l *URLService) GetByShort(ctx context.Context, shortID string) (entity.IUR
 
 // Delete deletes a URL from the repository by its short.
 //
+// The URL is read first, so that link.deleted reaches the webhooks of its
+// owner, and its cache entries are removed, so that it stops redirecting
+// at once.
+//
 // Parameters:
 // - ctx: the context.Context for the operation.
+// - actor: the actor deleting the URL.
 // - short: the shortened URL to delete.
 //
 // Returns:
-// - error: an error if the operation failed.
-func (l *URLService) Delete(ctx context.Context, short string) error {
+// - error: ErrLinkNotFound if the URL does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// write links, or an error if the operation failed.
+func (l *URLService) Delete(ctx context.Context, actor Actor, short string) error {
+	url, err := l.urlRepository.GetByShort(ctx, short)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLinkNotFound
+		}
+		l.logger.Error("error getting url " + err.Error())
+		return err
+	}
+
+	if err := l.authorize(ctx, actor, url, entity.SCOPE_LINKS_WRITE); err != nil {
+		return err
+	}
+
 	if err := l.urlRepository.Delete(ctx, short); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLinkNotFound
+		}
 		l.logger.Error("error deleting url " + err.Error())
 		return err
 	}
 
+	// Stop redirecting and sharing the URL before its cache entries expire.
+	if err := l.cache.DeleteByShortUrl(ctx, short); err != nil {
+		l.logger.Error("error deleting URL from cache " + err.Error())
+		return err
+	}
+
+	if err := l.forgetOrigin(ctx, url); err != nil {
+		return err
+	}
+
+	l.publish(ctx, entity.EVENT_LINK_DELETED, url)
+
 	return nil
 }
 
 // Update updates a URL in the repository by its ID.
 //
+// The cached copy of the URL is replaced, so that the change takes effect
+// on the next redirect. Who disabled the URL is recorded from the actor,
+// and only admins may enable or change the reason of a URL disabled by an
+// admin or found to be malicious.
+//
 // Parameters:
 // - ctx: the context.Context for the operation.
-// - url: the URL to update in the repository.
+// - actor: the actor updating the URL.
+// - url: the URL to update in the repository, keeping its owner.
 //
 // Returns:
-// - error: an error if the operation failed.
-func (l *URLService) Update(ctx context.Context, url entity.IURL) error {
+// - entity.IURL: the updated URL.
+// - error: ErrLinkNotFound if the URL does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// write links, ErrLinkLocked if the actor may not change whether it is
+// disabled, a urlpolicy.Violation if a destination is refused, or an error
+// if the operation failed.
+func (l *URLService) Update(ctx context.Context, actor Actor, url entity.IURL) (entity.IURL, error) {
 	if err := utils.ValidateOrigin(url.GetOrigin()); err != nil {
+		return nil, err
+	}
+
+	if err := validateSettings(url); err != nil {
+		return nil, err
+	}
+
+	if err := checkDestinations(l.policy, url); err != nil {
+		return nil, err
+	}
+
+	previous, err := l.urlRepository.GetByShort(ctx, url.GetShort())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrLinkNotFound
+		}
+		l.logger.Error("error getting url " + err.Error())
+		return nil, err
+	}
+
+	if err := l.authorize(ctx, actor, previous, entity.SCOPE_LINKS_WRITE); err != nil {
+		return nil, err
+	}
+
+	// Links are never handed over to another owner.
+	if url.GetOwnerID() != previous.GetOwnerID() {
+		return nil, ErrLinkNotFound
+	}
+
+	updated := entity.CopyURL(url)
+	if updated.DisabledBy, err = disabledBy(actor, previous, url); err != nil {
+		return nil, err
+	}
+
+	if err := l.urlRepository.Update(ctx, updated); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrLinkNotFound
+		}
+		l.logger.Error("error updating url " + err.Error())
+		return nil, err
+	}
+
+	if err := l.cache.SetByShortUrl(ctx, updated); err != nil {
+		l.logger.Error("error setting URL in cache " + err.Error())
+		return nil, err
+	}
+
+	// Stop sharing the URL for its previous origin once it points elsewhere
+	// or has settings of its own.
+	if previous.GetOrigin() != updated.GetOrigin() || !isPlain(updated) {
+		if err := l.forgetOrigin(ctx, previous); err != nil {
+			return nil, err
+		}
+	}
+
+	l.publish(ctx, entity.EVENT_LINK_UPDATED, updated)
+
+	return updated, nil
+}
+
+// disabledBy returns who disabled a URL after an update by an actor.
+//
+// Owners may disable their URLs, and enable again those they disabled
+// themselves. URLs disabled by an admin or by the threat list check, or
+// before who disabled them was recorded, stay disabled with their reason
+// until an admin enables them.
+//
+// Parameters:
+// - actor: the actor updating the URL.
+// - previous: the URL before the update.
+// - url: the updated URL.
+//
+// Returns:
+// - string: who disabled the URL, empty if it is enabled.
+// - error: ErrLinkLocked if the actor may not change whether the URL is
+// disabled, or why.
+func disabledBy(actor Actor, previous, url entity.IURL) (string, error) {
+	if !previous.IsDisabled() {
+		switch {
+		case !url.IsDisabled():
+			return "", nil
+		case actor.Admin:
+			return entity.DISABLED_BY_ADMIN, nil
+		default:
+			return entity.DISABLED_BY_OWNER, nil
+		}
+	}
+
+	changed := !url.IsDisabled() || url.GetDisabledReason() != previous.GetDisabledReason()
+	if changed && !actor.Admin && previous.GetDisabledBy() != entity.DISABLED_BY_OWNER {
+		return "", ErrLinkLocked
+	}
+
+	if !url.IsDisabled() {
+		return "", nil
+	}
+
+	return previous.GetDisabledBy(), nil
+}
+
+// Disable stops a URL from redirecting, without checking its destinations
+// as Update does, so that links whose destinations are no longer allowed
+// can be disabled.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor disabling the URL, SystemActor for automatic checks.
+// - short: the short of the URL.
+// - by: who disabled the URL, e.g. entity.DISABLED_BY_THREAT.
+// - reason: why the URL is disabled, shown to its visitors and cut to
+// MAX_DISABLED_REASON_LENGTH bytes.
+//
+// Returns:
+// - error: ErrLinkNotFound if the URL does not exist or belongs to another
+// owner, ErrInsufficientScope or ErrInsufficientRole if the actor may not
+// write links, or an error if the operation failed.
+func (l *URLService) Disable(ctx context.Context, actor Actor, short, by, reason string) error {
+	previous, err := l.urlRepository.GetByShort(ctx, short)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLinkNotFound
+		}
+		l.logger.Error("error getting url " + err.Error())
 		return err
 	}
 
+	if err := l.authorize(ctx, actor, previous, entity.SCOPE_LINKS_WRITE); err != nil {
+		return err
+	}
+
+	for len(reason) > MAX_DISABLED_REASON_LENGTH {
+		_, size := utf8.DecodeLastRuneInString(reason)
+		reason = reason[:len(reason)-size]
+	}
+
+	url := entity.CopyURL(previous)
+	url.Disabled = true
+	url.DisabledReason = reason
+	url.DisabledBy = by
+
 	if err := l.urlRepository.Update(ctx, url); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLinkNotFound
+		}
 		l.logger.Error("error updating url " + err.Error())
 		return err
 	}
 
+	if err := l.cache.SetByShortUrl(ctx, url); err != nil {
+		l.logger.Error("error setting URL in cache " + err.Error())
+		return err
+	}
+
+	if err := l.forgetOrigin(ctx, previous); err != nil {
+		return err
+	}
+
+	l.publish(ctx, entity.EVENT_LINK_UPDATED, url)
+
+	return nil
+}
+
+// forgetOrigin removes the cache entry sharing the URL for its origin,
+// if that entry still points to the URL.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - url: the URL that must no longer be shared.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (l *URLService) forgetOrigin(ctx context.Context, url entity.IURL) error {
+	cachedURL, err := l.cache.GetByLongUrl(ctx, url.GetOwnerID(), url.GetOrigin())
+	if err != nil || cachedURL.GetShort() != url.GetShort() {
+		return nil
+	}
+
+	if err := l.cache.DeleteByLongUrl(ctx, url.GetOwnerID(), url.GetOrigin()); err != nil {
+		l.logger.Error("error deleting URL from cache " + err.Error())
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/cache"
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+)
+
+type IUsageService interface {
+	// Check returns a QuotaError if one more use of a metric would exceed the quota of an account.
+	Check(ctx context.Context, account, metric string) error
+
+	// Record counts one use of a metric by an account.
+	Record(ctx context.Context, account, metric string) error
+
+	// Report returns the usage of an account during a month.
+	Report(ctx context.Context, account string, month time.Time) (*entity.UsageReport, error)
+
+	// Flush saves the counts changed since the last flush to the repository.
+	Flush(ctx context.Context) error
+}
+
+// QuotaError is returned when an account has used up its quota.
+//
+// Fields:
+// - Metric: entity.USAGE_LINKS or entity.USAGE_REDIRECTS.
+// - Window: entity.USAGE_WINDOW_DAY or entity.USAGE_WINDOW_MONTH.
+// - Limit: the quota.
+// - ResetAt: the start of the next UTC day or month, when the quota is available again.
+type QuotaError struct {
+	Metric  string
+	Window  string
+	Limit   int64
+	ResetAt time.Time
+}
+
+// Error implements error.
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s quota of %d %s exceeded, it resets at %s", e.Window, e.Limit, e.Metric, e.ResetAt.Format(time.RFC3339))
+}
+
+// Unwrap returns ErrQuotaExceeded.
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+type UsageService struct {
+	logger      *slog.Logger
+	enabled     bool
+	defaultPlan string
+	plans       map[string]entity.Quota
+	accounts    map[string]string
+	cache       cache.IUsageCache
+	repository  repository.IUsageRepository
+}
+
+// NewUsageService returns a UsageService enforcing the configured plans.
+//
+// The plans are read once, app.validateUsagePlans having checked that
+// every plan named exists.
+//
+// Parameters:
+// - logger: the logger object.
+// - config: the usage configuration.
+// - cache: the counts shared by every instance of the service.
+// - repository: the saved counts.
+//
+// Returns:
+// - *UsageService: the service.
+func NewUsageService(logger *slog.Logger, config config.IUsageConfig, cache cache.IUsageCache, repository repository.IUsageRepository) *UsageService {
+	service := &UsageService{
+		logger:      logger,
+		enabled:     config.GetEnabled(),
+		defaultPlan: config.GetDefaultPlan(),
+		plans:       map[string]entity.Quota{},
+		accounts:    map[string]string{},
+		cache:       cache,
+		repository:  repository,
+	}
+
+	for name, plan := range config.GetPlans() {
+		service.plans[name] = entity.Quota{
+			DailyLinks:       plan.DailyLinks,
+			MonthlyLinks:     plan.MonthlyLinks,
+			DailyRedirects:   plan.DailyRedirects,
+			MonthlyRedirects: plan.MonthlyRedirects,
+		}
+	}
+	for _, account := range config.GetAccountPlans() {
+		service.accounts[account.Account] = account.Plan
+	}
+
+	return service
+}
+
+// plan returns the plan of an account.
+//
+// Parameters:
+// - account: the owner ID of the account.
+//
+// Returns:
+// - string: the name of the plan.
+// - entity.Quota: the limits of the plan.
+func (s *UsageService) plan(account string) (string, entity.Quota) {
+	name, ok := s.accounts[account]
+	if !ok {
+		name = s.defaultPlan
+	}
+
+	return name, s.plans[name]
+}
+
+// Check returns a QuotaError if one more use of a metric would exceed the
+// daily or monthly quota of an account.
+//
+// Counts missing from Redis, e.g. after Redis lost them, are first loaded
+// from the counts saved in MongoDB, so that a quota is not reset by a
+// restart. Requests are not serialized between the check and the count,
+// so concurrent requests may exceed a quota by a few uses. Uses are let
+// through while Redis cannot be reached.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account, empty for requests without account, which are not limited.
+// - metric: entity.USAGE_LINKS or entity.USAGE_REDIRECTS.
+//
+// Returns:
+// - error: a QuotaError if the quota is used up.
+func (s *UsageService) Check(ctx context.Context, account, metric string) error {
+	if !s.enabled || account == "" {
+		return nil
+	}
+
+	_, quota := s.plan(account)
+	daily, monthly := quota.Limits(metric)
+	if daily == 0 && monthly == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	day, month := entity.UsagePeriods(now)
+
+	periods := []string{day, month}
+	if err := s.seed(ctx, account, month, periods); err != nil {
+		s.logger.Warn("Saved usage unavailable, checking live usage", slog.String("account", account), slog.String("err", err.Error()))
+	}
+
+	usages, err := s.cache.Get(ctx, account, periods)
+	if err != nil || len(usages) != 2 {
+		s.logger.Warn("Usage unavailable, quota not enforced", slog.String("account", account), slog.Any("err", err))
+		return nil
+	}
+
+	if daily > 0 && usages[0].Count(metric) >= daily {
+		return &QuotaError{
+			Metric:  metric,
+			Window:  entity.USAGE_WINDOW_DAY,
+			Limit:   daily,
+			ResetAt: time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	if monthly > 0 && usages[1].Count(metric) >= monthly {
+		return &QuotaError{
+			Metric:  metric,
+			Window:  entity.USAGE_WINDOW_MONTH,
+			Limit:   monthly,
+			ResetAt: time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	return nil
+}
+
+// seed raises the live counts of the periods of an account not seeded yet
+// to the counts saved in MongoDB, once per period and Redis key.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account.
+// - month: the current month, which every period starts with.
+// - periods: the current day and month.
+//
+// Returns:
+// - error: an error if the counts cannot be read or seeded.
+func (s *UsageService) seed(ctx context.Context, account, month string, periods []string) error {
+	unseeded, err := s.cache.Unseeded(ctx, account, periods)
+	if err != nil || len(unseeded) == 0 {
+		return err
+	}
+
+	saved, err := s.repository.List(ctx, account, month)
+	if err != nil {
+		return err
+	}
+
+	byPeriod := make(map[string]*entity.Usage, len(saved))
+	for _, usage := range saved {
+		byPeriod[usage.Period] = usage
+	}
+
+	usages := make([]*entity.Usage, len(unseeded))
+	for i, period := range unseeded {
+		if usage, ok := byPeriod[period]; ok {
+			usages[i] = usage
+		} else {
+			usages[i] = entity.NewUsage(account, period)
+		}
+	}
+
+	return s.cache.Seed(ctx, usages)
+}
+
+// Record counts one use of a metric by an account, in its UTC day and month.
+//
+// Counts missing from Redis are first loaded from the counts saved in
+// MongoDB, as in Check, whatever the plan of the account, so that the
+// saved counts of metrics without quota are not left behind by counts
+// restarting from zero.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account, empty for requests without account, which are not counted.
+// - metric: entity.USAGE_LINKS or entity.USAGE_REDIRECTS.
+//
+// Returns:
+// - error: an error if the use could not be counted.
+func (s *UsageService) Record(ctx context.Context, account, metric string) error {
+	if !s.enabled || account == "" {
+		return nil
+	}
+
+	day, month := entity.UsagePeriods(time.Now())
+
+	periods := []string{day, month}
+	if err := s.seed(ctx, account, month, periods); err != nil {
+		s.logger.Warn("Saved usage unavailable, counting live usage", slog.String("account", account), slog.String("err", err.Error()))
+	}
+
+	return s.cache.Increment(ctx, account, metric, periods)
+}
+
+// Report returns the usage of an account during a month.
+//
+// The saved counts are completed with the live counts of the current and
+// previous day, which may not have been flushed yet.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - account: the owner ID of the account.
+// - month: a time within the month, in any location.
+//
+// Returns:
+// - *entity.UsageReport: the usage of the month and of its days, with the plan of the account.
+// - error: an error if the saved counts cannot be read.
+func (s *UsageService) Report(ctx context.Context, account string, month time.Time) (*entity.UsageReport, error) {
+	monthPeriod := month.UTC().Format(entity.USAGE_MONTH_LAYOUT)
+
+	saved, err := s.repository.List(ctx, account, monthPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*entity.Usage, len(saved))
+	for _, usage := range saved {
+		usages[usage.Period] = usage
+	}
+
+	now := time.Now().UTC()
+	if monthPeriod == now.Format(entity.USAGE_MONTH_LAYOUT) {
+		periods := []string{now.Format(entity.USAGE_DAY_LAYOUT), monthPeriod}
+		if yesterday := now.AddDate(0, 0, -1); yesterday.Month() == now.Month() {
+			periods = append(periods, yesterday.Format(entity.USAGE_DAY_LAYOUT))
+		}
+
+		live, err := s.cache.Get(ctx, account, periods)
+		if err != nil {
+			s.logger.Warn("Live usage unavailable, reporting saved usage", slog.String("account", account), slog.String("err", err.Error()))
+		}
+		for _, usage := range live {
+			if stored, ok := usages[usage.Period]; ok {
+				stored.Merge(usage)
+			} else if usage.Links > 0 || usage.Redirects > 0 {
+				usages[usage.Period] = usage
+			}
+		}
+	}
+
+	name, quota := s.plan(account)
+	report := &entity.UsageReport{
+		Account: account,
+		Plan:    name,
+		Quota:   quota,
+		Month:   entity.NewUsage(account, monthPeriod),
+		Days:    []*entity.Usage{},
+	}
+	for period, usage := range usages {
+		if period == monthPeriod {
+			report.Month = usage
+		} else {
+			report.Days = append(report.Days, usage)
+		}
+	}
+	sort.Slice(report.Days, func(i, j int) bool { return report.Days[i].Period < report.Days[j].Period })
+
+	return report, nil
+}
+
+// Flush saves the counts changed since the last flush to the repository,
+// in batches, so that usage outlives Redis.
+//
+// Counts that cannot be saved are kept to be saved by the next flush.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if the counts cannot be read or saved.
+func (s *UsageService) Flush(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	for {
+		usages, err := s.cache.PopDirty(ctx, USAGE_FLUSH_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+		if len(usages) == 0 {
+			return nil
+		}
+
+		if err := s.repository.Save(ctx, usages); err != nil {
+			if err := s.cache.MarkDirty(ctx, usages); err != nil {
+				s.logger.Error("Usage lost until its next change", slog.Int("count", len(usages)), slog.String("err", err.Error()))
+			}
+			return err
+		}
+
+		if len(usages) < USAGE_FLUSH_BATCH_SIZE {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,645 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/internal/config"
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/pagetitle"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+// webhookEvents lists the event types webhooks can subscribe to.
+var webhookEvents = map[string]bool{
+	entity.EVENT_LINK_CREATED:            true,
+	entity.EVENT_LINK_UPDATED:            true,
+	entity.EVENT_LINK_DELETED:            true,
+	entity.EVENT_LINK_CLICKED:            true,
+	entity.EVENT_LINK_EXPIRED:            true,
+	entity.EVENT_CLICK_THRESHOLD_REACHED: true,
+
+	entity.EVENT_DESTINATION_HEALTH_CHANGED: true,
+}
+
+type IWebhookService interface {
+	// Register creates a webhook of the actor for the given events, limited to a short URL if one is given.
+	Register(ctx context.Context, actor Actor, url string, events []string, short string, clickThreshold int64) (*entity.Webhook, error)
+
+	// List returns the webhooks the actor may manage.
+	List(ctx context.Context, actor Actor) ([]*entity.Webhook, error)
+
+	// Delete removes a webhook the actor may manage.
+	Delete(ctx context.Context, actor Actor, id string) error
+
+	// Deliveries returns the delivery log of a webhook the actor may manage.
+	Deliveries(ctx context.Context, actor Actor, webhookID string) ([]*entity.WebhookDelivery, error)
+
+	// Replay schedules a new delivery of the payload of an earlier delivery to a webhook the actor may manage.
+	Replay(ctx context.Context, actor Actor, webhookID, deliveryID string) (*entity.WebhookDelivery, error)
+
+	// Publish schedules deliveries of the events to the subscribed webhooks.
+	Publish(ctx context.Context, events ...entity.Event) error
+
+	// DeliverDue attempts all deliveries whose next attempt is due.
+	DeliverDue(ctx context.Context) error
+}
+
+type WebhookService struct {
+	logger             *slog.Logger
+	webhookRepository  repository.IWebhookRepository
+	deliveryRepository repository.IWebhookDeliveryRepository
+	clickRepository    repository.IClickRepository
+	urlRepository      repository.IURLRepository
+	access             IPolicy
+	client             *http.Client
+	config             config.IWebhookConfig
+}
+
+func NewWebhookService(
+	logger *slog.Logger,
+	webhookRepository repository.IWebhookRepository,
+	deliveryRepository repository.IWebhookDeliveryRepository,
+	clickRepository repository.IClickRepository,
+	urlRepository repository.IURLRepository,
+	access IPolicy,
+	config config.IWebhookConfig,
+) *WebhookService {
+	return &WebhookService{
+		logger:             logger,
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		clickRepository:    clickRepository,
+		urlRepository:      urlRepository,
+		access:             access,
+		client:             newPublicClient(config.GetTimeout()),
+		config:             config,
+	}
+}
+
+// newPublicClient returns the client posting webhook deliveries and
+// checking destinations.
+//
+// It only connects to public addresses, checked after name resolution, so
+// that webhooks and links cannot be used to reach internal services.
+// Redirects are not followed, a redirect counting as a failed delivery.
+//
+// Parameters:
+// - timeout: the timeout of a single request.
+//
+// Returns:
+// - *http.Client: the client.
+func newPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: pagetitle.ControlPublic}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Register creates a webhook of the actor for the given events, limited to
+// a short URL if one is given.
+//
+// A signing secret is generated for the webhook and returned once. The
+// webhook belongs to the owner of the actor, who alone may manage it, and
+// may only be limited to a link the actor may manage webhooks of.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor registering the webhook.
+// - url: the endpoint deliveries are posted to.
+// - events: the subscribed event types.
+// - short: the shortened URL the webhook is limited to, or empty for every link.
+// - clickThreshold: the number of clicks that triggers click.threshold_reached.
+//
+// Returns:
+// - *entity.Webhook: the registered webhook.
+// - error: ErrInsufficientScope if the actor may not manage webhooks,
+// ErrLinkNotFound if the link does not exist or belongs to another owner,
+// ErrInvalidWebhookURL if the endpoint is not https on a public host, or an
+// error if the webhook is invalid or could not be stored.
+func (s *WebhookService) Register(ctx context.Context, actor Actor, url string, events []string, short string, clickThreshold int64) (*entity.Webhook, error) {
+	if err := s.access.AuthorizeOwner(ctx, actor, actor.OwnerID, entity.SCOPE_WEBHOOKS); err != nil {
+		return nil, err
+	}
+
+	if short != "" {
+		if err := s.authorizeLink(ctx, actor, short); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateWebhookURL(url); err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, ErrUnknownEventType
+	}
+
+	thresholdEvent := false
+	for _, event := range events {
+		if !webhookEvents[event] {
+			return nil, ErrUnknownEventType
+		}
+		thresholdEvent = thresholdEvent || event == entity.EVENT_CLICK_THRESHOLD_REACHED
+	}
+
+	// A click threshold is counted per link, so it needs both a link and a positive value.
+	if thresholdEvent && (short == "" || clickThreshold <= 0) {
+		return nil, ErrInvalidClickThreshold
+	}
+
+	webhook := entity.NewWebhook(utils.NewID(), actor.OwnerID, url, utils.NewID(), events, short, clickThreshold)
+
+	if err := s.webhookRepository.Create(ctx, webhook); err != nil {
+		s.logger.Error("error registering webhook " + err.Error())
+		return nil, err
+	}
+
+	s.logger.Info("Registered webhook", slog.String("id", webhook.ID), slog.String("url", webhook.URL))
+
+	return webhook, nil
+}
+
+// List returns the webhooks the actor may manage, those of its owner or,
+// for admins, of every owner.
+//
+// Signing secrets are not included.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor listing the webhooks.
+//
+// Returns:
+// - []*entity.Webhook: the webhooks.
+// - error: ErrInsufficientScope if the actor may not manage webhooks, or an
+// error if the operation failed.
+func (s *WebhookService) List(ctx context.Context, actor Actor) ([]*entity.Webhook, error) {
+	if err := s.access.AuthorizeOwner(ctx, actor, actor.OwnerID, entity.SCOPE_WEBHOOKS); err != nil {
+		return nil, err
+	}
+
+	ownerID := actor.OwnerID
+	if actor.Admin {
+		ownerID = ""
+	}
+
+	webhooks, err := s.webhookRepository.List(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes a webhook the actor may manage.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor removing the webhook.
+// - id: the ID of the webhook.
+//
+// Returns:
+// - error: ErrWebhookNotFound if the webhook does not exist or belongs to
+// another owner, or an error if the operation failed.
+func (s *WebhookService) Delete(ctx context.Context, actor Actor, id string) error {
+	if _, err := s.get(ctx, actor, id); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Deliveries returns the delivery log of a webhook the actor may manage.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor reading the log.
+// - webhookID: the ID of the webhook.
+//
+// Returns:
+// - []*entity.WebhookDelivery: the most recent deliveries, newest first.
+// - error: ErrWebhookNotFound if the webhook does not exist or belongs to
+// another owner, or an error if the operation failed.
+func (s *WebhookService) Deliveries(ctx context.Context, actor Actor, webhookID string) ([]*entity.WebhookDelivery, error) {
+	if _, err := s.get(ctx, actor, webhookID); err != nil {
+		return nil, err
+	}
+
+	return s.deliveryRepository.ListByWebhook(ctx, webhookID, WEBHOOK_DELIVERY_LOG_LIMIT)
+}
+
+// Replay schedules a new delivery of the payload of an earlier delivery
+// to a webhook the actor may manage.
+//
+// The original delivery is kept unchanged in the log.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor replaying the delivery.
+// - webhookID: the ID of the webhook the delivery belongs to.
+// - deliveryID: the ID of the delivery to replay.
+//
+// Returns:
+// - *entity.WebhookDelivery: the new delivery.
+// - error: ErrWebhookNotFound if the webhook does not exist or belongs to
+// another owner, ErrDeliveryNotFound if the delivery does not exist, or an
+// error if the operation failed.
+func (s *WebhookService) Replay(ctx context.Context, actor Actor, webhookID, deliveryID string) (*entity.WebhookDelivery, error) {
+	if _, err := s.get(ctx, actor, webhookID); err != nil {
+		return nil, err
+	}
+
+	original, err := s.deliveryRepository.GetByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	if original.WebhookID != webhookID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	replay := entity.NewWebhookDelivery(
+		utils.NewID(),
+		original.WebhookID,
+		entity.Event{ID: original.EventID, Type: original.EventType},
+		original.Payload,
+	)
+	replay.ReplayOf = original.ID
+
+	if err := s.deliveryRepository.Create(ctx, replay); err != nil {
+		return nil, err
+	}
+
+	return replay, nil
+}
+
+// validateWebhookURL checks that deliveries to an endpoint are encrypted
+// and not addressed to an internal host.
+//
+// Host names are resolved when delivering, the delivery client refusing
+// the names that resolve to non-public addresses.
+//
+// Parameters:
+// - rawURL: the endpoint of the webhook.
+//
+// Returns:
+// - error: utils.ErrNotValidURL if the URL is malformed, ErrInvalidWebhookURL if
+// it is not https, names localhost or is a non-public IP address.
+func validateWebhookURL(rawURL string) error {
+	if err := utils.ValidateOrigin(rawURL); err != nil {
+		return err
+	}
+
+	endpoint, err := url.Parse(rawURL)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(endpoint.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInvalidWebhookURL
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !pagetitle.IsPublic(ip) {
+		return ErrInvalidWebhookURL
+	}
+
+	return nil
+}
+
+// get returns a webhook the actor may manage.
+//
+// Webhooks of other owners are reported as not found, so that their IDs
+// cannot be probed.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - id: the ID of the webhook.
+//
+// Returns:
+// - *entity.Webhook: the webhook.
+// - error: ErrWebhookNotFound if the webhook does not exist or belongs to
+// another owner, ErrInsufficientScope if the actor may not manage webhooks,
+// or an error if the operation failed.
+func (s *WebhookService) get(ctx context.Context, actor Actor, id string) (*entity.Webhook, error) {
+	webhook, err := s.webhookRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.access.AuthorizeOwner(ctx, actor, webhook.OwnerID, entity.SCOPE_WEBHOOKS); err != nil {
+		if errors.Is(err, ErrNotOwner) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// authorizeLink checks that the actor may limit a webhook to a link.
+//
+// Links of other owners are reported as not found, so that their codes
+// cannot be probed.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - short: the shortened URL.
+//
+// Returns:
+// - error: ErrLinkNotFound if the link does not exist or belongs to another
+// owner, or an error if the actor may not manage its webhooks.
+func (s *WebhookService) authorizeLink(ctx context.Context, actor Actor, short string) error {
+	url, err := s.urlRepository.GetByShort(ctx, short)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLinkNotFound
+		}
+		return err
+	}
+
+	err = s.access.AuthorizeOwner(ctx, actor, url.GetOwnerID(), entity.SCOPE_WEBHOOKS)
+	if errors.Is(err, ErrNotOwner) {
+		return ErrLinkNotFound
+	}
+
+	return err
+}
+
+// Publish schedules deliveries of the events to the subscribed webhooks:
+// those of the link, and those of the owner of the link registered for
+// every link.
+//
+// Click events additionally trigger click.threshold_reached for webhooks
+// whose threshold has been reached. Deliveries are identified by event and
+// webhook, so publishing the same event twice schedules it only once.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - events: the events to deliver.
+//
+// Returns:
+// - error: an error if the deliveries could not be scheduled.
+func (s *WebhookService) Publish(ctx context.Context, events ...entity.Event) error {
+	for _, event := range events {
+		webhooks, err := s.webhookRepository.FindSubscribed(ctx, event.Type, event.Short, event.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		for _, webhook := range webhooks {
+			if err := s.schedule(ctx, webhook, event); err != nil {
+				return err
+			}
+		}
+
+		if event.Type == entity.EVENT_LINK_CLICKED {
+			if err := s.checkClickThresholds(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkClickThresholds schedules click.threshold_reached for every webhook
+// of the clicked link whose threshold has been reached for the first time.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - click: the click event.
+//
+// Returns:
+// - error: an error if the operation failed.
+func (s *WebhookService) checkClickThresholds(ctx context.Context, click entity.Event) error {
+	webhooks, err := s.webhookRepository.FindSubscribed(ctx, entity.EVENT_CLICK_THRESHOLD_REACHED, click.Short, click.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	var clicks int64 = -1
+	for _, webhook := range webhooks {
+		if webhook.ThresholdReached {
+			continue
+		}
+
+		if clicks < 0 {
+			if clicks, err = s.clickRepository.Count(ctx, click.Short); err != nil {
+				return err
+			}
+		}
+
+		if clicks < webhook.ClickThreshold {
+			continue
+		}
+
+		marked, err := s.webhookRepository.MarkThresholdReached(ctx, webhook.ID)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			continue
+		}
+
+		event := entity.NewEvent(
+			webhook.ID+":"+entity.EVENT_CLICK_THRESHOLD_REACHED,
+			entity.EVENT_CLICK_THRESHOLD_REACHED,
+			entity.NewURL(click.Short, click.Origin),
+			map[string]any{"click_threshold": webhook.ClickThreshold, "clicks": clicks},
+		)
+		event.OwnerID = click.OwnerID
+
+		if err := s.schedule(ctx, webhook, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schedule stores a pending delivery of the event to the webhook.
+func (s *WebhookService) schedule(ctx context.Context, webhook *entity.Webhook, event entity.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	delivery := entity.NewWebhookDelivery(event.ID+":"+webhook.ID, webhook.ID, event, string(payload))
+
+	return s.deliveryRepository.Create(ctx, delivery)
+}
+
+// DeliverDue attempts all deliveries whose next attempt is due.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if the due deliveries could not be loaded or saved.
+func (s *WebhookService) DeliverDue(ctx context.Context) error {
+	due, err := s.deliveryRepository.Due(ctx, time.Now(), s.config.GetBatchSize())
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range due {
+		webhook, err := s.webhookRepository.GetByID(ctx, delivery.WebhookID)
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			delivery.Status = entity.DELIVERY_STATUS_FAILED
+			delivery.LastError = ErrWebhookNotFound.Error()
+		case err != nil:
+			return err
+		default:
+			s.attempt(ctx, webhook, delivery)
+		}
+
+		if err := s.deliveryRepository.Update(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attempt posts the delivery to the webhook once and updates its state.
+//
+// Failed deliveries are retried with exponential backoff until the
+// maximum number of attempts is reached.
+func (s *WebhookService) attempt(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) {
+	now := time.Now()
+
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	delivery.ResponseStatus = 0
+	delivery.LastError = ""
+
+	status, err := s.post(ctx, webhook, delivery, now)
+	delivery.ResponseStatus = status
+
+	if err == nil {
+		delivery.Status = entity.DELIVERY_STATUS_SUCCEEDED
+		s.logger.Debug("Delivered webhook", slog.String("delivery", delivery.ID), slog.Int("status", status))
+		return
+	}
+
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= s.config.GetMaxAttempts() {
+		delivery.Status = entity.DELIVERY_STATUS_FAILED
+		s.logger.Error("Webhook delivery failed", slog.String("delivery", delivery.ID), slog.String("err", err.Error()))
+		return
+	}
+
+	delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+}
+
+// post sends the signed payload of the delivery to the webhook.
+//
+// Returns:
+// - int: the HTTP status of the response, or zero if there was none.
+// - error: an error if the request failed or the status is not 2xx.
+func (s *WebhookService) post(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery, now time.Time) (int, error) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", WEBHOOK_CONTENT_TYPE)
+	request.Header.Set(WEBHOOK_ID_HEADER, webhook.ID)
+	request.Header.Set(WEBHOOK_EVENT_HEADER, delivery.EventType)
+	request.Header.Set(WEBHOOK_DELIVERY_HEADER, delivery.ID)
+	request.Header.Set(WEBHOOK_TIMESTAMP_HEADER, timestamp)
+	request.Header.Set(WEBHOOK_SIGNATURE_HEADER, SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, WEBHOOK_RESPONSE_LIMIT))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, ErrWebhookRejected
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt after the given
+// number of attempts.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.config.GetInitialBackoff()
+	maxDelay := s.config.GetMaxBackoff()
+
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+// SignWebhookPayload returns the signature sent with a webhook delivery.
+//
+// The signature is the hex-encoded HMAC-SHA256 of "<timestamp>.<payload>"
+// keyed by the webhook secret, prefixed with "sha256=". Receivers should
+// recompute it and reject old timestamps to prevent replays.
+//
+// Parameters:
+// - secret: the webhook secret.
+// - timestamp: the Unix timestamp sent in the timestamp header.
+// - payload: the request body.
+//
+// Returns:
+// - string: the signature header value.
+func SignWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+
+	return WEBHOOK_SIGNATURE_PREFIX + hex.EncodeToString(mac.Sum(nil))
+}
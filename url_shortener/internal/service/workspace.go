@@ -0,0 +1,548 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/flew1x/url_shortener_ms/internal/entity"
+	"github.com/flew1x/url_shortener_ms/internal/repository"
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+type IWorkspaceService interface {
+	// Create creates a workspace owned by the actor.
+	Create(ctx context.Context, actor Actor, name string) (*entity.Workspace, error)
+
+	// List returns the workspaces of the actor.
+	List(ctx context.Context, actor Actor) ([]*entity.Workspace, error)
+
+	// Get returns a workspace of the actor.
+	Get(ctx context.Context, actor Actor, workspaceID string) (*entity.Workspace, error)
+
+	// Role returns the role of the actor in a workspace.
+	Role(ctx context.Context, actor Actor, workspaceID string) (string, error)
+
+	// Members returns the members of a workspace.
+	Members(ctx context.Context, actor Actor, workspaceID string) ([]*entity.Member, error)
+
+	// SetRole changes the role of a member.
+	SetRole(ctx context.Context, actor Actor, workspaceID, userID, role string) error
+
+	// RemoveMember removes a member from a workspace.
+	RemoveMember(ctx context.Context, actor Actor, workspaceID, userID string) error
+
+	// Invite invites a user to a workspace, returning the token of the invitation once.
+	Invite(ctx context.Context, actor Actor, workspaceID, invitee, role string) (*entity.Invitation, string, error)
+
+	// Invitations returns the invitations of a workspace.
+	Invitations(ctx context.Context, actor Actor, workspaceID string) ([]*entity.Invitation, error)
+
+	// RevokeInvitation deletes an invitation of a workspace.
+	RevokeInvitation(ctx context.Context, actor Actor, workspaceID, invitationID string) error
+
+	// Accept makes the actor a member of the workspace of an invitation.
+	Accept(ctx context.Context, actor Actor, token string) (*entity.Member, error)
+
+	// CreateKey creates an API key of a workspace, returning its token once.
+	CreateKey(ctx context.Context, actor Actor, workspaceID, name string, scopes []string) (*entity.APIKey, string, error)
+
+	// Keys returns the API keys of a workspace.
+	Keys(ctx context.Context, actor Actor, workspaceID string) ([]*entity.APIKey, error)
+
+	// RevokeKey revokes an API key of a workspace.
+	RevokeKey(ctx context.Context, actor Actor, workspaceID, keyID string) error
+}
+
+type WorkspaceService struct {
+	logger      *slog.Logger
+	policy      IPolicy
+	workspaces  repository.IWorkspaceRepository
+	members     repository.IMemberRepository
+	invitations repository.IInvitationRepository
+	keys        IAPIKeyService
+}
+
+func NewWorkspaceService(
+	logger *slog.Logger,
+	policy IPolicy,
+	workspaces repository.IWorkspaceRepository,
+	members repository.IMemberRepository,
+	invitations repository.IInvitationRepository,
+	keys IAPIKeyService,
+) *WorkspaceService {
+	return &WorkspaceService{
+		logger:      logger,
+		policy:      policy,
+		workspaces:  workspaces,
+		members:     members,
+		invitations: invitations,
+		keys:        keys,
+	}
+}
+
+// Create creates a workspace, the actor becoming its owner.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the user creating the workspace.
+// - name: the name of the workspace.
+//
+// Returns:
+// - *entity.Workspace: the created workspace.
+// - error: ErrInvalidWorkspaceName, ErrUserRequired if the actor is not a
+// user, or an error if the operation failed.
+func (s *WorkspaceService) Create(ctx context.Context, actor Actor, name string) (*entity.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MAX_WORKSPACE_NAME_LENGTH || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return nil, ErrInvalidWorkspaceName
+	}
+
+	if actor.UserID == "" {
+		return nil, ErrUserRequired
+	}
+
+	workspace := entity.NewWorkspace(utils.NewID(), name, actor.UserID)
+	if err := s.workspaces.Create(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	if err := s.members.Create(ctx, entity.NewMember(workspace.ID, actor.UserID, entity.ROLE_OWNER)); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Created workspace", slog.String("id", workspace.ID), slog.String("owner", actor.UserID))
+
+	return workspace, nil
+}
+
+// List returns the workspaces the actor is a member of, every workspace for admins.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+//
+// Returns:
+// - []*entity.Workspace: the workspaces, by name.
+// - error: an error if the operation failed.
+func (s *WorkspaceService) List(ctx context.Context, actor Actor) ([]*entity.Workspace, error) {
+	if actor.Admin {
+		return s.workspaces.List(ctx, nil)
+	}
+
+	ids := []string{}
+	if actor.UserID != "" {
+		members, err := s.members.ListByUser(ctx, actor.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			ids = append(ids, member.WorkspaceID)
+		}
+	}
+
+	return s.workspaces.List(ctx, ids)
+}
+
+// Get returns a workspace the actor is a member of.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - *entity.Workspace: the workspace.
+// - error: ErrWorkspaceNotFound, or an error if the operation failed.
+func (s *WorkspaceService) Get(ctx context.Context, actor Actor, workspaceID string) (*entity.Workspace, error) {
+	if _, err := s.policy.Role(ctx, actor, workspaceID); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaces.Get(ctx, workspaceID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrWorkspaceNotFound
+	}
+
+	return workspace, err
+}
+
+// Role returns the role of the actor in a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - string: the role.
+// - error: ErrWorkspaceNotFound, or an error if the operation failed.
+func (s *WorkspaceService) Role(ctx context.Context, actor Actor, workspaceID string) (string, error) {
+	return s.policy.Role(ctx, actor, workspaceID)
+}
+
+// Members returns the members of a workspace, to any of its members.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - []*entity.Member: the members, oldest first.
+// - error: ErrWorkspaceNotFound, or an error if the operation failed.
+func (s *WorkspaceService) Members(ctx context.Context, actor Actor, workspaceID string) ([]*entity.Member, error) {
+	if _, err := s.policy.Role(ctx, actor, workspaceID); err != nil {
+		return nil, err
+	}
+
+	return s.members.List(ctx, workspaceID)
+}
+
+// SetRole changes the role of a member.
+//
+// Admins manage admins, editors and viewers, only owners grant or remove
+// the owner role, and a workspace always keeps an owner.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - userID: the member.
+// - role: the new role.
+//
+// Returns:
+// - error: ErrUnknownRole, ErrWorkspaceNotFound, ErrInsufficientRole,
+// ErrMemberNotFound, ErrLastOwner, or an error if the operation failed.
+func (s *WorkspaceService) SetRole(ctx context.Context, actor Actor, workspaceID, userID, role string) error {
+	if !slices.Contains(entity.Roles, role) {
+		return ErrUnknownRole
+	}
+
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_MEMBERS); err != nil {
+		return err
+	}
+
+	member, err := s.member(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.ROLE_OWNER || role == entity.ROLE_OWNER {
+		if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_OWNERS); err != nil {
+			return err
+		}
+	}
+
+	if member.Role == entity.ROLE_OWNER && role != entity.ROLE_OWNER {
+		if err := s.keepOwner(ctx, workspaceID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.members.UpdateRole(ctx, workspaceID, userID, role); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+
+	s.logger.Info("Changed member role", slog.String("workspace", workspaceID), slog.String("user", userID), slog.String("role", role))
+
+	return nil
+}
+
+// RemoveMember removes a member from a workspace.
+//
+// Members may leave a workspace, other members are removed by admins,
+// owners only by owners, and a workspace always keeps an owner.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - userID: the member.
+//
+// Returns:
+// - error: ErrWorkspaceNotFound, ErrInsufficientRole, ErrMemberNotFound,
+// ErrLastOwner, or an error if the operation failed.
+func (s *WorkspaceService) RemoveMember(ctx context.Context, actor Actor, workspaceID, userID string) error {
+	leaving := userID == actor.UserID
+
+	if leaving {
+		if _, err := s.policy.Role(ctx, actor, workspaceID); err != nil {
+			return err
+		}
+	} else if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_MEMBERS); err != nil {
+		return err
+	}
+
+	member, err := s.member(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.ROLE_OWNER {
+		if !leaving {
+			if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_OWNERS); err != nil {
+				return err
+			}
+		}
+		if err := s.keepOwner(ctx, workspaceID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.members.Delete(ctx, workspaceID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+
+	s.logger.Info("Removed member", slog.String("workspace", workspaceID), slog.String("user", userID))
+
+	return nil
+}
+
+// Invite invites a user to a workspace.
+//
+// The token is a random secret, only its hash is stored, so it is returned
+// once and must be passed on to the invitee, who alone may accept it.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - invitee: the user invited, as identified by the subject claim of their JWT.
+// - role: the role the invitee is given.
+//
+// Returns:
+// - *entity.Invitation: the invitation.
+// - string: the token of the invitation.
+// - error: ErrInvalidInvitee, ErrUnknownRole, ErrWorkspaceNotFound,
+// ErrInsufficientRole, ErrAlreadyMember, or an error if the operation failed.
+func (s *WorkspaceService) Invite(ctx context.Context, actor Actor, workspaceID, invitee, role string) (*entity.Invitation, string, error) {
+	invitee = strings.TrimSpace(invitee)
+	if invitee == "" || len(invitee) > MAX_OWNER_ID_LENGTH || strings.IndexFunc(invitee, unicode.IsControl) >= 0 {
+		return nil, "", ErrInvalidInvitee
+	}
+
+	if !slices.Contains(entity.Roles, role) {
+		return nil, "", ErrUnknownRole
+	}
+
+	permission := entity.ACTION_MANAGE_MEMBERS
+	if role == entity.ROLE_OWNER {
+		permission = entity.ACTION_MANAGE_OWNERS
+	}
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, permission); err != nil {
+		return nil, "", err
+	}
+
+	if _, err := s.members.Get(ctx, workspaceID, invitee); err == nil {
+		return nil, "", ErrAlreadyMember
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, "", err
+	}
+
+	token, err := newToken(INVITATION_TOKEN_PREFIX)
+	if err != nil {
+		return nil, "", err
+	}
+
+	invitation := entity.NewInvitation(utils.NewID(), workspaceID, invitee, role, hashToken(token), actor.UserID, INVITATION_TTL)
+	if err := s.invitations.Create(ctx, invitation); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("Invited member", slog.String("workspace", workspaceID), slog.String("invitation", invitation.ID))
+
+	return invitation, token, nil
+}
+
+// Invitations returns the invitations of a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - []*entity.Invitation: the invitations, newest first.
+// - error: ErrWorkspaceNotFound, ErrInsufficientRole, or an error if the operation failed.
+func (s *WorkspaceService) Invitations(ctx context.Context, actor Actor, workspaceID string) ([]*entity.Invitation, error) {
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_MEMBERS); err != nil {
+		return nil, err
+	}
+
+	return s.invitations.List(ctx, workspaceID)
+}
+
+// RevokeInvitation deletes an invitation of a workspace, which cannot be accepted from then on.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - invitationID: the ID of the invitation.
+//
+// Returns:
+// - error: ErrWorkspaceNotFound, ErrInsufficientRole, ErrInvitationNotFound,
+// or an error if the operation failed.
+func (s *WorkspaceService) RevokeInvitation(ctx context.Context, actor Actor, workspaceID, invitationID string) error {
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_MEMBERS); err != nil {
+		return err
+	}
+
+	if err := s.invitations.Delete(ctx, workspaceID, invitationID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Accept makes the actor a member of the workspace of an invitation.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the invitee.
+// - token: the token of the invitation.
+//
+// Returns:
+// - *entity.Member: the membership.
+// - error: ErrUserRequired, ErrInvalidInvitation if the token is unknown,
+// expired, already used or meant for another user, ErrAlreadyMember, or an
+// error if the operation failed.
+func (s *WorkspaceService) Accept(ctx context.Context, actor Actor, token string) (*entity.Member, error) {
+	if actor.UserID == "" {
+		return nil, ErrUserRequired
+	}
+
+	if !strings.HasPrefix(token, INVITATION_TOKEN_PREFIX) {
+		return nil, ErrInvalidInvitation
+	}
+
+	invitation, err := s.invitations.GetByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+		return nil, err
+	}
+
+	if !invitation.IsPending() || invitation.Invitee != actor.UserID {
+		return nil, ErrInvalidInvitation
+	}
+
+	if err := s.invitations.Accept(ctx, invitation.ID, time.Now()); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+		return nil, err
+	}
+
+	member := entity.NewMember(invitation.WorkspaceID, actor.UserID, invitation.Role)
+	if err := s.members.Create(ctx, member); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrAlreadyMember
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Accepted invitation", slog.String("workspace", member.WorkspaceID), slog.String("user", member.UserID))
+
+	return member, nil
+}
+
+// CreateKey creates an API key of a workspace, the links it creates
+// belonging to the workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - name: what the key is used for.
+// - scopes: the scopes granted to the key, among links:read, links:write and stats:read.
+//
+// Returns:
+// - *entity.APIKey: the created key.
+// - string: the token of the key.
+// - error: ErrWorkspaceNotFound, ErrInsufficientRole, the errors of
+// IAPIKeyService.Create, or an error if the operation failed.
+func (s *WorkspaceService) CreateKey(ctx context.Context, actor Actor, workspaceID, name string, scopes []string) (*entity.APIKey, string, error) {
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_KEYS); err != nil {
+		return nil, "", err
+	}
+
+	return s.keys.Create(ctx, name, "", workspaceID, scopes)
+}
+
+// Keys returns the API keys of a workspace, revoked ones included.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+//
+// Returns:
+// - []*entity.APIKey: the keys, newest first.
+// - error: ErrWorkspaceNotFound, ErrInsufficientRole, or an error if the operation failed.
+func (s *WorkspaceService) Keys(ctx context.Context, actor Actor, workspaceID string) ([]*entity.APIKey, error) {
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_KEYS); err != nil {
+		return nil, err
+	}
+
+	return s.keys.List(ctx, workspaceID)
+}
+
+// RevokeKey revokes an API key of a workspace.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - actor: the actor.
+// - workspaceID: the ID of the workspace.
+// - keyID: the ID of the key.
+//
+// Returns:
+// - error: ErrWorkspaceNotFound, ErrInsufficientRole, ErrAPIKeyNotFound if
+// the workspace has no such key, or an error if the operation failed.
+func (s *WorkspaceService) RevokeKey(ctx context.Context, actor Actor, workspaceID, keyID string) error {
+	if _, err := s.policy.Authorize(ctx, actor, workspaceID, entity.ACTION_MANAGE_KEYS); err != nil {
+		return err
+	}
+
+	return s.keys.Revoke(ctx, workspaceID, keyID)
+}
+
+// member returns a member of a workspace.
+func (s *WorkspaceService) member(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+	member, err := s.members.Get(ctx, workspaceID, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrMemberNotFound
+	}
+
+	return member, err
+}
+
+// keepOwner returns ErrLastOwner if a workspace has a single owner, who
+// may then neither leave nor be demoted.
+func (s *WorkspaceService) keepOwner(ctx context.Context, workspaceID string) error {
+	owners, err := s.members.CountRole(ctx, workspaceID, entity.ROLE_OWNER)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
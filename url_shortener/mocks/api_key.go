@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/api_key.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/api_key.go -destination=mocks/api_key.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/auth_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/auth_config.go -destination=mocks/auth_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuthConfig is a mock of IAuthConfig interface.
+type MockIAuthConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthConfigMockRecorder
+}
+
+// MockIAuthConfigMockRecorder is the mock recorder for MockIAuthConfig.
+type MockIAuthConfigMockRecorder struct {
+	mock *MockIAuthConfig
+}
+
+// NewMockIAuthConfig creates a new mock instance.
+func NewMockIAuthConfig(ctrl *gomock.Controller) *MockIAuthConfig {
+	mock := &MockIAuthConfig{ctrl: ctrl}
+	mock.recorder = &MockIAuthConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthConfig) EXPECT() *MockIAuthConfigMockRecorder {
+	return m.recorder
+}
+
+// GetEnabled mocks base method.
+func (m *MockIAuthConfig) GetEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockIAuthConfigMockRecorder) GetEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockIAuthConfig)(nil).GetEnabled))
+}
+
+// GetJWKS mocks base method.
+func (m *MockIAuthConfig) GetJWKS() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockIAuthConfigMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWKS))
+}
+
+// GetJWKSRefreshInterval mocks base method.
+func (m *MockIAuthConfig) GetJWKSRefreshInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKSRefreshInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetJWKSRefreshInterval indicates an expected call of GetJWKSRefreshInterval.
+func (mr *MockIAuthConfigMockRecorder) GetJWKSRefreshInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKSRefreshInterval", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWKSRefreshInterval))
+}
+
+// GetJWTAlgorithms mocks base method.
+func (m *MockIAuthConfig) GetJWTAlgorithms() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTAlgorithms")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetJWTAlgorithms indicates an expected call of GetJWTAlgorithms.
+func (mr *MockIAuthConfigMockRecorder) GetJWTAlgorithms() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTAlgorithms", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTAlgorithms))
+}
+
+// GetJWTAudience mocks base method.
+func (m *MockIAuthConfig) GetJWTAudience() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTAudience")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetJWTAudience indicates an expected call of GetJWTAudience.
+func (mr *MockIAuthConfigMockRecorder) GetJWTAudience() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTAudience", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTAudience))
+}
+
+// GetJWTDefaultScopes mocks base method.
+func (m *MockIAuthConfig) GetJWTDefaultScopes() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTDefaultScopes")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetJWTDefaultScopes indicates an expected call of GetJWTDefaultScopes.
+func (mr *MockIAuthConfigMockRecorder) GetJWTDefaultScopes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTDefaultScopes", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTDefaultScopes))
+}
+
+// GetJWTIssuer mocks base method.
+func (m *MockIAuthConfig) GetJWTIssuer() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTIssuer")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetJWTIssuer indicates an expected call of GetJWTIssuer.
+func (mr *MockIAuthConfigMockRecorder) GetJWTIssuer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTIssuer", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTIssuer))
+}
+
+// GetJWTLeeway mocks base method.
+func (m *MockIAuthConfig) GetJWTLeeway() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTLeeway")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetJWTLeeway indicates an expected call of GetJWTLeeway.
+func (mr *MockIAuthConfigMockRecorder) GetJWTLeeway() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTLeeway", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTLeeway))
+}
+
+// GetJWTScopesClaim mocks base method.
+func (m *MockIAuthConfig) GetJWTScopesClaim() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTScopesClaim")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetJWTScopesClaim indicates an expected call of GetJWTScopesClaim.
+func (mr *MockIAuthConfigMockRecorder) GetJWTScopesClaim() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTScopesClaim", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTScopesClaim))
+}
+
+// GetJWTSubjectClaim mocks base method.
+func (m *MockIAuthConfig) GetJWTSubjectClaim() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTSubjectClaim")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetJWTSubjectClaim indicates an expected call of GetJWTSubjectClaim.
+func (mr *MockIAuthConfigMockRecorder) GetJWTSubjectClaim() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTSubjectClaim", reflect.TypeOf((*MockIAuthConfig)(nil).GetJWTSubjectClaim))
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/campaign.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/campaign.go -destination=mocks/campaign.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICampaignService is a mock of ICampaignService interface.
+type MockICampaignService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICampaignServiceMockRecorder
+}
+
+// MockICampaignServiceMockRecorder is the mock recorder for MockICampaignService.
+type MockICampaignServiceMockRecorder struct {
+	mock *MockICampaignService
+}
+
+// NewMockICampaignService creates a new mock instance.
+func NewMockICampaignService(ctrl *gomock.Controller) *MockICampaignService {
+	mock := &MockICampaignService{ctrl: ctrl}
+	mock.recorder = &MockICampaignServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICampaignService) EXPECT() *MockICampaignServiceMockRecorder {
+	return m.recorder
+}
+
+// Stats mocks base method.
+func (m *MockICampaignService) Stats(ctx context.Context, ownerID, origin string, from, to time.Time) ([]entity.CampaignStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, ownerID, origin, from, to)
+	ret0, _ := ret[0].([]entity.CampaignStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockICampaignServiceMockRecorder) Stats(ctx, ownerID, origin, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockICampaignService)(nil).Stats), ctx, ownerID, origin, from, to)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/click.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/click.go -destination=mocks/click.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIClick is a mock of IClick interface.
+type MockIClick struct {
+	ctrl     *gomock.Controller
+	recorder *MockIClickMockRecorder
+}
+
+// MockIClickMockRecorder is the mock recorder for MockIClick.
+type MockIClickMockRecorder struct {
+	mock *MockIClick
+}
+
+// NewMockIClick creates a new mock instance.
+func NewMockIClick(ctrl *gomock.Controller) *MockIClick {
+	mock := &MockIClick{ctrl: ctrl}
+	mock.recorder = &MockIClickMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIClick) EXPECT() *MockIClickMockRecorder {
+	return m.recorder
+}
+
+// GetCreatedAt mocks base method.
+func (m *MockIClick) GetCreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetCreatedAt indicates an expected call of GetCreatedAt.
+func (mr *MockIClickMockRecorder) GetCreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatedAt", reflect.TypeOf((*MockIClick)(nil).GetCreatedAt))
+}
+
+// GetIP mocks base method.
+func (m *MockIClick) GetIP() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIP")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetIP indicates an expected call of GetIP.
+func (mr *MockIClickMockRecorder) GetIP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIP", reflect.TypeOf((*MockIClick)(nil).GetIP))
+}
+
+// GetReferer mocks base method.
+func (m *MockIClick) GetReferer() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferer")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetReferer indicates an expected call of GetReferer.
+func (mr *MockIClickMockRecorder) GetReferer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferer", reflect.TypeOf((*MockIClick)(nil).GetReferer))
+}
+
+// GetShort mocks base method.
+func (m *MockIClick) GetShort() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShort")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetShort indicates an expected call of GetShort.
+func (mr *MockIClickMockRecorder) GetShort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShort", reflect.TypeOf((*MockIClick)(nil).GetShort))
+}
+
+// GetUserAgent mocks base method.
+func (m *MockIClick) GetUserAgent() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAgent")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetUserAgent indicates an expected call of GetUserAgent.
+func (mr *MockIClickMockRecorder) GetUserAgent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAgent", reflect.TypeOf((*MockIClick)(nil).GetUserAgent))
+}
+
+// GetVariant mocks base method.
+func (m *MockIClick) GetVariant() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariant")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetVariant indicates an expected call of GetVariant.
+func (mr *MockIClickMockRecorder) GetVariant() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariant", reflect.TypeOf((*MockIClick)(nil).GetVariant))
+}
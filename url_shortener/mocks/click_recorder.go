@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/click_recorder.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/click_recorder.go -destination=mocks/click_recorder.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/deeplink.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/deeplink.go -destination=mocks/deeplink.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
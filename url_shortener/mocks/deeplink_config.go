@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/deeplink_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/deeplink_config.go -destination=mocks/deeplink_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDeepLinkConfig is a mock of IDeepLinkConfig interface.
+type MockIDeepLinkConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeepLinkConfigMockRecorder
+}
+
+// MockIDeepLinkConfigMockRecorder is the mock recorder for MockIDeepLinkConfig.
+type MockIDeepLinkConfigMockRecorder struct {
+	mock *MockIDeepLinkConfig
+}
+
+// NewMockIDeepLinkConfig creates a new mock instance.
+func NewMockIDeepLinkConfig(ctrl *gomock.Controller) *MockIDeepLinkConfig {
+	mock := &MockIDeepLinkConfig{ctrl: ctrl}
+	mock.recorder = &MockIDeepLinkConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeepLinkConfig) EXPECT() *MockIDeepLinkConfigMockRecorder {
+	return m.recorder
+}
+
+// GetAppleAppSiteAssociationPath mocks base method.
+func (m *MockIDeepLinkConfig) GetAppleAppSiteAssociationPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppleAppSiteAssociationPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAppleAppSiteAssociationPath indicates an expected call of GetAppleAppSiteAssociationPath.
+func (mr *MockIDeepLinkConfigMockRecorder) GetAppleAppSiteAssociationPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppleAppSiteAssociationPath", reflect.TypeOf((*MockIDeepLinkConfig)(nil).GetAppleAppSiteAssociationPath))
+}
+
+// GetAssetLinksPath mocks base method.
+func (m *MockIDeepLinkConfig) GetAssetLinksPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetLinksPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAssetLinksPath indicates an expected call of GetAssetLinksPath.
+func (mr *MockIDeepLinkConfigMockRecorder) GetAssetLinksPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetLinksPath", reflect.TypeOf((*MockIDeepLinkConfig)(nil).GetAssetLinksPath))
+}
+
+// GetFallbackDelay mocks base method.
+func (m *MockIDeepLinkConfig) GetFallbackDelay() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFallbackDelay")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetFallbackDelay indicates an expected call of GetFallbackDelay.
+func (mr *MockIDeepLinkConfigMockRecorder) GetFallbackDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFallbackDelay", reflect.TypeOf((*MockIDeepLinkConfig)(nil).GetFallbackDelay))
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/destination_policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/destination_policy.go -destination=mocks/destination_policy.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDestinationPolicy is a mock of IDestinationPolicy interface.
+type MockIDestinationPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDestinationPolicyMockRecorder
+}
+
+// MockIDestinationPolicyMockRecorder is the mock recorder for MockIDestinationPolicy.
+type MockIDestinationPolicyMockRecorder struct {
+	mock *MockIDestinationPolicy
+}
+
+// NewMockIDestinationPolicy creates a new mock instance.
+func NewMockIDestinationPolicy(ctrl *gomock.Controller) *MockIDestinationPolicy {
+	mock := &MockIDestinationPolicy{ctrl: ctrl}
+	mock.recorder = &MockIDestinationPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDestinationPolicy) EXPECT() *MockIDestinationPolicyMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIDestinationPolicy) Check(destination string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", destination)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIDestinationPolicyMockRecorder) Check(destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIDestinationPolicy)(nil).Check), destination)
+}
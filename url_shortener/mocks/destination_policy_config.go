@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/destination_policy_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/destination_policy_config.go -destination=mocks/destination_policy_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDestinationPolicyConfig is a mock of IDestinationPolicyConfig interface.
+type MockIDestinationPolicyConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDestinationPolicyConfigMockRecorder
+}
+
+// MockIDestinationPolicyConfigMockRecorder is the mock recorder for MockIDestinationPolicyConfig.
+type MockIDestinationPolicyConfigMockRecorder struct {
+	mock *MockIDestinationPolicyConfig
+}
+
+// NewMockIDestinationPolicyConfig creates a new mock instance.
+func NewMockIDestinationPolicyConfig(ctrl *gomock.Controller) *MockIDestinationPolicyConfig {
+	mock := &MockIDestinationPolicyConfig{ctrl: ctrl}
+	mock.recorder = &MockIDestinationPolicyConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDestinationPolicyConfig) EXPECT() *MockIDestinationPolicyConfigMockRecorder {
+	return m.recorder
+}
+
+// GetPath mocks base method.
+func (m *MockIDestinationPolicyConfig) GetPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetPath indicates an expected call of GetPath.
+func (mr *MockIDestinationPolicyConfigMockRecorder) GetPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPath", reflect.TypeOf((*MockIDestinationPolicyConfig)(nil).GetPath))
+}
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/event.go -destination=mocks/event.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
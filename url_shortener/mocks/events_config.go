@@ -0,0 +1,208 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/events_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/events_config.go -destination=mocks/events_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEventsConfig is a mock of IEventsConfig interface.
+type MockIEventsConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventsConfigMockRecorder
+}
+
+// MockIEventsConfigMockRecorder is the mock recorder for MockIEventsConfig.
+type MockIEventsConfigMockRecorder struct {
+	mock *MockIEventsConfig
+}
+
+// NewMockIEventsConfig creates a new mock instance.
+func NewMockIEventsConfig(ctrl *gomock.Controller) *MockIEventsConfig {
+	mock := &MockIEventsConfig{ctrl: ctrl}
+	mock.recorder = &MockIEventsConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventsConfig) EXPECT() *MockIEventsConfigMockRecorder {
+	return m.recorder
+}
+
+// GetBatchSize mocks base method.
+func (m *MockIEventsConfig) GetBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetBatchSize indicates an expected call of GetBatchSize.
+func (mr *MockIEventsConfigMockRecorder) GetBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchSize", reflect.TypeOf((*MockIEventsConfig)(nil).GetBatchSize))
+}
+
+// GetFilePath mocks base method.
+func (m *MockIEventsConfig) GetFilePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetFilePath indicates an expected call of GetFilePath.
+func (mr *MockIEventsConfigMockRecorder) GetFilePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilePath", reflect.TypeOf((*MockIEventsConfig)(nil).GetFilePath))
+}
+
+// GetHTTPTimeout mocks base method.
+func (m *MockIEventsConfig) GetHTTPTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetHTTPTimeout indicates an expected call of GetHTTPTimeout.
+func (mr *MockIEventsConfigMockRecorder) GetHTTPTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPTimeout", reflect.TypeOf((*MockIEventsConfig)(nil).GetHTTPTimeout))
+}
+
+// GetHTTPURL mocks base method.
+func (m *MockIEventsConfig) GetHTTPURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetHTTPURL indicates an expected call of GetHTTPURL.
+func (mr *MockIEventsConfigMockRecorder) GetHTTPURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPURL", reflect.TypeOf((*MockIEventsConfig)(nil).GetHTTPURL))
+}
+
+// GetInitialBackoff mocks base method.
+func (m *MockIEventsConfig) GetInitialBackoff() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitialBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetInitialBackoff indicates an expected call of GetInitialBackoff.
+func (mr *MockIEventsConfigMockRecorder) GetInitialBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitialBackoff", reflect.TypeOf((*MockIEventsConfig)(nil).GetInitialBackoff))
+}
+
+// GetLease mocks base method.
+func (m *MockIEventsConfig) GetLease() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLease")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetLease indicates an expected call of GetLease.
+func (mr *MockIEventsConfigMockRecorder) GetLease() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLease", reflect.TypeOf((*MockIEventsConfig)(nil).GetLease))
+}
+
+// GetMaxAttempts mocks base method.
+func (m *MockIEventsConfig) GetMaxAttempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxAttempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxAttempts indicates an expected call of GetMaxAttempts.
+func (mr *MockIEventsConfigMockRecorder) GetMaxAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxAttempts", reflect.TypeOf((*MockIEventsConfig)(nil).GetMaxAttempts))
+}
+
+// GetMaxBackoff mocks base method.
+func (m *MockIEventsConfig) GetMaxBackoff() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMaxBackoff indicates an expected call of GetMaxBackoff.
+func (mr *MockIEventsConfigMockRecorder) GetMaxBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBackoff", reflect.TypeOf((*MockIEventsConfig)(nil).GetMaxBackoff))
+}
+
+// GetRedisStream mocks base method.
+func (m *MockIEventsConfig) GetRedisStream() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedisStream")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRedisStream indicates an expected call of GetRedisStream.
+func (mr *MockIEventsConfigMockRecorder) GetRedisStream() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedisStream", reflect.TypeOf((*MockIEventsConfig)(nil).GetRedisStream))
+}
+
+// GetRedisStreamMaxLen mocks base method.
+func (m *MockIEventsConfig) GetRedisStreamMaxLen() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedisStreamMaxLen")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRedisStreamMaxLen indicates an expected call of GetRedisStreamMaxLen.
+func (mr *MockIEventsConfigMockRecorder) GetRedisStreamMaxLen() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedisStreamMaxLen", reflect.TypeOf((*MockIEventsConfig)(nil).GetRedisStreamMaxLen))
+}
+
+// GetRelayInterval mocks base method.
+func (m *MockIEventsConfig) GetRelayInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelayInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetRelayInterval indicates an expected call of GetRelayInterval.
+func (mr *MockIEventsConfigMockRecorder) GetRelayInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelayInterval", reflect.TypeOf((*MockIEventsConfig)(nil).GetRelayInterval))
+}
+
+// GetSink mocks base method.
+func (m *MockIEventsConfig) GetSink() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSink")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSink indicates an expected call of GetSink.
+func (mr *MockIEventsConfigMockRecorder) GetSink() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSink", reflect.TypeOf((*MockIEventsConfig)(nil).GetSink))
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/geo_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/geo_config.go -destination=mocks/geo_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIGeoConfig is a mock of IGeoConfig interface.
+type MockIGeoConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGeoConfigMockRecorder
+}
+
+// MockIGeoConfigMockRecorder is the mock recorder for MockIGeoConfig.
+type MockIGeoConfigMockRecorder struct {
+	mock *MockIGeoConfig
+}
+
+// NewMockIGeoConfig creates a new mock instance.
+func NewMockIGeoConfig(ctrl *gomock.Controller) *MockIGeoConfig {
+	mock := &MockIGeoConfig{ctrl: ctrl}
+	mock.recorder = &MockIGeoConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGeoConfig) EXPECT() *MockIGeoConfigMockRecorder {
+	return m.recorder
+}
+
+// GetDatabasePath mocks base method.
+func (m *MockIGeoConfig) GetDatabasePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabasePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDatabasePath indicates an expected call of GetDatabasePath.
+func (mr *MockIGeoConfigMockRecorder) GetDatabasePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabasePath", reflect.TypeOf((*MockIGeoConfig)(nil).GetDatabasePath))
+}
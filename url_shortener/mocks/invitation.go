@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/invitation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/invitation.go -destination=mocks/invitation.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIInvitationRepository is a mock of IInvitationRepository interface.
+type MockIInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIInvitationRepositoryMockRecorder
+}
+
+// MockIInvitationRepositoryMockRecorder is the mock recorder for MockIInvitationRepository.
+type MockIInvitationRepositoryMockRecorder struct {
+	mock *MockIInvitationRepository
+}
+
+// NewMockIInvitationRepository creates a new mock instance.
+func NewMockIInvitationRepository(ctrl *gomock.Controller) *MockIInvitationRepository {
+	mock := &MockIInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockIInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIInvitationRepository) EXPECT() *MockIInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockIInvitationRepository) Accept(ctx context.Context, id string, acceptedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, id, acceptedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockIInvitationRepositoryMockRecorder) Accept(ctx, id, acceptedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockIInvitationRepository)(nil).Accept), ctx, id, acceptedAt)
+}
+
+// Create mocks base method.
+func (m *MockIInvitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIInvitationRepositoryMockRecorder) Create(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIInvitationRepository)(nil).Create), ctx, invitation)
+}
+
+// Delete mocks base method.
+func (m *MockIInvitationRepository) Delete(ctx context.Context, workspaceID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, workspaceID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIInvitationRepositoryMockRecorder) Delete(ctx, workspaceID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIInvitationRepository)(nil).Delete), ctx, workspaceID, id)
+}
+
+// GetByHash mocks base method.
+func (m *MockIInvitationRepository) GetByHash(ctx context.Context, hash string) (*entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockIInvitationRepositoryMockRecorder) GetByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockIInvitationRepository)(nil).GetByHash), ctx, hash)
+}
+
+// List mocks base method.
+func (m *MockIInvitationRepository) List(ctx context.Context, workspaceID string) ([]*entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, workspaceID)
+	ret0, _ := ret[0].([]*entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIInvitationRepositoryMockRecorder) List(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIInvitationRepository)(nil).List), ctx, workspaceID)
+}
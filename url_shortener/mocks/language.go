@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/language.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/language.go -destination=mocks/language.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
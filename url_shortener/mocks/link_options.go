@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/link_options.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/link_options.go -destination=mocks/link_options.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
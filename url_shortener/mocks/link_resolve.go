@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/link_resolve.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/link_resolve.go -destination=mocks/link_resolve.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
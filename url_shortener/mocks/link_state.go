@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/link_state.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/link_state.go -destination=mocks/link_state.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/member.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/member.go -destination=mocks/member.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMemberRepository is a mock of IMemberRepository interface.
+type MockIMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMemberRepositoryMockRecorder
+}
+
+// MockIMemberRepositoryMockRecorder is the mock recorder for MockIMemberRepository.
+type MockIMemberRepositoryMockRecorder struct {
+	mock *MockIMemberRepository
+}
+
+// NewMockIMemberRepository creates a new mock instance.
+func NewMockIMemberRepository(ctrl *gomock.Controller) *MockIMemberRepository {
+	mock := &MockIMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockIMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMemberRepository) EXPECT() *MockIMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountRole mocks base method.
+func (m *MockIMemberRepository) CountRole(ctx context.Context, workspaceID, role string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRole", ctx, workspaceID, role)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRole indicates an expected call of CountRole.
+func (mr *MockIMemberRepositoryMockRecorder) CountRole(ctx, workspaceID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRole", reflect.TypeOf((*MockIMemberRepository)(nil).CountRole), ctx, workspaceID, role)
+}
+
+// Create mocks base method.
+func (m *MockIMemberRepository) Create(ctx context.Context, member *entity.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIMemberRepositoryMockRecorder) Create(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIMemberRepository)(nil).Create), ctx, member)
+}
+
+// Delete mocks base method.
+func (m *MockIMemberRepository) Delete(ctx context.Context, workspaceID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIMemberRepositoryMockRecorder) Delete(ctx, workspaceID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIMemberRepository)(nil).Delete), ctx, workspaceID, userID)
+}
+
+// Get mocks base method.
+func (m *MockIMemberRepository) Get(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIMemberRepositoryMockRecorder) Get(ctx, workspaceID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIMemberRepository)(nil).Get), ctx, workspaceID, userID)
+}
+
+// List mocks base method.
+func (m *MockIMemberRepository) List(ctx context.Context, workspaceID string) ([]*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, workspaceID)
+	ret0, _ := ret[0].([]*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIMemberRepositoryMockRecorder) List(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIMemberRepository)(nil).List), ctx, workspaceID)
+}
+
+// ListByUser mocks base method.
+func (m *MockIMemberRepository) ListByUser(ctx context.Context, userID string) ([]*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIMemberRepositoryMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIMemberRepository)(nil).ListByUser), ctx, userID)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIMemberRepository) UpdateRole(ctx context.Context, workspaceID, userID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, workspaceID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIMemberRepositoryMockRecorder) UpdateRole(ctx, workspaceID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIMemberRepository)(nil).UpdateRole), ctx, workspaceID, userID, role)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/monitor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/monitor.go -destination=mocks/monitor.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMonitorService is a mock of IMonitorService interface.
+type MockIMonitorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMonitorServiceMockRecorder
+}
+
+// MockIMonitorServiceMockRecorder is the mock recorder for MockIMonitorService.
+type MockIMonitorServiceMockRecorder struct {
+	mock *MockIMonitorService
+}
+
+// NewMockIMonitorService creates a new mock instance.
+func NewMockIMonitorService(ctrl *gomock.Controller) *MockIMonitorService {
+	mock := &MockIMonitorService{ctrl: ctrl}
+	mock.recorder = &MockIMonitorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMonitorService) EXPECT() *MockIMonitorServiceMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockIMonitorService) Scan(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockIMonitorServiceMockRecorder) Scan(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockIMonitorService)(nil).Scan), ctx)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/outbox.go -destination=mocks/outbox.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
+	repository "github.com/flew1x/url_shortener_ms/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOutboxRepository is a mock of IOutboxRepository interface.
+type MockIOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxRepositoryMockRecorder
+}
+
+// MockIOutboxRepositoryMockRecorder is the mock recorder for MockIOutboxRepository.
+type MockIOutboxRepositoryMockRecorder struct {
+	mock *MockIOutboxRepository
+}
+
+// NewMockIOutboxRepository creates a new mock instance.
+func NewMockIOutboxRepository(ctrl *gomock.Controller) *MockIOutboxRepository {
+	mock := &MockIOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockIOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxRepository) EXPECT() *MockIOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIOutboxRepository) Add(ctx context.Context, consumers []string, events []entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, consumers, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIOutboxRepositoryMockRecorder) Add(ctx, consumers, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIOutboxRepository)(nil).Add), ctx, consumers, events)
+}
+
+// Claim mocks base method.
+func (m *MockIOutboxRepository) Claim(ctx context.Context, consumer string, limit int, lease time.Duration) ([]repository.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, consumer, limit, lease)
+	ret0, _ := ret[0].([]repository.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIOutboxRepositoryMockRecorder) Claim(ctx, consumer, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIOutboxRepository)(nil).Claim), ctx, consumer, limit, lease)
+}
+
+// DeadLetter mocks base method.
+func (m *MockIOutboxRepository) DeadLetter(ctx context.Context, consumer string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetter", ctx, consumer, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeadLetter indicates an expected call of DeadLetter.
+func (mr *MockIOutboxRepositoryMockRecorder) DeadLetter(ctx, consumer, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetter", reflect.TypeOf((*MockIOutboxRepository)(nil).DeadLetter), ctx, consumer, ids)
+}
+
+// Remove mocks base method.
+func (m *MockIOutboxRepository) Remove(ctx context.Context, consumer string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, consumer, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIOutboxRepositoryMockRecorder) Remove(ctx, consumer, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIOutboxRepository)(nil).Remove), ctx, consumer, ids)
+}
+
+// Retry mocks base method.
+func (m *MockIOutboxRepository) Retry(ctx context.Context, consumer string, ids []string, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, consumer, ids, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockIOutboxRepositoryMockRecorder) Retry(ctx, consumer, ids, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockIOutboxRepository)(nil).Retry), ctx, consumer, ids, next)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/policy.go -destination=mocks/policy.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/flew1x/url_shortener_ms/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPolicy is a mock of IPolicy interface.
+type MockIPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPolicyMockRecorder
+}
+
+// MockIPolicyMockRecorder is the mock recorder for MockIPolicy.
+type MockIPolicyMockRecorder struct {
+	mock *MockIPolicy
+}
+
+// NewMockIPolicy creates a new mock instance.
+func NewMockIPolicy(ctrl *gomock.Controller) *MockIPolicy {
+	mock := &MockIPolicy{ctrl: ctrl}
+	mock.recorder = &MockIPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPolicy) EXPECT() *MockIPolicyMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockIPolicy) Authorize(ctx context.Context, actor service.Actor, workspaceID, permission string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, actor, workspaceID, permission)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockIPolicyMockRecorder) Authorize(ctx, actor, workspaceID, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockIPolicy)(nil).Authorize), ctx, actor, workspaceID, permission)
+}
+
+// AuthorizeOwner mocks base method.
+func (m *MockIPolicy) AuthorizeOwner(ctx context.Context, actor service.Actor, ownerID, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeOwner", ctx, actor, ownerID, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeOwner indicates an expected call of AuthorizeOwner.
+func (mr *MockIPolicyMockRecorder) AuthorizeOwner(ctx, actor, ownerID, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeOwner", reflect.TypeOf((*MockIPolicy)(nil).AuthorizeOwner), ctx, actor, ownerID, scope)
+}
+
+// Role mocks base method.
+func (m *MockIPolicy) Role(ctx context.Context, actor service.Actor, workspaceID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Role", ctx, actor, workspaceID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Role indicates an expected call of Role.
+func (mr *MockIPolicyMockRecorder) Role(ctx, actor, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockIPolicy)(nil).Role), ctx, actor, workspaceID)
+}
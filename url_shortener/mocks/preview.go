@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/preview.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/preview.go -destination=mocks/preview.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPreviewService is a mock of IPreviewService interface.
+type MockIPreviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPreviewServiceMockRecorder
+}
+
+// MockIPreviewServiceMockRecorder is the mock recorder for MockIPreviewService.
+type MockIPreviewServiceMockRecorder struct {
+	mock *MockIPreviewService
+}
+
+// NewMockIPreviewService creates a new mock instance.
+func NewMockIPreviewService(ctrl *gomock.Controller) *MockIPreviewService {
+	mock := &MockIPreviewService{ctrl: ctrl}
+	mock.recorder = &MockIPreviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPreviewService) EXPECT() *MockIPreviewServiceMockRecorder {
+	return m.recorder
+}
+
+// Title mocks base method.
+func (m *MockIPreviewService) Title(ctx context.Context, destination string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Title", ctx, destination)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Title indicates an expected call of Title.
+func (mr *MockIPreviewServiceMockRecorder) Title(ctx, destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Title", reflect.TypeOf((*MockIPreviewService)(nil).Title), ctx, destination)
+}
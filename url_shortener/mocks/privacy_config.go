@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/privacy_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/privacy_config.go -destination=mocks/privacy_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPrivacyConfig is a mock of IPrivacyConfig interface.
+type MockIPrivacyConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPrivacyConfigMockRecorder
+}
+
+// MockIPrivacyConfigMockRecorder is the mock recorder for MockIPrivacyConfig.
+type MockIPrivacyConfigMockRecorder struct {
+	mock *MockIPrivacyConfig
+}
+
+// NewMockIPrivacyConfig creates a new mock instance.
+func NewMockIPrivacyConfig(ctrl *gomock.Controller) *MockIPrivacyConfig {
+	mock := &MockIPrivacyConfig{ctrl: ctrl}
+	mock.recorder = &MockIPrivacyConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPrivacyConfig) EXPECT() *MockIPrivacyConfigMockRecorder {
+	return m.recorder
+}
+
+// GetClickRetention mocks base method.
+func (m *MockIPrivacyConfig) GetClickRetention() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClickRetention")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetClickRetention indicates an expected call of GetClickRetention.
+func (mr *MockIPrivacyConfigMockRecorder) GetClickRetention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClickRetention", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetClickRetention))
+}
+
+// GetIPAnonymizationMode mocks base method.
+func (m *MockIPrivacyConfig) GetIPAnonymizationMode() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPAnonymizationMode")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetIPAnonymizationMode indicates an expected call of GetIPAnonymizationMode.
+func (mr *MockIPrivacyConfigMockRecorder) GetIPAnonymizationMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPAnonymizationMode", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetIPAnonymizationMode))
+}
+
+// GetIPHashSaltRotation mocks base method.
+func (m *MockIPrivacyConfig) GetIPHashSaltRotation() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPHashSaltRotation")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetIPHashSaltRotation indicates an expected call of GetIPHashSaltRotation.
+func (mr *MockIPrivacyConfigMockRecorder) GetIPHashSaltRotation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPHashSaltRotation", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetIPHashSaltRotation))
+}
+
+// GetIPHashSecret mocks base method.
+func (m *MockIPrivacyConfig) GetIPHashSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPHashSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetIPHashSecret indicates an expected call of GetIPHashSecret.
+func (mr *MockIPrivacyConfigMockRecorder) GetIPHashSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPHashSecret", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetIPHashSecret))
+}
+
+// GetIPv4PrefixLength mocks base method.
+func (m *MockIPrivacyConfig) GetIPv4PrefixLength() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPv4PrefixLength")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetIPv4PrefixLength indicates an expected call of GetIPv4PrefixLength.
+func (mr *MockIPrivacyConfigMockRecorder) GetIPv4PrefixLength() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPv4PrefixLength", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetIPv4PrefixLength))
+}
+
+// GetIPv6PrefixLength mocks base method.
+func (m *MockIPrivacyConfig) GetIPv6PrefixLength() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPv6PrefixLength")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetIPv6PrefixLength indicates an expected call of GetIPv6PrefixLength.
+func (mr *MockIPrivacyConfigMockRecorder) GetIPv6PrefixLength() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPv6PrefixLength", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetIPv6PrefixLength))
+}
+
+// GetRetentionInterval mocks base method.
+func (m *MockIPrivacyConfig) GetRetentionInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetentionInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetRetentionInterval indicates an expected call of GetRetentionInterval.
+func (mr *MockIPrivacyConfigMockRecorder) GetRetentionInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionInterval", reflect.TypeOf((*MockIPrivacyConfig)(nil).GetRetentionInterval))
+}
+
+// RespectDoNotTrack mocks base method.
+func (m *MockIPrivacyConfig) RespectDoNotTrack() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespectDoNotTrack")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RespectDoNotTrack indicates an expected call of RespectDoNotTrack.
+func (mr *MockIPrivacyConfigMockRecorder) RespectDoNotTrack() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespectDoNotTrack", reflect.TypeOf((*MockIPrivacyConfig)(nil).RespectDoNotTrack))
+}
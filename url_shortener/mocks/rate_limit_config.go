@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/rate_limit_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/rate_limit_config.go -destination=mocks/rate_limit_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRateLimitConfig is a mock of IRateLimitConfig interface.
+type MockIRateLimitConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateLimitConfigMockRecorder
+}
+
+// MockIRateLimitConfigMockRecorder is the mock recorder for MockIRateLimitConfig.
+type MockIRateLimitConfigMockRecorder struct {
+	mock *MockIRateLimitConfig
+}
+
+// NewMockIRateLimitConfig creates a new mock instance.
+func NewMockIRateLimitConfig(ctrl *gomock.Controller) *MockIRateLimitConfig {
+	mock := &MockIRateLimitConfig{ctrl: ctrl}
+	mock.recorder = &MockIRateLimitConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateLimitConfig) EXPECT() *MockIRateLimitConfigMockRecorder {
+	return m.recorder
+}
+
+// GetAPILimit mocks base method.
+func (m *MockIRateLimitConfig) GetAPILimit() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPILimit")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAPILimit indicates an expected call of GetAPILimit.
+func (mr *MockIRateLimitConfigMockRecorder) GetAPILimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPILimit", reflect.TypeOf((*MockIRateLimitConfig)(nil).GetAPILimit))
+}
+
+// GetAdminLimit mocks base method.
+func (m *MockIRateLimitConfig) GetAdminLimit() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminLimit")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAdminLimit indicates an expected call of GetAdminLimit.
+func (mr *MockIRateLimitConfigMockRecorder) GetAdminLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminLimit", reflect.TypeOf((*MockIRateLimitConfig)(nil).GetAdminLimit))
+}
+
+// GetAuthLimit mocks base method.
+func (m *MockIRateLimitConfig) GetAuthLimit() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthLimit")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAuthLimit indicates an expected call of GetAuthLimit.
+func (mr *MockIRateLimitConfigMockRecorder) GetAuthLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthLimit", reflect.TypeOf((*MockIRateLimitConfig)(nil).GetAuthLimit))
+}
+
+// GetEnabled mocks base method.
+func (m *MockIRateLimitConfig) GetEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockIRateLimitConfigMockRecorder) GetEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockIRateLimitConfig)(nil).GetEnabled))
+}
+
+// GetRedirectLimit mocks base method.
+func (m *MockIRateLimitConfig) GetRedirectLimit() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectLimit")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRedirectLimit indicates an expected call of GetRedirectLimit.
+func (mr *MockIRateLimitConfigMockRecorder) GetRedirectLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectLimit", reflect.TypeOf((*MockIRateLimitConfig)(nil).GetRedirectLimit))
+}
+
+// GetShortenLimit mocks base method.
+func (m *MockIRateLimitConfig) GetShortenLimit() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortenLimit")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetShortenLimit indicates an expected call of GetShortenLimit.
+func (mr *MockIRateLimitConfigMockRecorder) GetShortenLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortenLimit", reflect.TypeOf((*MockIRateLimitConfig)(nil).GetShortenLimit))
+}
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/schedule.go -destination=mocks/schedule.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -52,18 +52,18 @@ func (mr *MockIServerConfigMockRecorder) GetBindIP() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBindIP", reflect.TypeOf((*MockIServerConfig)(nil).GetBindIP))
 }
 
-// GetLimitPerSecond mocks base method.
-func (m *MockIServerConfig) GetLimitPerSecond() int {
+// GetBrandName mocks base method.
+func (m *MockIServerConfig) GetBrandName() string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLimitPerSecond")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetBrandName")
+	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// GetLimitPerSecond indicates an expected call of GetLimitPerSecond.
-func (mr *MockIServerConfigMockRecorder) GetLimitPerSecond() *gomock.Call {
+// GetBrandName indicates an expected call of GetBrandName.
+func (mr *MockIServerConfigMockRecorder) GetBrandName() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitPerSecond", reflect.TypeOf((*MockIServerConfig)(nil).GetLimitPerSecond))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrandName", reflect.TypeOf((*MockIServerConfig)(nil).GetBrandName))
 }
 
 // GetPort mocks base method.
@@ -93,3 +93,17 @@ func (mr *MockIServerConfigMockRecorder) GetScheme() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheme", reflect.TypeOf((*MockIServerConfig)(nil).GetScheme))
 }
+
+// GetTrustedProxies mocks base method.
+func (m *MockIServerConfig) GetTrustedProxies() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustedProxies")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetTrustedProxies indicates an expected call of GetTrustedProxies.
+func (mr *MockIServerConfigMockRecorder) GetTrustedProxies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustedProxies", reflect.TypeOf((*MockIServerConfig)(nil).GetTrustedProxies))
+}
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/split.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/split.go -destination=mocks/split.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/targeting.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/targeting.go -destination=mocks/targeting.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
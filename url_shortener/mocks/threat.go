@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/threat.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/threat.go -destination=mocks/threat.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIThreatService is a mock of IThreatService interface.
+type MockIThreatService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIThreatServiceMockRecorder
+}
+
+// MockIThreatServiceMockRecorder is the mock recorder for MockIThreatService.
+type MockIThreatServiceMockRecorder struct {
+	mock *MockIThreatService
+}
+
+// NewMockIThreatService creates a new mock instance.
+func NewMockIThreatService(ctrl *gomock.Controller) *MockIThreatService {
+	mock := &MockIThreatService{ctrl: ctrl}
+	mock.recorder = &MockIThreatServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIThreatService) EXPECT() *MockIThreatServiceMockRecorder {
+	return m.recorder
+}
+
+// Rescan mocks base method.
+func (m *MockIThreatService) Rescan(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rescan", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rescan indicates an expected call of Rescan.
+func (mr *MockIThreatServiceMockRecorder) Rescan(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rescan", reflect.TypeOf((*MockIThreatService)(nil).Rescan), ctx)
+}
+
+// Update mocks base method.
+func (m *MockIThreatService) Update(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIThreatServiceMockRecorder) Update(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIThreatService)(nil).Update), ctx)
+}
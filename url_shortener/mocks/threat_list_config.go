@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/threat_list_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/threat_list_config.go -destination=mocks/threat_list_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIThreatListConfig is a mock of IThreatListConfig interface.
+type MockIThreatListConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIThreatListConfigMockRecorder
+}
+
+// MockIThreatListConfigMockRecorder is the mock recorder for MockIThreatListConfig.
+type MockIThreatListConfigMockRecorder struct {
+	mock *MockIThreatListConfig
+}
+
+// NewMockIThreatListConfig creates a new mock instance.
+func NewMockIThreatListConfig(ctrl *gomock.Controller) *MockIThreatListConfig {
+	mock := &MockIThreatListConfig{ctrl: ctrl}
+	mock.recorder = &MockIThreatListConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIThreatListConfig) EXPECT() *MockIThreatListConfigMockRecorder {
+	return m.recorder
+}
+
+// GetRescanInterval mocks base method.
+func (m *MockIThreatListConfig) GetRescanInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRescanInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetRescanInterval indicates an expected call of GetRescanInterval.
+func (mr *MockIThreatListConfigMockRecorder) GetRescanInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRescanInterval", reflect.TypeOf((*MockIThreatListConfig)(nil).GetRescanInterval))
+}
+
+// GetStatePath mocks base method.
+func (m *MockIThreatListConfig) GetStatePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetStatePath indicates an expected call of GetStatePath.
+func (mr *MockIThreatListConfigMockRecorder) GetStatePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatePath", reflect.TypeOf((*MockIThreatListConfig)(nil).GetStatePath))
+}
+
+// GetUpdatePath mocks base method.
+func (m *MockIThreatListConfig) GetUpdatePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpdatePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetUpdatePath indicates an expected call of GetUpdatePath.
+func (mr *MockIThreatListConfigMockRecorder) GetUpdatePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdatePath", reflect.TypeOf((*MockIThreatListConfig)(nil).GetUpdatePath))
+}
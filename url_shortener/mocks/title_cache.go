@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/cache/title_cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/cache/title_cache.go -destination=mocks/title_cache.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITitleCache is a mock of ITitleCache interface.
+type MockITitleCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockITitleCacheMockRecorder
+}
+
+// MockITitleCacheMockRecorder is the mock recorder for MockITitleCache.
+type MockITitleCacheMockRecorder struct {
+	mock *MockITitleCache
+}
+
+// NewMockITitleCache creates a new mock instance.
+func NewMockITitleCache(ctrl *gomock.Controller) *MockITitleCache {
+	mock := &MockITitleCache{ctrl: ctrl}
+	mock.recorder = &MockITitleCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITitleCache) EXPECT() *MockITitleCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockITitleCache) Get(ctx context.Context, pageURL string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, pageURL)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockITitleCacheMockRecorder) Get(ctx, pageURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockITitleCache)(nil).Get), ctx, pageURL)
+}
+
+// Set mocks base method.
+func (m *MockITitleCache) Set(ctx context.Context, pageURL, title string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, pageURL, title, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockITitleCacheMockRecorder) Set(ctx, pageURL, title, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockITitleCache)(nil).Set), ctx, pageURL, title, ttl)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/token.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/token.go -destination=mocks/token.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/flew1x/url_shortener_ms/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITokenService is a mock of ITokenService interface.
+type MockITokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenServiceMockRecorder
+}
+
+// MockITokenServiceMockRecorder is the mock recorder for MockITokenService.
+type MockITokenServiceMockRecorder struct {
+	mock *MockITokenService
+}
+
+// NewMockITokenService creates a new mock instance.
+func NewMockITokenService(ctrl *gomock.Controller) *MockITokenService {
+	mock := &MockITokenService{ctrl: ctrl}
+	mock.recorder = &MockITokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenService) EXPECT() *MockITokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockITokenService) Authenticate(ctx context.Context, token string) (service.TokenIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(service.TokenIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockITokenServiceMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockITokenService)(nil).Authenticate), ctx, token)
+}
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -53,6 +54,62 @@ func (mr *MockIURLMockRecorder) GetCreatedAt() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatedAt", reflect.TypeOf((*MockIURL)(nil).GetCreatedAt))
 }
 
+// GetDeepLink mocks base method.
+func (m *MockIURL) GetDeepLink() *entity.DeepLink {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeepLink")
+	ret0, _ := ret[0].(*entity.DeepLink)
+	return ret0
+}
+
+// GetDeepLink indicates an expected call of GetDeepLink.
+func (mr *MockIURLMockRecorder) GetDeepLink() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeepLink", reflect.TypeOf((*MockIURL)(nil).GetDeepLink))
+}
+
+// GetDisabledBy mocks base method.
+func (m *MockIURL) GetDisabledBy() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisabledBy")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDisabledBy indicates an expected call of GetDisabledBy.
+func (mr *MockIURLMockRecorder) GetDisabledBy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisabledBy", reflect.TypeOf((*MockIURL)(nil).GetDisabledBy))
+}
+
+// GetDisabledReason mocks base method.
+func (m *MockIURL) GetDisabledReason() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisabledReason")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDisabledReason indicates an expected call of GetDisabledReason.
+func (mr *MockIURLMockRecorder) GetDisabledReason() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisabledReason", reflect.TypeOf((*MockIURL)(nil).GetDisabledReason))
+}
+
+// GetLanguages mocks base method.
+func (m *MockIURL) GetLanguages() []entity.LanguageRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLanguages")
+	ret0, _ := ret[0].([]entity.LanguageRule)
+	return ret0
+}
+
+// GetLanguages indicates an expected call of GetLanguages.
+func (mr *MockIURLMockRecorder) GetLanguages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLanguages", reflect.TypeOf((*MockIURL)(nil).GetLanguages))
+}
+
 // GetOrigin mocks base method.
 func (m *MockIURL) GetOrigin() string {
 	m.ctrl.T.Helper()
@@ -67,6 +124,104 @@ func (mr *MockIURLMockRecorder) GetOrigin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrigin", reflect.TypeOf((*MockIURL)(nil).GetOrigin))
 }
 
+// GetOwnerID mocks base method.
+func (m *MockIURL) GetOwnerID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetOwnerID indicates an expected call of GetOwnerID.
+func (mr *MockIURLMockRecorder) GetOwnerID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerID", reflect.TypeOf((*MockIURL)(nil).GetOwnerID))
+}
+
+// GetQueryAllowlist mocks base method.
+func (m *MockIURL) GetQueryAllowlist() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryAllowlist")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetQueryAllowlist indicates an expected call of GetQueryAllowlist.
+func (mr *MockIURLMockRecorder) GetQueryAllowlist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryAllowlist", reflect.TypeOf((*MockIURL)(nil).GetQueryAllowlist))
+}
+
+// GetQueryPolicy mocks base method.
+func (m *MockIURL) GetQueryPolicy() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryPolicy")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetQueryPolicy indicates an expected call of GetQueryPolicy.
+func (mr *MockIURLMockRecorder) GetQueryPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryPolicy", reflect.TypeOf((*MockIURL)(nil).GetQueryPolicy))
+}
+
+// GetQueryPrecedence mocks base method.
+func (m *MockIURL) GetQueryPrecedence() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryPrecedence")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetQueryPrecedence indicates an expected call of GetQueryPrecedence.
+func (mr *MockIURLMockRecorder) GetQueryPrecedence() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryPrecedence", reflect.TypeOf((*MockIURL)(nil).GetQueryPrecedence))
+}
+
+// GetRedirectCode mocks base method.
+func (m *MockIURL) GetRedirectCode() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectCode")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRedirectCode indicates an expected call of GetRedirectCode.
+func (mr *MockIURLMockRecorder) GetRedirectCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectCode", reflect.TypeOf((*MockIURL)(nil).GetRedirectCode))
+}
+
+// GetRules mocks base method.
+func (m *MockIURL) GetRules() []entity.TargetingRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules")
+	ret0, _ := ret[0].([]entity.TargetingRule)
+	return ret0
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockIURLMockRecorder) GetRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockIURL)(nil).GetRules))
+}
+
+// GetSchedule mocks base method.
+func (m *MockIURL) GetSchedule() *entity.Schedule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule")
+	ret0, _ := ret[0].(*entity.Schedule)
+	return ret0
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockIURLMockRecorder) GetSchedule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockIURL)(nil).GetSchedule))
+}
+
 // GetShort mocks base method.
 func (m *MockIURL) GetShort() string {
 	m.ctrl.T.Helper()
@@ -80,3 +235,73 @@ func (mr *MockIURLMockRecorder) GetShort() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShort", reflect.TypeOf((*MockIURL)(nil).GetShort))
 }
+
+// GetSplit mocks base method.
+func (m *MockIURL) GetSplit() *entity.Split {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSplit")
+	ret0, _ := ret[0].(*entity.Split)
+	return ret0
+}
+
+// GetSplit indicates an expected call of GetSplit.
+func (mr *MockIURLMockRecorder) GetSplit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSplit", reflect.TypeOf((*MockIURL)(nil).GetSplit))
+}
+
+// GetUTM mocks base method.
+func (m *MockIURL) GetUTM() *entity.UTM {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUTM")
+	ret0, _ := ret[0].(*entity.UTM)
+	return ret0
+}
+
+// GetUTM indicates an expected call of GetUTM.
+func (mr *MockIURLMockRecorder) GetUTM() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTM", reflect.TypeOf((*MockIURL)(nil).GetUTM))
+}
+
+// IsDisabled mocks base method.
+func (m *MockIURL) IsDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDisabled indicates an expected call of IsDisabled.
+func (mr *MockIURLMockRecorder) IsDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDisabled", reflect.TypeOf((*MockIURL)(nil).IsDisabled))
+}
+
+// IsInterstitial mocks base method.
+func (m *MockIURL) IsInterstitial() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInterstitial")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsInterstitial indicates an expected call of IsInterstitial.
+func (mr *MockIURLMockRecorder) IsInterstitial() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInterstitial", reflect.TypeOf((*MockIURL)(nil).IsInterstitial))
+}
+
+// IsPrefix mocks base method.
+func (m *MockIURL) IsPrefix() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrefix")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPrefix indicates an expected call of IsPrefix.
+func (mr *MockIURLMockRecorder) IsPrefix() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrefix", reflect.TypeOf((*MockIURL)(nil).IsPrefix))
+}
@@ -40,19 +40,47 @@ func (m *MockIUrlCache) EXPECT() *MockIUrlCacheMockRecorder {
 	return m.recorder
 }
 
+// DeleteByLongUrl mocks base method.
+func (m *MockIUrlCache) DeleteByLongUrl(ctx context.Context, ownerID, longUrl string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByLongUrl", ctx, ownerID, longUrl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByLongUrl indicates an expected call of DeleteByLongUrl.
+func (mr *MockIUrlCacheMockRecorder) DeleteByLongUrl(ctx, ownerID, longUrl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByLongUrl", reflect.TypeOf((*MockIUrlCache)(nil).DeleteByLongUrl), ctx, ownerID, longUrl)
+}
+
+// DeleteByShortUrl mocks base method.
+func (m *MockIUrlCache) DeleteByShortUrl(ctx context.Context, shortUrl string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByShortUrl", ctx, shortUrl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByShortUrl indicates an expected call of DeleteByShortUrl.
+func (mr *MockIUrlCacheMockRecorder) DeleteByShortUrl(ctx, shortUrl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByShortUrl", reflect.TypeOf((*MockIUrlCache)(nil).DeleteByShortUrl), ctx, shortUrl)
+}
+
 // GetByLongUrl mocks base method.
-func (m *MockIUrlCache) GetByLongUrl(ctx context.Context, longUrl string) (entity.IURL, error) {
+func (m *MockIUrlCache) GetByLongUrl(ctx context.Context, ownerID, longUrl string) (entity.IURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByLongUrl", ctx, longUrl)
+	ret := m.ctrl.Call(m, "GetByLongUrl", ctx, ownerID, longUrl)
 	ret0, _ := ret[0].(entity.IURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByLongUrl indicates an expected call of GetByLongUrl.
-func (mr *MockIUrlCacheMockRecorder) GetByLongUrl(ctx, longUrl any) *gomock.Call {
+func (mr *MockIUrlCacheMockRecorder) GetByLongUrl(ctx, ownerID, longUrl any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLongUrl", reflect.TypeOf((*MockIUrlCache)(nil).GetByLongUrl), ctx, longUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLongUrl", reflect.TypeOf((*MockIUrlCache)(nil).GetByLongUrl), ctx, ownerID, longUrl)
 }
 
 // GetByShortUrl mocks base method.
@@ -70,6 +98,21 @@ func (mr *MockIUrlCacheMockRecorder) GetByShortUrl(ctx, shortUrl any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShortUrl", reflect.TypeOf((*MockIUrlCache)(nil).GetByShortUrl), ctx, shortUrl)
 }
 
+// GetByShortUrls mocks base method.
+func (m *MockIUrlCache) GetByShortUrls(ctx context.Context, shortUrls []string) (map[string]entity.IURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByShortUrls", ctx, shortUrls)
+	ret0, _ := ret[0].(map[string]entity.IURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByShortUrls indicates an expected call of GetByShortUrls.
+func (mr *MockIUrlCacheMockRecorder) GetByShortUrls(ctx, shortUrls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShortUrls", reflect.TypeOf((*MockIUrlCache)(nil).GetByShortUrls), ctx, shortUrls)
+}
+
 // SetByLongUrl mocks base method.
 func (m *MockIUrlCache) SetByLongUrl(ctx context.Context, url entity.IURL) error {
 	m.ctrl.T.Helper()
@@ -97,3 +140,17 @@ func (mr *MockIUrlCacheMockRecorder) SetByShortUrl(ctx, url any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetByShortUrl", reflect.TypeOf((*MockIUrlCache)(nil).SetByShortUrl), ctx, url)
 }
+
+// SetMissing mocks base method.
+func (m *MockIUrlCache) SetMissing(ctx context.Context, shortUrls []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMissing", ctx, shortUrls)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMissing indicates an expected call of SetMissing.
+func (mr *MockIUrlCacheMockRecorder) SetMissing(ctx, shortUrls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMissing", reflect.TypeOf((*MockIUrlCache)(nil).SetMissing), ctx, shortUrls)
+}
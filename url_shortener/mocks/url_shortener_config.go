@@ -39,6 +39,132 @@ func (m *MockIURLConfig) EXPECT() *MockIURLConfigMockRecorder {
 	return m.recorder
 }
 
+// GetMissingCacheTTL mocks base method.
+func (m *MockIURLConfig) GetMissingCacheTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissingCacheTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMissingCacheTTL indicates an expected call of GetMissingCacheTTL.
+func (mr *MockIURLConfigMockRecorder) GetMissingCacheTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissingCacheTTL", reflect.TypeOf((*MockIURLConfig)(nil).GetMissingCacheTTL))
+}
+
+// GetPermanentRedirectMaxAge mocks base method.
+func (m *MockIURLConfig) GetPermanentRedirectMaxAge() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermanentRedirectMaxAge")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPermanentRedirectMaxAge indicates an expected call of GetPermanentRedirectMaxAge.
+func (mr *MockIURLConfigMockRecorder) GetPermanentRedirectMaxAge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermanentRedirectMaxAge", reflect.TypeOf((*MockIURLConfig)(nil).GetPermanentRedirectMaxAge))
+}
+
+// GetPrefixMaxDepth mocks base method.
+func (m *MockIURLConfig) GetPrefixMaxDepth() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrefixMaxDepth")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetPrefixMaxDepth indicates an expected call of GetPrefixMaxDepth.
+func (mr *MockIURLConfigMockRecorder) GetPrefixMaxDepth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrefixMaxDepth", reflect.TypeOf((*MockIURLConfig)(nil).GetPrefixMaxDepth))
+}
+
+// GetPreviewTitleCacheTTL mocks base method.
+func (m *MockIURLConfig) GetPreviewTitleCacheTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreviewTitleCacheTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPreviewTitleCacheTTL indicates an expected call of GetPreviewTitleCacheTTL.
+func (mr *MockIURLConfigMockRecorder) GetPreviewTitleCacheTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreviewTitleCacheTTL", reflect.TypeOf((*MockIURLConfig)(nil).GetPreviewTitleCacheTTL))
+}
+
+// GetPreviewTitleTimeout mocks base method.
+func (m *MockIURLConfig) GetPreviewTitleTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreviewTitleTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPreviewTitleTimeout indicates an expected call of GetPreviewTitleTimeout.
+func (mr *MockIURLConfigMockRecorder) GetPreviewTitleTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreviewTitleTimeout", reflect.TypeOf((*MockIURLConfig)(nil).GetPreviewTitleTimeout))
+}
+
+// GetRedirectStatusCode mocks base method.
+func (m *MockIURLConfig) GetRedirectStatusCode() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectStatusCode")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRedirectStatusCode indicates an expected call of GetRedirectStatusCode.
+func (mr *MockIURLConfigMockRecorder) GetRedirectStatusCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectStatusCode", reflect.TypeOf((*MockIURLConfig)(nil).GetRedirectStatusCode))
+}
+
+// GetSchedulePendingMessage mocks base method.
+func (m *MockIURLConfig) GetSchedulePendingMessage() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulePendingMessage")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSchedulePendingMessage indicates an expected call of GetSchedulePendingMessage.
+func (mr *MockIURLConfigMockRecorder) GetSchedulePendingMessage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulePendingMessage", reflect.TypeOf((*MockIURLConfig)(nil).GetSchedulePendingMessage))
+}
+
+// GetScheduleTimezone mocks base method.
+func (m *MockIURLConfig) GetScheduleTimezone() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleTimezone")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetScheduleTimezone indicates an expected call of GetScheduleTimezone.
+func (mr *MockIURLConfigMockRecorder) GetScheduleTimezone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleTimezone", reflect.TypeOf((*MockIURLConfig)(nil).GetScheduleTimezone))
+}
+
+// GetSplitCookieMaxAge mocks base method.
+func (m *MockIURLConfig) GetSplitCookieMaxAge() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSplitCookieMaxAge")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetSplitCookieMaxAge indicates an expected call of GetSplitCookieMaxAge.
+func (mr *MockIURLConfigMockRecorder) GetSplitCookieMaxAge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSplitCookieMaxAge", reflect.TypeOf((*MockIURLConfig)(nil).GetSplitCookieMaxAge))
+}
+
 // LengthShortURL mocks base method.
 func (m *MockIURLConfig) LengthShortURL() int {
 	m.ctrl.T.Helper()
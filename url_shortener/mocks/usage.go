@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/usage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/usage.go -destination=mocks/usage.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/cache/usage_cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/cache/usage_cache.go -destination=mocks/usage_cache.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUsageCache is a mock of IUsageCache interface.
+type MockIUsageCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUsageCacheMockRecorder
+}
+
+// MockIUsageCacheMockRecorder is the mock recorder for MockIUsageCache.
+type MockIUsageCacheMockRecorder struct {
+	mock *MockIUsageCache
+}
+
+// NewMockIUsageCache creates a new mock instance.
+func NewMockIUsageCache(ctrl *gomock.Controller) *MockIUsageCache {
+	mock := &MockIUsageCache{ctrl: ctrl}
+	mock.recorder = &MockIUsageCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUsageCache) EXPECT() *MockIUsageCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIUsageCache) Get(ctx context.Context, account string, periods []string) ([]*entity.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, account, periods)
+	ret0, _ := ret[0].([]*entity.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIUsageCacheMockRecorder) Get(ctx, account, periods any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIUsageCache)(nil).Get), ctx, account, periods)
+}
+
+// Increment mocks base method.
+func (m *MockIUsageCache) Increment(ctx context.Context, account, metric string, periods []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, account, metric, periods)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockIUsageCacheMockRecorder) Increment(ctx, account, metric, periods any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockIUsageCache)(nil).Increment), ctx, account, metric, periods)
+}
+
+// MarkDirty mocks base method.
+func (m *MockIUsageCache) MarkDirty(ctx context.Context, usages []*entity.Usage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDirty", ctx, usages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDirty indicates an expected call of MarkDirty.
+func (mr *MockIUsageCacheMockRecorder) MarkDirty(ctx, usages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDirty", reflect.TypeOf((*MockIUsageCache)(nil).MarkDirty), ctx, usages)
+}
+
+// PopDirty mocks base method.
+func (m *MockIUsageCache) PopDirty(ctx context.Context, count int64) ([]*entity.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopDirty", ctx, count)
+	ret0, _ := ret[0].([]*entity.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopDirty indicates an expected call of PopDirty.
+func (mr *MockIUsageCacheMockRecorder) PopDirty(ctx, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopDirty", reflect.TypeOf((*MockIUsageCache)(nil).PopDirty), ctx, count)
+}
+
+// Seed mocks base method.
+func (m *MockIUsageCache) Seed(ctx context.Context, usages []*entity.Usage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", ctx, usages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockIUsageCacheMockRecorder) Seed(ctx, usages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockIUsageCache)(nil).Seed), ctx, usages)
+}
+
+// Unseeded mocks base method.
+func (m *MockIUsageCache) Unseeded(ctx context.Context, account string, periods []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unseeded", ctx, account, periods)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unseeded indicates an expected call of Unseeded.
+func (mr *MockIUsageCacheMockRecorder) Unseeded(ctx, account, periods any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unseeded", reflect.TypeOf((*MockIUsageCache)(nil).Unseeded), ctx, account, periods)
+}
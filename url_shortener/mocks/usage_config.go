@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/usage_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/usage_config.go -destination=mocks/usage_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	config "github.com/flew1x/url_shortener_ms/internal/config"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUsageConfig is a mock of IUsageConfig interface.
+type MockIUsageConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUsageConfigMockRecorder
+}
+
+// MockIUsageConfigMockRecorder is the mock recorder for MockIUsageConfig.
+type MockIUsageConfigMockRecorder struct {
+	mock *MockIUsageConfig
+}
+
+// NewMockIUsageConfig creates a new mock instance.
+func NewMockIUsageConfig(ctrl *gomock.Controller) *MockIUsageConfig {
+	mock := &MockIUsageConfig{ctrl: ctrl}
+	mock.recorder = &MockIUsageConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUsageConfig) EXPECT() *MockIUsageConfigMockRecorder {
+	return m.recorder
+}
+
+// GetAccountPlans mocks base method.
+func (m *MockIUsageConfig) GetAccountPlans() []config.AccountPlan {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountPlans")
+	ret0, _ := ret[0].([]config.AccountPlan)
+	return ret0
+}
+
+// GetAccountPlans indicates an expected call of GetAccountPlans.
+func (mr *MockIUsageConfigMockRecorder) GetAccountPlans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountPlans", reflect.TypeOf((*MockIUsageConfig)(nil).GetAccountPlans))
+}
+
+// GetDefaultPlan mocks base method.
+func (m *MockIUsageConfig) GetDefaultPlan() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultPlan")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultPlan indicates an expected call of GetDefaultPlan.
+func (mr *MockIUsageConfigMockRecorder) GetDefaultPlan() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultPlan", reflect.TypeOf((*MockIUsageConfig)(nil).GetDefaultPlan))
+}
+
+// GetEnabled mocks base method.
+func (m *MockIUsageConfig) GetEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockIUsageConfigMockRecorder) GetEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockIUsageConfig)(nil).GetEnabled))
+}
+
+// GetFlushInterval mocks base method.
+func (m *MockIUsageConfig) GetFlushInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlushInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetFlushInterval indicates an expected call of GetFlushInterval.
+func (mr *MockIUsageConfigMockRecorder) GetFlushInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlushInterval", reflect.TypeOf((*MockIUsageConfig)(nil).GetFlushInterval))
+}
+
+// GetPlans mocks base method.
+func (m *MockIUsageConfig) GetPlans() map[string]config.UsagePlan {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlans")
+	ret0, _ := ret[0].(map[string]config.UsagePlan)
+	return ret0
+}
+
+// GetPlans indicates an expected call of GetPlans.
+func (mr *MockIUsageConfigMockRecorder) GetPlans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlans", reflect.TypeOf((*MockIUsageConfig)(nil).GetPlans))
+}
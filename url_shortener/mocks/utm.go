@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/utm.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/utm.go -destination=mocks/utm.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/visitor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/visitor.go -destination=mocks/visitor.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/webhook.go -destination=mocks/webhook.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
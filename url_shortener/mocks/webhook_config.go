@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/webhook_config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/webhook_config.go -destination=mocks/webhook_config.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookConfig is a mock of IWebhookConfig interface.
+type MockIWebhookConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookConfigMockRecorder
+}
+
+// MockIWebhookConfigMockRecorder is the mock recorder for MockIWebhookConfig.
+type MockIWebhookConfigMockRecorder struct {
+	mock *MockIWebhookConfig
+}
+
+// NewMockIWebhookConfig creates a new mock instance.
+func NewMockIWebhookConfig(ctrl *gomock.Controller) *MockIWebhookConfig {
+	mock := &MockIWebhookConfig{ctrl: ctrl}
+	mock.recorder = &MockIWebhookConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookConfig) EXPECT() *MockIWebhookConfigMockRecorder {
+	return m.recorder
+}
+
+// GetBatchSize mocks base method.
+func (m *MockIWebhookConfig) GetBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetBatchSize indicates an expected call of GetBatchSize.
+func (mr *MockIWebhookConfigMockRecorder) GetBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchSize", reflect.TypeOf((*MockIWebhookConfig)(nil).GetBatchSize))
+}
+
+// GetDispatchInterval mocks base method.
+func (m *MockIWebhookConfig) GetDispatchInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispatchInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDispatchInterval indicates an expected call of GetDispatchInterval.
+func (mr *MockIWebhookConfigMockRecorder) GetDispatchInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispatchInterval", reflect.TypeOf((*MockIWebhookConfig)(nil).GetDispatchInterval))
+}
+
+// GetInitialBackoff mocks base method.
+func (m *MockIWebhookConfig) GetInitialBackoff() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitialBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetInitialBackoff indicates an expected call of GetInitialBackoff.
+func (mr *MockIWebhookConfigMockRecorder) GetInitialBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitialBackoff", reflect.TypeOf((*MockIWebhookConfig)(nil).GetInitialBackoff))
+}
+
+// GetMaxAttempts mocks base method.
+func (m *MockIWebhookConfig) GetMaxAttempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxAttempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxAttempts indicates an expected call of GetMaxAttempts.
+func (mr *MockIWebhookConfigMockRecorder) GetMaxAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxAttempts", reflect.TypeOf((*MockIWebhookConfig)(nil).GetMaxAttempts))
+}
+
+// GetMaxBackoff mocks base method.
+func (m *MockIWebhookConfig) GetMaxBackoff() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMaxBackoff indicates an expected call of GetMaxBackoff.
+func (mr *MockIWebhookConfigMockRecorder) GetMaxBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBackoff", reflect.TypeOf((*MockIWebhookConfig)(nil).GetMaxBackoff))
+}
+
+// GetMonitorInterval mocks base method.
+func (m *MockIWebhookConfig) GetMonitorInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonitorInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMonitorInterval indicates an expected call of GetMonitorInterval.
+func (mr *MockIWebhookConfigMockRecorder) GetMonitorInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonitorInterval", reflect.TypeOf((*MockIWebhookConfig)(nil).GetMonitorInterval))
+}
+
+// GetTimeout mocks base method.
+func (m *MockIWebhookConfig) GetTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetTimeout indicates an expected call of GetTimeout.
+func (mr *MockIWebhookConfigMockRecorder) GetTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeout", reflect.TypeOf((*MockIWebhookConfig)(nil).GetTimeout))
+}
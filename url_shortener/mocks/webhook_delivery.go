@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/webhook_delivery.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/webhook_delivery.go -destination=mocks/webhook_delivery.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/flew1x/url_shortener_ms/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookDeliveryRepository is a mock of IWebhookDeliveryRepository interface.
+type MockIWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookDeliveryRepositoryMockRecorder
+}
+
+// MockIWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockIWebhookDeliveryRepository.
+type MockIWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockIWebhookDeliveryRepository
+}
+
+// NewMockIWebhookDeliveryRepository creates a new mock instance.
+func NewMockIWebhookDeliveryRepository(ctrl *gomock.Controller) *MockIWebhookDeliveryRepository {
+	mock := &MockIWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockIWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookDeliveryRepository) EXPECT() *MockIWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIWebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) Create(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).Create), ctx, delivery)
+}
+
+// Due mocks base method.
+func (m *MockIWebhookDeliveryRepository) Due(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Due", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Due indicates an expected call of Due.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) Due(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Due", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).Due), ctx, now, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockIWebhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWebhook mocks base method.
+func (m *MockIWebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWebhook", ctx, webhookID, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWebhook indicates an expected call of ListByWebhook.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) ListByWebhook(ctx, webhookID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWebhook", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).ListByWebhook), ctx, webhookID, limit)
+}
+
+// Update mocks base method.
+func (m *MockIWebhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) Update(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).Update), ctx, delivery)
+}
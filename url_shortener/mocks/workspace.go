@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entity/workspace.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/entity/workspace.go -destination=mocks/workspace.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
@@ -0,0 +1,113 @@
+package anonymizer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"time"
+)
+
+type IAnonymizer interface {
+	// Anonymize returns an anonymized representation of the given IP address.
+	Anonymize(ip string) string
+}
+
+// noopAnonymizer keeps IP addresses unchanged.
+type noopAnonymizer struct{}
+
+// NewNoopAnonymizer returns an IAnonymizer that keeps IP addresses unchanged.
+func NewNoopAnonymizer() IAnonymizer {
+	return &noopAnonymizer{}
+}
+
+// Anonymize implements IAnonymizer.
+func (a *noopAnonymizer) Anonymize(ip string) string {
+	return ip
+}
+
+// truncatingAnonymizer zeroes the host part of IP addresses.
+type truncatingAnonymizer struct {
+	ipv4Mask net.IPMask
+	ipv6Mask net.IPMask
+}
+
+// NewTruncatingAnonymizer returns an IAnonymizer that keeps only the first
+// bits of an IP address.
+//
+// Parameters:
+// - ipv4Bits: the number of bits kept for IPv4 addresses.
+// - ipv6Bits: the number of bits kept for IPv6 addresses.
+//
+// Returns:
+// - IAnonymizer: the truncating anonymizer.
+func NewTruncatingAnonymizer(ipv4Bits, ipv6Bits int) IAnonymizer {
+	return &truncatingAnonymizer{
+		ipv4Mask: net.CIDRMask(ipv4Bits, net.IPv4len*8),
+		ipv6Mask: net.CIDRMask(ipv6Bits, net.IPv6len*8),
+	}
+}
+
+// Anonymize implements IAnonymizer.
+//
+// Values that are not IP addresses are dropped.
+func (a *truncatingAnonymizer) Anonymize(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		return ipv4.Mask(a.ipv4Mask).String()
+	}
+
+	return parsed.Mask(a.ipv6Mask).String()
+}
+
+// hashingAnonymizer replaces IP addresses with a keyed hash whose salt
+// rotates periodically, so that hashes cannot be linked across periods.
+type hashingAnonymizer struct {
+	secret   []byte
+	rotation time.Duration
+	now      func() time.Time
+}
+
+// NewHashingAnonymizer returns an IAnonymizer that replaces IP addresses
+// with an HMAC-SHA256 keyed by a salt derived from the secret and the
+// current rotation period.
+//
+// Parameters:
+// - secret: the secret key salts are derived from.
+// - rotation: how often the salt changes.
+//
+// Returns:
+// - IAnonymizer: the hashing anonymizer.
+func NewHashingAnonymizer(secret string, rotation time.Duration) IAnonymizer {
+	return &hashingAnonymizer{secret: []byte(secret), rotation: rotation, now: time.Now}
+}
+
+// Anonymize implements IAnonymizer.
+func (a *hashingAnonymizer) Anonymize(ip string) string {
+	if ip == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, a.salt())
+	mac.Write([]byte(ip))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// salt derives the salt of the current rotation period from the secret.
+func (a *hashingAnonymizer) salt() []byte {
+	period := make([]byte, 8)
+	if a.rotation > 0 {
+		binary.BigEndian.PutUint64(period, uint64(a.now().UnixNano()/int64(a.rotation)))
+	}
+
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write(period)
+
+	return mac.Sum(nil)
+}
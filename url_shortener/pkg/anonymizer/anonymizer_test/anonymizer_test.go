@@ -0,0 +1,53 @@
+package anonymizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/pkg/anonymizer"
+)
+
+func TestTruncatingAnonymizer(t *testing.T) {
+	a := anonymizer.NewTruncatingAnonymizer(24, 48)
+
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "ipv4", ip: "203.0.113.195", want: "203.0.113.0"},
+		{name: "ipv4 mapped", ip: "::ffff:203.0.113.195", want: "203.0.113.0"},
+		{name: "ipv6", ip: "2001:db8:85a3:8d3:1319:8a2e:370:7348", want: "2001:db8:85a3::"},
+		{name: "invalid", ip: "not an ip", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Anonymize(tt.ip); got != tt.want {
+				t.Errorf("Anonymize(%q) = %q, want %q", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashingAnonymizer(t *testing.T) {
+	a := anonymizer.NewHashingAnonymizer("secret", 24*time.Hour)
+	b := anonymizer.NewHashingAnonymizer("other secret", 24*time.Hour)
+
+	first := a.Anonymize("203.0.113.195")
+	if first == "" || first == "203.0.113.195" {
+		t.Fatalf("Anonymize returned %q", first)
+	}
+
+	if second := a.Anonymize("203.0.113.195"); second != first {
+		t.Errorf("hash is not stable within a rotation period: %q != %q", first, second)
+	}
+
+	if other := b.Anonymize("203.0.113.195"); other == first {
+		t.Errorf("hash does not depend on the secret")
+	}
+
+	if empty := a.Anonymize(""); empty != "" {
+		t.Errorf("Anonymize(\"\") = %q, want empty", empty)
+	}
+}
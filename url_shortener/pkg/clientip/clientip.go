@@ -0,0 +1,107 @@
+package clientip
+
+import (
+	"net"
+	"strings"
+)
+
+// Resolver finds the address of the client behind trusted proxies.
+type Resolver struct {
+	trusted []*net.IPNet
+}
+
+// NewResolver returns a Resolver trusting the given networks.
+//
+// Parameters:
+// - trusted: the networks of the proxies allowed to set X-Forwarded-For.
+//
+// Returns:
+// - *Resolver: the resolver.
+func NewResolver(trusted []*net.IPNet) *Resolver {
+	return &Resolver{trusted: trusted}
+}
+
+// ParseNetworks parses CIDR ranges and single addresses.
+//
+// Parameters:
+// - values: the networks, e.g. "10.0.0.0/8" or "127.0.0.1".
+//
+// Returns:
+// - []*net.IPNet: the parsed networks.
+// - error: an error if a value is neither a CIDR range nor an address.
+func ParseNetworks(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// Resolve returns the address of the client.
+//
+// X-Forwarded-For is only read when the peer is a trusted proxy. It is
+// then walked from the right, the nearest hop first, and the first address
+// that is not a trusted proxy is the client. Addresses left of it could
+// have been forged by the client.
+//
+// Parameters:
+// - remoteAddr: the address of the peer, with or without a port.
+// - forwardedFor: the X-Forwarded-For header.
+//
+// Returns:
+// - string: the address of the client.
+func (r *Resolver) Resolve(remoteAddr, forwardedFor string) string {
+	peer := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		peer = host
+	}
+
+	if !r.isTrusted(peer) || forwardedFor == "" {
+		return peer
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// A malformed entry ends the chain that can be trusted.
+			return peer
+		}
+		if !r.isTrusted(hop) {
+			return hop
+		}
+		peer = hop
+	}
+
+	return peer
+}
+
+// isTrusted reports whether the address belongs to a trusted proxy.
+func (r *Resolver) isTrusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range r.trusted {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+package clientip
+
+import (
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/pkg/clientip"
+)
+
+func TestResolve(t *testing.T) {
+	trusted, err := clientip.ParseNetworks([]string{"10.0.0.0/8", "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("ParseNetworks() error = %v", err)
+	}
+	resolver := clientip.NewResolver(trusted)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{name: "untrusted peer", remoteAddr: "203.0.113.7:4711", forwardedFor: "198.51.100.1", want: "203.0.113.7"},
+		{name: "trusted peer", remoteAddr: "10.0.0.2:4711", forwardedFor: "198.51.100.1", want: "198.51.100.1"},
+		{name: "forged entries", remoteAddr: "127.0.0.1:4711", forwardedFor: "192.0.2.9, 198.51.100.1, 10.0.0.3", want: "198.51.100.1"},
+		{name: "no header", remoteAddr: "10.0.0.2:4711", want: "10.0.0.2"},
+		{name: "malformed", remoteAddr: "10.0.0.2:4711", forwardedFor: "garbage", want: "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.Resolve(tt.remoteAddr, tt.forwardedFor); got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
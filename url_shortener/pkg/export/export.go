@@ -0,0 +1,142 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	FORMAT_CSV    = "csv"
+	FORMAT_NDJSON = "ndjson"
+)
+
+type IRowWriter interface {
+	// WriteRow writes a single row whose values follow the order of the fields.
+	WriteRow(values []any) error
+
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+// NewRowWriter returns an IRowWriter for the given format.
+//
+// Parameters:
+// - format: the export format, "csv" or "ndjson".
+// - w: the writer rows are written to.
+// - fields: the names of the exported fields.
+//
+// Returns:
+// - IRowWriter: the row writer.
+// - error: ErrUnknownFormat if the format is not supported.
+func NewRowWriter(format string, w io.Writer, fields []string) (IRowWriter, error) {
+	switch format {
+	case FORMAT_CSV:
+		return NewCSVWriter(w, fields)
+	case FORMAT_NDJSON:
+		return NewNDJSONWriter(w, fields), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ContentType returns the MIME type of the given format.
+//
+// Parameters:
+// - format: the export format.
+//
+// Returns:
+// - string: the MIME type.
+func ContentType(format string) string {
+	if format == FORMAT_CSV {
+		return "text/csv; charset=utf-8"
+	}
+
+	return "application/x-ndjson"
+}
+
+// csvWriter writes rows as comma-separated values with a header line.
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+// NewCSVWriter returns an IRowWriter that writes CSV and writes the header immediately.
+//
+// Parameters:
+// - w: the writer rows are written to.
+// - fields: the names of the exported fields, used as the header.
+//
+// Returns:
+// - IRowWriter: the CSV row writer.
+// - error: an error if the header could not be written.
+func NewCSVWriter(w io.Writer, fields []string) (IRowWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(fields); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: writer}, nil
+}
+
+// WriteRow implements IRowWriter.
+func (c *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatValue(value)
+	}
+
+	return c.writer.Write(record)
+}
+
+// Flush implements IRowWriter.
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// formatValue converts a value to its CSV representation.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ndjsonWriter writes every row as a JSON object on its own line.
+type ndjsonWriter struct {
+	encoder *json.Encoder
+	fields  []string
+}
+
+// NewNDJSONWriter returns an IRowWriter that writes newline-delimited JSON.
+//
+// Parameters:
+// - w: the writer rows are written to.
+// - fields: the names of the exported fields, used as object keys.
+//
+// Returns:
+// - IRowWriter: the NDJSON row writer.
+func NewNDJSONWriter(w io.Writer, fields []string) IRowWriter {
+	return &ndjsonWriter{encoder: json.NewEncoder(w), fields: fields}
+}
+
+// WriteRow implements IRowWriter.
+func (n *ndjsonWriter) WriteRow(values []any) error {
+	object := make(map[string]any, len(values))
+	for i, value := range values {
+		object[n.fields[i]] = value
+	}
+
+	return n.encoder.Encode(object)
+}
+
+// Flush implements IRowWriter.
+func (n *ndjsonWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package geoip
+
+import (
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+type ILocator interface {
+	// Locate returns the location of the given IP address.
+	Locate(ip string) (Location, error)
+
+	// Close releases the database.
+	Close() error
+}
+
+// Location is where an IP address is registered.
+//
+// Fields:
+// - Country: the ISO 3166-1 alpha-2 code of the country, e.g. "DE".
+// - Region: the ISO 3166-2 code of the subdivision, e.g. "DE-BY", or empty.
+type Location struct {
+	Country string
+	Region  string
+}
+
+// noopLocator locates nothing, used when no database is configured.
+type noopLocator struct{}
+
+// NewNoopLocator returns an ILocator that returns an empty location.
+func NewNoopLocator() ILocator {
+	return &noopLocator{}
+}
+
+// Locate implements ILocator.
+func (l *noopLocator) Locate(ip string) (Location, error) {
+	return Location{}, nil
+}
+
+// Close implements ILocator.
+func (l *noopLocator) Close() error {
+	return nil
+}
+
+// mmdbLocator looks IP addresses up in a MaxMind DB file.
+type mmdbLocator struct {
+	reader *maxminddb.Reader
+}
+
+// mmdbRecord holds the fields read from GeoIP2 and GeoLite2 Country and City databases.
+type mmdbRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+}
+
+// NewMMDBLocator returns an ILocator reading the given .mmdb file.
+//
+// The file is memory-mapped, so lookups do not touch the disk and the
+// database is never sent anywhere.
+//
+// Parameters:
+// - path: the path of a GeoIP2 or GeoLite2 Country or City database.
+//
+// Returns:
+// - ILocator: the locator.
+// - error: an error if the database cannot be opened.
+func NewMMDBLocator(path string) (ILocator, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mmdbLocator{reader: reader}, nil
+}
+
+// Locate implements ILocator.
+//
+// Addresses that cannot be parsed or are not in the database have an
+// empty location.
+func (l *mmdbLocator) Locate(ip string) (Location, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return Location{}, nil
+	}
+
+	var record mmdbRecord
+	if err := l.reader.Lookup(parsed, &record); err != nil {
+		return Location{}, err
+	}
+
+	location := Location{Country: record.Country.ISOCode}
+	if len(record.Subdivisions) > 0 && record.Subdivisions[0].ISOCode != "" {
+		location.Region = location.Country + "-" + record.Subdivisions[0].ISOCode
+	}
+
+	return location, nil
+}
+
+// Close implements ILocator.
+func (l *mmdbLocator) Close() error {
+	return l.reader.Close()
+}
@@ -0,0 +1,296 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MAX_DOCUMENT_SIZE bounds the size of a JWKS document.
+	MAX_DOCUMENT_SIZE = 1 << 20
+
+	// FETCH_TIMEOUT bounds the time allowed to download a JWKS document.
+	FETCH_TIMEOUT = 10 * time.Second
+
+	// MIN_REFRESH_INTERVAL bounds how often unknown key IDs trigger a
+	// refresh, so that forged tokens cannot flood the identity provider.
+	MIN_REFRESH_INTERVAL = time.Minute
+)
+
+var (
+	ErrKeyNotFound = errors.New("no key with this ID in the JWKS")
+	ErrNoKeys      = errors.New("JWKS contains no usable signing key")
+)
+
+// jsonWebKey is a key of a JWKS document, as defined by RFC 7517.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet holds the public keys of a JWKS document, read from a file or an
+// http(s) URL.
+//
+// The document is read again once it is older than the refresh interval,
+// and earlier when a token names a key it does not contain, which happens
+// when the identity provider rotates its keys. The last keys read are kept
+// while the document cannot be read.
+type KeySet struct {
+	source          string
+	refreshInterval time.Duration
+	client          *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	refreshedAt time.Time
+}
+
+// NewKeySet returns a KeySet reading the given source.
+//
+// Parameters:
+// - source: the path of a JWKS file, or the http(s) URL of a JWKS document.
+// - refreshInterval: how long the keys are used before they are read again.
+//
+// Returns:
+// - *KeySet: the key set, read on first use.
+func NewKeySet(source string, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		source:          source,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: FETCH_TIMEOUT},
+	}
+}
+
+// Key returns the public key with the given ID.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - kid: the ID of the key, empty if the token names none, in which case
+// the set must contain a single key.
+//
+// Returns:
+// - crypto.PublicKey: an *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+// - error: ErrKeyNotFound if the set has no such key, or an error if the
+// document could never be read.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil || time.Since(s.refreshedAt) >= s.refreshInterval {
+		if err := s.refresh(ctx); err != nil && s.keys == nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(s.refreshedAt) >= MIN_REFRESH_INTERVAL {
+		if err := s.refresh(ctx); err != nil {
+			return nil, err
+		}
+		if key, ok := s.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// Refresh reads the document again.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+//
+// Returns:
+// - error: an error if the document cannot be read or has no usable key,
+// the previous keys being kept.
+func (s *KeySet) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refresh(ctx)
+}
+
+// lookup returns the key with the given ID, or the only key for an empty ID.
+func (s *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh reads the document and replaces the keys, the caller holding the lock.
+func (s *KeySet) refresh(ctx context.Context) error {
+	// Failed reads count as refreshes too, so that an unavailable
+	// identity provider is not asked again on every request.
+	s.refreshedAt = time.Now()
+
+	data, err := s.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+	return nil
+}
+
+// read returns the document from the file or URL of the set.
+func (s *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.source, "https://") && !strings.HasPrefix(s.source, "http://") {
+		return os.ReadFile(s.source)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading JWKS: %s", response.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, MAX_DOCUMENT_SIZE))
+}
+
+// Parse returns the signing keys of a JWKS document, by key ID.
+//
+// Keys meant for encryption and keys of unsupported types are skipped.
+//
+// Parameters:
+// - data: the JSON document.
+//
+// Returns:
+// - map[string]crypto.PublicKey: the keys.
+// - error: an error if the document is malformed or has no usable key.
+func Parse(data []byte) (map[string]crypto.PublicKey, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: %w", jwk.Kid, err)
+		}
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	return keys, nil
+}
+
+// publicKey decodes the key, nil for an unsupported key type.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if n.BitLen() < 2048 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("weak or malformed RSA key")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("malformed Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+// decodeInt decodes a base64url big-endian integer.
+func decodeInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("malformed key parameter")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
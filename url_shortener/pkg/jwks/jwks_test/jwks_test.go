@@ -0,0 +1,87 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/pkg/jwks"
+)
+
+// ecKey returns the JWK of a new P-256 key.
+func ecKey(t *testing.T, kid, use string) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	x, y := make([]byte, 32), make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	return fmt.Sprintf(`{"kty":"EC","kid":%q,"use":%q,"crv":"P-256","x":%q,"y":%q}`, kid, use, encode(x), encode(y))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		kids     []string
+		err      bool
+	}{
+		{name: "signing keys", document: `{"keys":[` + ecKey(t, "a", "sig") + `,` + ecKey(t, "b", "") + `]}`, kids: []string{"a", "b"}},
+		{name: "encryption and unsupported keys skipped", document: `{"keys":[` + ecKey(t, "a", "sig") + `,` + ecKey(t, "enc", "enc") + `,{"kty":"oct","kid":"hmac","k":"c2VjcmV0"}]}`, kids: []string{"a"}},
+		{name: "weak RSA key", document: `{"keys":[{"kty":"RSA","kid":"weak","n":"AQAB","e":"AQAB"}]}`, err: true},
+		{name: "point off the curve", document: `{"keys":[{"kty":"EC","kid":"off","crv":"P-256","x":"AQ","y":"AQ"}]}`, err: true},
+		{name: "no usable key", document: `{"keys":[{"kty":"oct","kid":"hmac","k":"c2VjcmV0"}]}`, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := jwks.Parse([]byte(tt.document))
+			if (err != nil) != tt.err {
+				t.Fatalf("Parse() error = %v, want error %v", err, tt.err)
+			}
+			if len(keys) != len(tt.kids) {
+				t.Fatalf("Parse() = %d keys, want %v", len(keys), tt.kids)
+			}
+			for _, kid := range tt.kids {
+				if _, ok := keys[kid]; !ok {
+					t.Errorf("Parse() misses key %q", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestKeySetKeepsKeysWhenSourceFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(`{"keys":[`+ecKey(t, "a", "sig")+`]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := jwks.NewKeySet(path, 0)
+	if _, err := keys.Key(context.Background(), ""); err != nil {
+		t.Fatalf("Key() error = %v, want the only key", err)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Key(context.Background(), "a"); err != nil {
+		t.Fatalf("Key() error = %v, want the previous key", err)
+	}
+	if _, err := keys.Key(context.Background(), "b"); !errors.Is(err, jwks.ErrKeyNotFound) {
+		t.Fatalf("Key() error = %v, want ErrKeyNotFound", err)
+	}
+}
@@ -0,0 +1,207 @@
+package pagetitle
+
+import (
+	"context"
+	"errors"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// MAX_BODY_SIZE bounds the part of a page searched for its title.
+	MAX_BODY_SIZE = 256 << 10
+
+	// MAX_TITLE_LENGTH bounds the length of a returned title.
+	MAX_TITLE_LENGTH = 200
+
+	// MAX_REDIRECTS bounds the redirects followed to reach a page.
+	MAX_REDIRECTS = 3
+)
+
+var (
+	ErrForbiddenAddress = errors.New("address is not public")
+	ErrTooManyRedirects = errors.New("too many redirects")
+	ErrNotHTML          = errors.New("page is not HTML")
+)
+
+// specialPurpose lists the special-purpose ranges that are global unicast
+// for the net package but must not be reached: shared, benchmarking,
+// documentation, reserved and broadcast IPv4 addresses, and the IPv6
+// ranges embedding IPv4 addresses or reserved for documentation, ULAs,
+// link-local addresses and discarding traffic. IPv4-mapped IPv6 addresses,
+// ::ffff:0:0/96, are checked as the IPv4 addresses they map.
+var specialPurpose = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"192.88.99.0/24",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"240.0.0.0/4",
+	"255.255.255.255/32",
+	"::/96",
+	"64:ff9b::/96",
+	"64:ff9b:1::/48",
+	"100::/64",
+	"2001::/32",
+	"2001:db8::/32",
+	"2002::/16",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// titlePattern matches the title element of an HTML page.
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// Fetcher reads the titles of web pages.
+//
+// Pages are only fetched from public addresses, checked after name
+// resolution, so that links cannot be used to probe internal services.
+type Fetcher struct {
+	client *http.Client
+}
+
+// NewFetcher returns a Fetcher giving up on pages after the timeout.
+//
+// Parameters:
+// - timeout: the time allowed to fetch a page, redirects included.
+//
+// Returns:
+// - *Fetcher: the fetcher.
+func NewFetcher(timeout time.Duration) *Fetcher {
+	dialer := &net.Dialer{Timeout: timeout, Control: ControlPublic}
+
+	return &Fetcher{client: &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			DisableKeepAlives:   true,
+		},
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) > MAX_REDIRECTS {
+				return ErrTooManyRedirects
+			}
+			return nil
+		},
+	}}
+}
+
+// Fetch returns the title of the page at the given URL.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - url: the http or https URL of the page.
+//
+// Returns:
+// - string: the title with entities decoded and whitespace collapsed, empty
+// if the page has none.
+// - error: an error if the page could not be fetched or is not HTML.
+func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Accept", "text/html")
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), "text/html") {
+		return "", ErrNotHTML
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, MAX_BODY_SIZE))
+	if err != nil {
+		return "", err
+	}
+
+	match := titlePattern.FindSubmatch(body)
+	if match == nil {
+		return "", nil
+	}
+
+	title := strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " ")
+	if runes := []rune(title); len(runes) > MAX_TITLE_LENGTH {
+		title = string(runes[:MAX_TITLE_LENGTH]) + "…"
+	}
+
+	return title, nil
+}
+
+// ControlPublic refuses connections to loopback, private, link-local and
+// other non-public addresses. It is meant as the Control of a net.Dialer,
+// so that the address is checked after name resolution.
+//
+// Parameters:
+// - address: the resolved "host:port" being dialed.
+//
+// Returns:
+// - error: ErrForbiddenAddress if the address is not public.
+func ControlPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !IsPublic(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// IsPublic reports whether an IP address is a public unicast address,
+// excluding loopback, private, link-local, multicast and unspecified ones
+// as well as the special-purpose ranges, e.g. carrier-grade NAT, NAT64
+// and 6to4 addresses.
+//
+// Parameters:
+// - ip: the address.
+//
+// Returns:
+// - bool: true if the address is public.
+func IsPublic(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	for _, network := range specialPurpose {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mustParseCIDRs parses network ranges written in CIDR notation.
+//
+// Parameters:
+// - cidrs: the ranges.
+//
+// Returns:
+// - []*net.IPNet: the parsed ranges. It panics if a range is malformed.
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+
+	return networks
+}
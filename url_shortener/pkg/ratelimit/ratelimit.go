@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KEY_PREFIX starts the Redis keys of the limiter.
+const KEY_PREFIX = "ratelimit:"
+
+var ErrInvalidLimit = errors.New(`limit must be "<count>/<period>", e.g. "100/1s" or "600/1m", with a positive count and period`)
+
+// gcra is the generic cell rate algorithm run atomically by Redis.
+//
+// The key holds the theoretical arrival time (TAT) of the next request, in
+// microseconds of the Redis clock, so that every instance shares one clock.
+// A request is allowed when the TAT it would move to is at most the period
+// ahead of now, which allows up to count requests at once and refills one
+// request every period/count.
+//
+// KEYS[1]: the key of the client.
+// ARGV[1]: the emission interval, period/count, in microseconds.
+// ARGV[2]: the period, in microseconds.
+//
+// Returns {allowed, remaining, reset after, retry after}, durations in microseconds.
+var gcra = redis.NewScript(`
+redis.replicate_commands()
+
+local interval = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call("GET", KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - period
+if allow_at > now then
+	return {0, 0, tat - now, allow_at - now}
+end
+
+redis.call("SET", KEYS[1], new_tat, "PX", math.ceil((new_tat - now) / 1000))
+
+return {1, math.floor((period - (new_tat - now)) / interval), new_tat - now, 0}
+`)
+
+// Limit is the number of requests allowed in a period.
+//
+// Fields:
+// - Count: the number of requests, also allowed at once.
+// - Period: the period over which the requests are spread.
+type Limit struct {
+	Count  int
+	Period time.Duration
+}
+
+// ParseLimit parses a limit written "<count>/<period>".
+//
+// The period is a Go duration, its number being optional, so that "100/s",
+// "100/1s" and "6000/1m" are accepted.
+//
+// Parameters:
+// - value: the limit.
+//
+// Returns:
+// - Limit: the limit.
+// - error: ErrInvalidLimit if the limit is malformed.
+func ParseLimit(value string) (Limit, error) {
+	count, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n <= 0 {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	return Limit{Count: n, Period: d}, nil
+}
+
+// String returns the limit written "<count>/<period>".
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Count, l.Period)
+}
+
+// Result is the outcome of a request against a limit.
+//
+// Fields:
+// - Allowed: true if the request is within the limit.
+// - Limit: the limit.
+// - Remaining: the number of requests still allowed at once.
+// - ResetAfter: the time until the full count is allowed again.
+// - RetryAfter: the time until the next request is allowed, zero if it is.
+type Result struct {
+	Allowed    bool
+	Limit      Limit
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// Limiter limits the requests of clients, sharing its state in Redis
+// between every instance of the service.
+type Limiter struct {
+	client redis.Scripter
+}
+
+// NewLimiter returns a Limiter storing its state with the given client.
+//
+// Parameters:
+// - client: the Redis client.
+//
+// Returns:
+// - *Limiter: the limiter.
+func NewLimiter(client redis.Scripter) *Limiter {
+	return &Limiter{client: client}
+}
+
+// Allow counts a request of a client against a limit.
+//
+// Parameters:
+// - ctx: the context.Context for the operation.
+// - key: the client and the policy limiting it, e.g. "shorten:ip:203.0.113.7".
+// - limit: the limit.
+//
+// Returns:
+// - Result: whether the request is allowed, and the state of the limit.
+// - error: an error if Redis cannot be reached.
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	period := limit.Period.Microseconds()
+	interval := max(period/int64(limit.Count), 1)
+
+	values, err := gcra.Run(ctx, l.client, []string{KEY_PREFIX + key}, interval, period).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 4 {
+		return Result{}, fmt.Errorf("rate limit script returned %d values", len(values))
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Microsecond,
+		RetryAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/flew1x/url_shortener_ms/pkg/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ratelimit.Limit
+		err   error
+	}{
+		{value: "100/1s", want: ratelimit.Limit{Count: 100, Period: time.Second}},
+		{value: "100/s", want: ratelimit.Limit{Count: 100, Period: time.Second}},
+		{value: " 600/m ", want: ratelimit.Limit{Count: 600, Period: time.Minute}},
+		{value: "5000/1h30m", want: ratelimit.Limit{Count: 5000, Period: 90 * time.Minute}},
+		{value: "100", err: ratelimit.ErrInvalidLimit},
+		{value: "0/1s", err: ratelimit.ErrInvalidLimit},
+		{value: "-1/1s", err: ratelimit.ErrInvalidLimit},
+		{value: "10/0s", err: ratelimit.ErrInvalidLimit},
+		{value: "10/", err: ratelimit.ErrInvalidLimit},
+		{value: "10/fortnight", err: ratelimit.ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ratelimit.ParseLimit(tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseLimit() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	server := miniredis.RunT(t)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	server.SetTime(now)
+
+	limiter := ratelimit.NewLimiter(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	limit := ratelimit.Limit{Count: 2, Period: time.Second}
+	ctx := context.Background()
+
+	allow := func(key string) ratelimit.Result {
+		t.Helper()
+		result, err := limiter.Allow(ctx, key, limit)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		return result
+	}
+
+	steps := []struct {
+		name    string
+		elapsed time.Duration
+		want    ratelimit.Result
+	}{
+		{name: "first", want: ratelimit.Result{Allowed: true, Limit: limit, Remaining: 1, ResetAfter: 500 * time.Millisecond}},
+		{name: "burst used up", want: ratelimit.Result{Allowed: true, Limit: limit, Remaining: 0, ResetAfter: time.Second}},
+		{name: "denied", want: ratelimit.Result{Allowed: false, Limit: limit, Remaining: 0, ResetAfter: time.Second, RetryAfter: 500 * time.Millisecond}},
+		{name: "refilled", elapsed: 500 * time.Millisecond, want: ratelimit.Result{Allowed: true, Limit: limit, Remaining: 0, ResetAfter: time.Second}},
+		{name: "denied again", elapsed: 500 * time.Millisecond, want: ratelimit.Result{Allowed: false, Limit: limit, Remaining: 0, ResetAfter: time.Second, RetryAfter: 500 * time.Millisecond}},
+		{name: "reset", elapsed: 1500 * time.Millisecond, want: ratelimit.Result{Allowed: true, Limit: limit, Remaining: 1, ResetAfter: 500 * time.Millisecond}},
+	}
+
+	for _, step := range steps {
+		server.SetTime(now.Add(step.elapsed))
+		if got := allow("api:ip:203.0.113.7"); got != step.want {
+			t.Errorf("%s: Allow() = %+v, want %+v", step.name, got, step.want)
+		}
+	}
+
+	if got := allow("api:ip:198.51.100.1"); !got.Allowed || got.Remaining != 1 {
+		t.Errorf("Allow() of another client = %+v, want its own burst", got)
+	}
+}
+
+func TestLimiterUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	limiter := ratelimit.NewLimiter(redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1}))
+	server.Close()
+
+	if _, err := limiter.Allow(context.Background(), "api:ip:203.0.113.7", ratelimit.Limit{Count: 2, Period: time.Second}); err == nil {
+		t.Error("Allow() without Redis error = nil, want an error")
+	}
+}
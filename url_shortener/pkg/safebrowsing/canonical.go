@@ -0,0 +1,360 @@
+package safebrowsing
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MAX_HOST_SUFFIXES bounds the hosts looked up for a URL, its own and
+	// those formed from its last components.
+	MAX_HOST_SUFFIXES = 5
+
+	// MAX_PATH_PREFIXES bounds the paths formed from the first components
+	// of a URL looked up besides its own path with and without query.
+	MAX_PATH_PREFIXES = 4
+
+	// MAX_UNESCAPE_ROUNDS bounds the percent-unescaping of a URL.
+	MAX_UNESCAPE_ROUNDS = 1024
+)
+
+var ErrInvalidURL = errors.New("URL cannot be canonicalized")
+
+// URL is a canonicalized URL, split as lookups need it.
+//
+// Fields:
+// - Scheme: the lowercase scheme.
+// - Host: the lowercase host, without user info and port.
+// - Path: the path, starting with "/".
+// - Query: the query, without "?".
+// - HasQuery: whether the URL has a query, possibly empty.
+type URL struct {
+	Scheme   string
+	Host     string
+	Path     string
+	Query    string
+	HasQuery bool
+}
+
+// String returns the canonical URL.
+func (u URL) String() string {
+	s := u.Scheme + "://" + u.Host + u.Path
+	if u.HasQuery {
+		s += "?" + u.Query
+	}
+
+	return s
+}
+
+// Canonicalize canonicalizes a URL as Safe Browsing does before hashing it.
+//
+// Tabs and line breaks and the fragment are removed, the URL is
+// percent-unescaped until it no longer changes, the host is lowercased
+// with its dots normalized and IPv4 addresses written as four decimal
+// numbers, "." and ".." are resolved in the path, and the characters
+// below 33 or above 126, "#" and "%" are escaped again.
+//
+// Parameters:
+// - raw: the URL, "http://" being assumed without scheme.
+//
+// Returns:
+// - URL: the canonical URL.
+// - error: ErrInvalidURL if the URL has no host.
+func Canonicalize(raw string) (URL, error) {
+	// Bytes rather than runes, the URL not being necessarily valid UTF-8.
+	raw = strings.NewReplacer("\t", "", "\r", "", "\n", "").Replace(raw)
+	raw = strings.TrimSpace(raw)
+
+	raw, _, _ = strings.Cut(raw, "#")
+	raw = unescape(raw)
+
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok {
+		scheme, rest = "http", raw
+	}
+
+	end := strings.IndexAny(rest, "/?")
+	if end < 0 {
+		end = len(rest)
+	}
+	authority, rest := rest[:end], rest[end:]
+
+	host := canonicalHost(authority)
+	if host == "" {
+		return URL{}, ErrInvalidURL
+	}
+
+	path, query, hasQuery := strings.Cut(rest, "?")
+
+	return URL{
+		Scheme:   lower(scheme),
+		Host:     escape(host),
+		Path:     escape(canonicalPath(path)),
+		Query:    escape(query),
+		HasQuery: hasQuery,
+	}, nil
+}
+
+// Expressions returns the host suffix and path prefix combinations of a
+// URL that are hashed to look it up, e.g. for "http://a.b.c/1/2.html?p=1":
+// "a.b.c/1/2.html?p=1", "a.b.c/1/2.html", "a.b.c/", "a.b.c/1/", and the
+// same paths on "b.c".
+//
+// Parameters:
+// - u: the canonical URL.
+//
+// Returns:
+// - []string: the expressions, without duplicates.
+func (u URL) Expressions() []string {
+	var expressions []string
+	seen := map[string]bool{}
+
+	for _, host := range hostSuffixes(u.Host) {
+		for _, path := range u.pathPrefixes() {
+			if expression := host + path; !seen[expression] {
+				seen[expression] = true
+				expressions = append(expressions, expression)
+			}
+		}
+	}
+
+	return expressions
+}
+
+// hostSuffixes returns the host and, unless it is an IP address, the
+// hosts formed from its last components, from the longest to the shortest
+// of two components.
+func hostSuffixes(host string) []string {
+	suffixes := []string{host}
+	if isIPv4(host) || strings.HasPrefix(host, "[") {
+		return suffixes
+	}
+
+	components := strings.Split(host, ".")
+	start := max(len(components)-MAX_HOST_SUFFIXES, 1)
+	for i := start; i <= len(components)-2 && len(suffixes) < MAX_HOST_SUFFIXES; i++ {
+		suffixes = append(suffixes, strings.Join(components[i:], "."))
+	}
+
+	return suffixes
+}
+
+// pathPrefixes returns the path with and without query, and the paths
+// formed from its first components, starting with "/".
+func (u URL) pathPrefixes() []string {
+	var prefixes []string
+	if u.HasQuery {
+		prefixes = append(prefixes, u.Path+"?"+u.Query)
+	}
+	prefixes = append(prefixes, u.Path)
+
+	prefix := "/"
+	components := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < MAX_PATH_PREFIXES; i++ {
+		if prefix != u.Path {
+			prefixes = append(prefixes, prefix)
+		}
+		if i >= len(components)-1 || components[i] == "" {
+			break
+		}
+		prefix += components[i] + "/"
+	}
+
+	return prefixes
+}
+
+// canonicalHost returns the lowercase host of an authority, without user
+// info, port, leading, trailing and consecutive dots, IPv4 addresses being
+// written as four decimal numbers.
+func canonicalHost(authority string) string {
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		authority = authority[i+1:]
+	}
+	if strings.HasPrefix(authority, "[") {
+		if end := strings.Index(authority, "]"); end >= 0 {
+			return lower(authority[:end+1])
+		}
+	}
+	if i := strings.LastIndex(authority, ":"); i >= 0 {
+		authority = authority[:i]
+	}
+
+	var components []string
+	for _, component := range strings.Split(authority, ".") {
+		if component != "" {
+			components = append(components, component)
+		}
+	}
+	host := lower(strings.Join(components, "."))
+
+	if ip, ok := parseIPv4(host); ok {
+		return ip
+	}
+
+	return host
+}
+
+// parseIPv4 parses a host written as an IPv4 address of one to four
+// decimal, octal or hexadecimal numbers, the last filling the remaining bytes.
+//
+// Returns:
+// - string: the address as four decimal numbers.
+// - bool: false if the host is not an IPv4 address.
+func parseIPv4(host string) (string, bool) {
+	components := strings.Split(host, ".")
+	if len(components) > 4 {
+		return "", false
+	}
+
+	var value uint64
+	for i, component := range components {
+		n, ok := parseIPv4Component(component)
+		if !ok {
+			return "", false
+		}
+
+		if i < len(components)-1 {
+			if n > 255 {
+				return "", false
+			}
+			value = value<<8 | n
+			continue
+		}
+
+		remaining := 4 - i
+		if n >= 1<<(8*remaining) {
+			return "", false
+		}
+		value = value<<(8*remaining) | n
+	}
+
+	return strconv.FormatUint(value>>24&0xff, 10) + "." +
+		strconv.FormatUint(value>>16&0xff, 10) + "." +
+		strconv.FormatUint(value>>8&0xff, 10) + "." +
+		strconv.FormatUint(value&0xff, 10), true
+}
+
+// parseIPv4Component parses a number of an IPv4 address, hexadecimal
+// after "0x", octal after "0", decimal otherwise.
+func parseIPv4Component(component string) (uint64, bool) {
+	base := 10
+	switch {
+	case strings.HasPrefix(lower(component), "0x"):
+		base, component = 16, component[2:]
+	case len(component) > 1 && component[0] == '0':
+		base, component = 8, component[1:]
+	}
+
+	n, err := strconv.ParseUint(component, base, 32)
+	return n, err == nil
+}
+
+// isIPv4 reports whether a canonical host is an IPv4 address.
+func isIPv4(host string) bool {
+	ip, ok := parseIPv4(host)
+	return ok && ip == host
+}
+
+// canonicalPath resolves "." and ".." and removes consecutive slashes.
+func canonicalPath(path string) string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "", ".":
+		case "..":
+			if len(segments) > 0 {
+				segments = segments[:len(segments)-1]
+			}
+		default:
+			segments = append(segments, segment)
+		}
+	}
+
+	canonical := "/" + strings.Join(segments, "/")
+	if len(segments) > 0 && (strings.HasSuffix(path, "/") || strings.HasSuffix(path, "/.") || strings.HasSuffix(path, "/..")) {
+		canonical += "/"
+	}
+
+	return canonical
+}
+
+// unescape percent-unescapes a string until it no longer changes, leaving
+// "%" not followed by two hexadecimal digits as is.
+func unescape(s string) string {
+	for i := 0; i < MAX_UNESCAPE_ROUNDS; i++ {
+		unescaped := unescapeOnce(s)
+		if unescaped == s {
+			break
+		}
+		s = unescaped
+	}
+
+	return s
+}
+
+// unescapeOnce percent-unescapes a string once.
+func unescapeOnce(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+			b.WriteByte(unhex(s[i+1])<<4 | unhex(s[i+2]))
+			i += 2
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// escape percent-escapes the characters below 33 or above 126, "#" and "%".
+func escape(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || c == '#' || c == '%' {
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0xf])
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// lower lowercases the ASCII letters of a string, leaving the other bytes
+// as they are even if they are not valid UTF-8.
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+
+	return string(b)
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
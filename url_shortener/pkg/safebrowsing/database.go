@@ -0,0 +1,481 @@
+package safebrowsing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	RESPONSE_FULL_UPDATE    = "FULL_UPDATE"
+	RESPONSE_PARTIAL_UPDATE = "PARTIAL_UPDATE"
+
+	COMPRESSION_RAW = "RAW"
+
+	// THREAT_ENTRY_URL is the type of the lists of URL hash prefixes, the
+	// only lists looked up.
+	THREAT_ENTRY_URL = "URL"
+
+	MIN_PREFIX_SIZE = 4
+	MAX_PREFIX_SIZE = sha256.Size
+)
+
+var (
+	ErrThreat         = errors.New("destination is listed as a threat")
+	ErrInvalidUpdate  = errors.New("invalid threat list update")
+	ErrChecksum       = errors.New("threat list checksum mismatch")
+	ErrUnknownList    = errors.New("partial update of an unknown threat list")
+	ErrRiceCompressed = errors.New("Rice-compressed threat list updates are not supported, request RAW compression")
+)
+
+// Update is a threat list update, the response of the Safe Browsing v4
+// threatListUpdates:fetch method.
+type Update struct {
+	ListUpdateResponses []ListUpdate `json:"listUpdateResponses"`
+}
+
+// ListUpdate is the update of a threat list.
+//
+// Fields:
+// - ThreatType: the threat of the list, e.g. "MALWARE" or "SOCIAL_ENGINEERING".
+// - ThreatEntryType: the type of the entries, only "URL" lists being looked up.
+// - PlatformType: the platform of the list, e.g. "ANY_PLATFORM".
+// - ResponseType: "FULL_UPDATE" to replace the list, "PARTIAL_UPDATE" to change it.
+// - Additions: the hash prefixes added to the list.
+// - Removals: the indices of the hash prefixes removed from the sorted list.
+// - NewClientState: the state of the list after the update.
+// - Checksum: the SHA-256 hash of the sorted hash prefixes after the update.
+type ListUpdate struct {
+	ThreatType      string        `json:"threatType"`
+	ThreatEntryType string        `json:"threatEntryType"`
+	PlatformType    string        `json:"platformType"`
+	ResponseType    string        `json:"responseType"`
+	Additions       []ThreatEntry `json:"additions,omitempty"`
+	Removals        []ThreatEntry `json:"removals,omitempty"`
+	NewClientState  string        `json:"newClientState,omitempty"`
+	Checksum        *Checksum     `json:"checksum,omitempty"`
+}
+
+// ThreatEntry is a set of additions or removals of a list update.
+type ThreatEntry struct {
+	CompressionType string      `json:"compressionType,omitempty"`
+	RawHashes       *RawHashes  `json:"rawHashes,omitempty"`
+	RawIndices      *RawIndices `json:"rawIndices,omitempty"`
+}
+
+// RawHashes are hash prefixes of the same size, concatenated.
+type RawHashes struct {
+	PrefixSize int    `json:"prefixSize"`
+	RawHashes  []byte `json:"rawHashes"`
+}
+
+// RawIndices are indices into a sorted list of hash prefixes.
+type RawIndices struct {
+	Indices []int `json:"indices"`
+}
+
+// Checksum is the SHA-256 hash of the sorted hash prefixes of a list.
+type Checksum struct {
+	SHA256 []byte `json:"sha256"`
+}
+
+// Threat is returned for a destination matching a threat list.
+//
+// Fields:
+// - Destination: the destination.
+// - ThreatType: the threat of the list it matches, e.g. "MALWARE".
+// - Confirmed: true if the full hash of the destination is listed, false if
+// only a hash prefix matched, which safe URLs may share.
+type Threat struct {
+	Destination string
+	ThreatType  string
+	Confirmed   bool
+}
+
+// Error implements error.
+func (t *Threat) Error() string {
+	return fmt.Sprintf("destination %s is listed as %s", t.Destination, Describe(t.ThreatType))
+}
+
+// Unwrap returns ErrThreat.
+func (t *Threat) Unwrap() error {
+	return ErrThreat
+}
+
+// Describe names a threat type for people, e.g. "phishing" for
+// "SOCIAL_ENGINEERING".
+func Describe(threatType string) string {
+	switch threatType {
+	case "SOCIAL_ENGINEERING":
+		return "phishing"
+	case "POTENTIALLY_HARMFUL_APPLICATION":
+		return "harmful application"
+	default:
+		return strings.ReplaceAll(strings.ToLower(threatType), "_", " ")
+	}
+}
+
+// list is a threat list, its hash prefixes kept sorted as updates index them.
+type list struct {
+	ListUpdate
+	prefixes []string
+	sizes    map[int]map[string]bool
+}
+
+// key identifies a threat list.
+func (u ListUpdate) key() string {
+	return u.ThreatType + "/" + u.PlatformType + "/" + u.ThreatEntryType
+}
+
+// Database is a local copy of hash prefix threat lists. The zero Database
+// is empty and matches nothing.
+type Database struct {
+	mu    sync.RWMutex
+	lists map[string]*list
+
+	// applied is the modification time of the update file last applied.
+	applied time.Time
+}
+
+// NewDatabase returns an empty Database.
+func NewDatabase() *Database {
+	return &Database{lists: map[string]*list{}}
+}
+
+// Apply applies an update, all of its lists or none.
+//
+// Full updates replace their list and partial updates change it, first
+// removing the prefixes at the given indices of the sorted list, then
+// adding new ones. Updates to the state a list already has are skipped, so
+// that applying the same update twice is harmless.
+//
+// Parameters:
+// - data: the update, in JSON.
+//
+// Returns:
+// - error: ErrInvalidUpdate, ErrChecksum, ErrUnknownList or ErrRiceCompressed if the update cannot be applied.
+func (d *Database) Apply(data []byte) error {
+	_, err := d.apply(data)
+	return err
+}
+
+// apply applies an update like Apply.
+//
+// Returns:
+// - bool: true if a list changed.
+// - error: an error if the update cannot be applied.
+func (d *Database) apply(data []byte) (bool, error) {
+	var update Update
+	if err := json.Unmarshal(data, &update); err != nil {
+		return false, fmt.Errorf("%w: %s", ErrInvalidUpdate, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	lists := make(map[string]*list, len(d.lists))
+	for key, current := range d.lists {
+		lists[key] = current
+	}
+
+	changed := false
+	for _, listUpdate := range update.ListUpdateResponses {
+		current := lists[listUpdate.key()]
+		if current != nil && listUpdate.NewClientState != "" && current.NewClientState == listUpdate.NewClientState {
+			continue
+		}
+
+		updated, err := applyList(current, listUpdate)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", listUpdate.key(), err)
+		}
+		lists[listUpdate.key()] = updated
+		changed = true
+	}
+
+	d.lists = lists
+
+	return changed, nil
+}
+
+// applyList returns a list changed by an update, the current list being
+// left untouched.
+func applyList(current *list, update ListUpdate) (*list, error) {
+	var prefixes []string
+	switch update.ResponseType {
+	case RESPONSE_FULL_UPDATE:
+	case RESPONSE_PARTIAL_UPDATE:
+		if current == nil {
+			return nil, ErrUnknownList
+		}
+		prefixes = current.prefixes
+	default:
+		return nil, fmt.Errorf("%w: unknown response type %q", ErrInvalidUpdate, update.ResponseType)
+	}
+
+	removed := map[int]bool{}
+	for _, removal := range update.Removals {
+		if removal.CompressionType != "" && removal.CompressionType != COMPRESSION_RAW {
+			return nil, ErrRiceCompressed
+		}
+		if removal.RawIndices == nil {
+			continue
+		}
+		for _, index := range removal.RawIndices.Indices {
+			if index < 0 || index >= len(prefixes) {
+				return nil, fmt.Errorf("%w: removal index %d out of range", ErrInvalidUpdate, index)
+			}
+			removed[index] = true
+		}
+	}
+
+	kept := make([]string, 0, len(prefixes))
+	for i, prefix := range prefixes {
+		if !removed[i] {
+			kept = append(kept, prefix)
+		}
+	}
+
+	for _, addition := range update.Additions {
+		if addition.CompressionType != "" && addition.CompressionType != COMPRESSION_RAW {
+			return nil, ErrRiceCompressed
+		}
+		if addition.RawHashes == nil {
+			continue
+		}
+
+		size, hashes := addition.RawHashes.PrefixSize, addition.RawHashes.RawHashes
+		if size < MIN_PREFIX_SIZE || size > MAX_PREFIX_SIZE || len(hashes)%size != 0 {
+			return nil, fmt.Errorf("%w: %d bytes of %d-byte prefixes", ErrInvalidUpdate, len(hashes), size)
+		}
+		for i := 0; i < len(hashes); i += size {
+			kept = append(kept, string(hashes[i:i+size]))
+		}
+	}
+
+	sort.Strings(kept)
+
+	if update.Checksum != nil && len(update.Checksum.SHA256) > 0 {
+		if sum := sha256.Sum256([]byte(strings.Join(kept, ""))); !bytes.Equal(sum[:], update.Checksum.SHA256) {
+			return nil, ErrChecksum
+		}
+	}
+
+	updated := &list{ListUpdate: update, prefixes: kept, sizes: map[int]map[string]bool{}}
+	updated.Additions, updated.Removals, updated.Checksum = nil, nil, nil
+	for _, prefix := range kept {
+		if updated.sizes[len(prefix)] == nil {
+			updated.sizes[len(prefix)] = map[string]bool{}
+		}
+		updated.sizes[len(prefix)][prefix] = true
+	}
+
+	return updated, nil
+}
+
+// Lookup returns the threat lists matching a URL.
+//
+// A hash prefix is enough for a match: without asking Safe Browsing for
+// the full hashes, lists of short prefixes may match safe URLs. A list
+// holding the full hash of the URL is preferred to one holding a prefix.
+//
+// Parameters:
+// - raw: the URL.
+//
+// Returns:
+// - string: the threat type of the first list matching the URL.
+// - bool: true if the list holds the full hash of the URL.
+// - bool: false if no list matches.
+func (d *Database) Lookup(raw string) (string, bool, bool) {
+	canonical, err := Canonicalize(raw)
+	if err != nil {
+		return "", false, false
+	}
+
+	var hashes [][sha256.Size]byte
+	for _, expression := range canonical.Expressions() {
+		hashes = append(hashes, sha256.Sum256([]byte(expression)))
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	keys := make([]string, 0, len(d.lists))
+	for key := range d.lists {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	threatType, listed := "", false
+	for _, key := range keys {
+		list := d.lists[key]
+		if list.ThreatEntryType != THREAT_ENTRY_URL {
+			continue
+		}
+		for _, hash := range hashes {
+			for size, prefixes := range list.sizes {
+				if !prefixes[string(hash[:size])] {
+					continue
+				}
+				if size == sha256.Size {
+					return list.ThreatType, true, true
+				}
+				if !listed {
+					threatType, listed = list.ThreatType, true
+				}
+			}
+		}
+	}
+
+	return threatType, false, listed
+}
+
+// Check decides whether a destination is listed as a threat. Only http
+// and https destinations are looked up.
+//
+// Parameters:
+// - destination: the URL or URI.
+//
+// Returns:
+// - error: a Threat wrapping ErrThreat if the destination is listed.
+func (d *Database) Check(destination string) error {
+	scheme, _, ok := strings.Cut(destination, "://")
+	if !ok || !strings.EqualFold(scheme, "http") && !strings.EqualFold(scheme, "https") {
+		return nil
+	}
+
+	if threatType, confirmed, listed := d.Lookup(destination); listed {
+		return &Threat{Destination: destination, ThreatType: threatType, Confirmed: confirmed}
+	}
+
+	return nil
+}
+
+// Len returns the number of hash prefixes of the database.
+func (d *Database) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	n := 0
+	for _, list := range d.lists {
+		n += len(list.prefixes)
+	}
+
+	return n
+}
+
+// ApplyFile applies the update of a file, unless it has not changed since
+// it was last applied.
+//
+// Parameters:
+// - path: the path of the update file.
+//
+// Returns:
+// - bool: true if the update changed a list.
+// - error: an error if the file cannot be read, or the update cannot be applied.
+func (d *Database) ApplyFile(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.RLock()
+	applied := d.applied
+	d.mu.RUnlock()
+	if info.ModTime().Equal(applied) {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	changed, err := d.apply(data)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	d.applied = info.ModTime()
+	d.mu.Unlock()
+
+	return changed, nil
+}
+
+// Load reads a database saved by Save.
+//
+// Parameters:
+// - path: the path of the saved database.
+//
+// Returns:
+// - error: an error if the file cannot be read or is invalid, os.ErrNotExist if it does not exist.
+func (d *Database) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return d.Apply(data)
+}
+
+// Save writes the database as a full update of every list, replacing the
+// file at once so that a crash never leaves it half written.
+//
+// Parameters:
+// - path: the path of the saved database.
+//
+// Returns:
+// - error: an error if the file cannot be written.
+func (d *Database) Save(path string) error {
+	d.mu.RLock()
+	update := Update{ListUpdateResponses: []ListUpdate{}}
+	for _, list := range d.lists {
+		listUpdate := list.ListUpdate
+		listUpdate.ResponseType = RESPONSE_FULL_UPDATE
+
+		bySize := map[int][]byte{}
+		for _, prefix := range list.prefixes {
+			bySize[len(prefix)] = append(bySize[len(prefix)], prefix...)
+		}
+		for size, hashes := range bySize {
+			listUpdate.Additions = append(listUpdate.Additions, ThreatEntry{
+				CompressionType: COMPRESSION_RAW,
+				RawHashes:       &RawHashes{PrefixSize: size, RawHashes: hashes},
+			})
+		}
+
+		sum := sha256.Sum256([]byte(strings.Join(list.prefixes, "")))
+		listUpdate.Checksum = &Checksum{SHA256: sum[:]}
+		update.ListUpdateResponses = append(update.ListUpdateResponses, listUpdate)
+	}
+	d.mu.RUnlock()
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
@@ -0,0 +1,113 @@
+package urlpolicy
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// File is a policy read from a file, reloaded when the file changes.
+type File struct {
+	path string
+
+	mu     sync.RWMutex
+	policy *Policy
+}
+
+// NewFile reads a policy file.
+//
+// Parameters:
+// - path: the path of the YAML file.
+//
+// Returns:
+// - *File: the policy, to be kept up to date with Watch.
+// - error: an error if the file cannot be read, or ErrInvalidPolicy.
+func NewFile(path string) (*File, error) {
+	policy, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{path: path, policy: policy}, nil
+}
+
+// Check decides whether a destination is allowed by the current policy.
+//
+// Parameters:
+// - destination: the URL or URI.
+//
+// Returns:
+// - error: a Violation wrapping ErrBlocked or ErrNotAllowed if the destination is refused.
+func (f *File) Check(destination string) error {
+	f.mu.RLock()
+	policy := f.policy
+	f.mu.RUnlock()
+
+	return policy.Check(destination)
+}
+
+// Reload reads the file again.
+//
+// Returns:
+// - error: an error if the file cannot be read or is invalid, the previous
+// policy being kept.
+func (f *File) Reload() error {
+	policy, err := Load(f.path)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.policy = policy
+	f.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the policy whenever the file changes, until the context
+// is done.
+//
+// The directory of the file is watched rather than the file, so that
+// files replaced by a rename, as editors and Kubernetes config maps do,
+// keep being followed.
+//
+// Parameters:
+// - ctx: the context.Context stopping the watch.
+// - reloaded: called after every reload with its error, nil on success.
+//
+// Returns:
+// - error: an error if the directory cannot be watched.
+func (f *File) Watch(ctx context.Context, reloaded func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(f.path)); err != nil {
+		return err
+	}
+
+	name := filepath.Clean(f.path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watcher.Errors:
+			reloaded(err)
+		case event := <-watcher.Events:
+			// A file replaced by a rename shows as created, and replacing a
+			// symlinked file only creates entries next to it. Until then a
+			// removed file keeps its policy.
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if filepath.Clean(event.Name) != name && event.Op&fsnotify.Create == 0 {
+				continue
+			}
+			reloaded(f.Reload())
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package urlpolicy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WILDCARD_PREFIX starts the domains matching every subdomain of a domain.
+const WILDCARD_PREFIX = "*."
+
+var (
+	ErrBlocked       = errors.New("destination is blocked")
+	ErrNotAllowed    = errors.New("destination is not on the allowlist")
+	ErrInvalidPolicy = errors.New("invalid destination policy")
+)
+
+// Rules is the content of a policy file, in YAML.
+//
+// Domains are matched exactly, "*.example.com" matching every subdomain of
+// example.com but not example.com itself.
+//
+// Fields:
+// - BlockedDomains: the domains destinations may not lead to.
+// - BlockedPatterns: regular expressions destinations, as written, may not match.
+// - BlockedSchemes: the schemes destinations may not use, e.g. "http" or "javascript".
+// - AllowedDomains: the only domains destinations may lead to, any domain if empty.
+type Rules struct {
+	BlockedDomains  []string `yaml:"blocked_domains"`
+	BlockedPatterns []string `yaml:"blocked_patterns"`
+	BlockedSchemes  []string `yaml:"blocked_schemes"`
+	AllowedDomains  []string `yaml:"allowed_domains"`
+}
+
+// Violation is returned for a destination refused by a policy.
+//
+// Fields:
+// - Destination: the refused destination.
+// - Rule: the kind of rule refusing it, e.g. "blocked domain".
+// - Err: ErrBlocked or ErrNotAllowed.
+type Violation struct {
+	Destination string
+	Rule        string
+	Err         error
+}
+
+// Error implements error.
+func (v *Violation) Error() string {
+	return fmt.Sprintf("destination %s is refused by a %s rule", v.Destination, v.Rule)
+}
+
+// Unwrap returns ErrBlocked or ErrNotAllowed.
+func (v *Violation) Unwrap() error {
+	return v.Err
+}
+
+// domains matches hosts against domains and wildcard subdomains.
+type domains struct {
+	exact    map[string]bool
+	suffixes []string
+}
+
+// newDomains parses a list of domains.
+//
+// Parameters:
+// - list: the domains, optionally starting with "*.".
+//
+// Returns:
+// - domains: the parsed domains.
+// - error: an error naming the first malformed domain.
+func newDomains(list []string) (domains, error) {
+	parsed := domains{exact: map[string]bool{}}
+
+	for _, domain := range list {
+		domain = normalizeHost(domain)
+		if base, ok := strings.CutPrefix(domain, WILDCARD_PREFIX); ok {
+			if base == "" || strings.Contains(base, "*") {
+				return domains{}, fmt.Errorf("%w: malformed domain %q", ErrInvalidPolicy, domain)
+			}
+			parsed.suffixes = append(parsed.suffixes, "."+base)
+			continue
+		}
+
+		if domain == "" || strings.ContainsAny(domain, "*/:") {
+			return domains{}, fmt.Errorf("%w: malformed domain %q", ErrInvalidPolicy, domain)
+		}
+		parsed.exact[domain] = true
+	}
+
+	return parsed, nil
+}
+
+// isEmpty reports whether no domain is listed.
+func (d domains) isEmpty() bool {
+	return len(d.exact) == 0 && len(d.suffixes) == 0
+}
+
+// match reports whether a normalized host is listed.
+func (d domains) match(host string) bool {
+	if d.exact[host] {
+		return true
+	}
+
+	for _, suffix := range d.suffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Policy decides which destinations links may lead to. The zero Policy
+// allows every destination.
+type Policy struct {
+	blocked  domains
+	allowed  domains
+	patterns []*regexp.Regexp
+	schemes  map[string]bool
+}
+
+// Parse parses the YAML rules of a policy.
+//
+// Parameters:
+// - data: the content of the policy file.
+//
+// Returns:
+// - *Policy: the policy.
+// - error: ErrInvalidPolicy if the file is not valid YAML, or a domain or pattern is malformed.
+func Parse(data []byte) (*Policy, error) {
+	var rules Rules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPolicy, err)
+	}
+
+	return New(rules)
+}
+
+// New returns the policy applying the rules.
+//
+// Parameters:
+// - rules: the rules.
+//
+// Returns:
+// - *Policy: the policy.
+// - error: ErrInvalidPolicy if a domain or pattern is malformed.
+func New(rules Rules) (*Policy, error) {
+	policy := &Policy{schemes: map[string]bool{}}
+
+	var err error
+	if policy.blocked, err = newDomains(rules.BlockedDomains); err != nil {
+		return nil, err
+	}
+	if policy.allowed, err = newDomains(rules.AllowedDomains); err != nil {
+		return nil, err
+	}
+
+	for _, pattern := range rules.BlockedPatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPolicy, err)
+		}
+		policy.patterns = append(policy.patterns, compiled)
+	}
+
+	for _, scheme := range rules.BlockedSchemes {
+		policy.schemes[strings.ToLower(strings.TrimSuffix(strings.TrimSpace(scheme), ":"))] = true
+	}
+
+	return policy, nil
+}
+
+// Load reads and parses a policy file.
+//
+// Parameters:
+// - path: the path of the YAML file.
+//
+// Returns:
+// - *Policy: the policy.
+// - error: an error if the file cannot be read, or ErrInvalidPolicy.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Check decides whether a destination is allowed.
+//
+// Schemes and patterns apply to every destination, including the app URIs
+// of deep links. Domains apply to http and https destinations, the
+// blocklist winning over the allowlist.
+//
+// Parameters:
+// - destination: the URL or URI.
+//
+// Returns:
+// - error: a Violation wrapping ErrBlocked or ErrNotAllowed if the destination is refused.
+func (p *Policy) Check(destination string) error {
+	for _, pattern := range p.patterns {
+		if pattern.MatchString(destination) {
+			return &Violation{Destination: destination, Rule: "blocked pattern", Err: ErrBlocked}
+		}
+	}
+
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		// Malformed destinations are refused by their own validation.
+		return nil
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if p.schemes[scheme] {
+		return &Violation{Destination: destination, Rule: "blocked scheme", Err: ErrBlocked}
+	}
+
+	if scheme != "http" && scheme != "https" {
+		return nil
+	}
+
+	host := normalizeHost(parsed.Hostname())
+	if p.blocked.match(host) {
+		return &Violation{Destination: destination, Rule: "blocked domain", Err: ErrBlocked}
+	}
+	if !p.allowed.isEmpty() && !p.allowed.match(host) {
+		return &Violation{Destination: destination, Rule: "allowed domain", Err: ErrNotAllowed}
+	}
+
+	return nil
+}
+
+// normalizeHost lowercases a host and removes the dot ending fully
+// qualified names, so that "Evil.COM." matches "evil.com".
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
@@ -0,0 +1,121 @@
+package urlpolicy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flew1x/url_shortener_ms/pkg/urlpolicy"
+)
+
+func TestCheck(t *testing.T) {
+	policy, err := urlpolicy.Parse([]byte(`
+blocked_domains: ["evil.com", "*.phish.example"]
+blocked_patterns: ['(?i)paypa1', '/wp-admin/']
+blocked_schemes: ["javascript:", "HTTP"]
+allowed_domains: ["example.com", "*.example.com", "evil.com", "login.phish.example"]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		destination string
+		err         error
+	}{
+		{destination: "https://example.com/page", err: nil},
+		{destination: "https://WWW.Example.com./page", err: nil},
+		{destination: "https://evil.com/", err: urlpolicy.ErrBlocked},
+		{destination: "https://EVIL.com:8443/", err: urlpolicy.ErrBlocked},
+		{destination: "https://login.phish.example/", err: urlpolicy.ErrBlocked},
+		{destination: "https://www.example.com/PayPa1/login", err: urlpolicy.ErrBlocked},
+		{destination: "http://example.com/", err: urlpolicy.ErrBlocked},
+		{destination: "javascript:alert(1)", err: urlpolicy.ErrBlocked},
+		{destination: "https://phish.example/", err: urlpolicy.ErrNotAllowed},
+		{destination: "https://notexample.com/", err: urlpolicy.ErrNotAllowed},
+		{destination: "myapp://open/item", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			if err := policy.Check(tt.destination); !errors.Is(err, tt.err) {
+				t.Errorf("Check() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	if err := (&urlpolicy.Policy{}).Check("https://evil.com/"); err != nil {
+		t.Errorf("zero Policy Check() error = %v, want nil", err)
+	}
+
+	for _, invalid := range []string{"blocked_patterns: ['(']", "blocked_domains: ['*.']", "blocked_domains: ['evil.*']", "allowed_domains: {"} {
+		if _, err := urlpolicy.Parse([]byte(invalid)); !errors.Is(err, urlpolicy.ErrInvalidPolicy) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidPolicy", invalid, err)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	write := func(content string) {
+		t.Helper()
+		// Replace the file as editors do, by renaming a new file over it.
+		if err := os.WriteFile(path+".tmp", []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`blocked_domains: ["evil.com"]`)
+	file, err := urlpolicy.NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads := make(chan error, 10)
+	watching := make(chan error, 1)
+	go func() { watching <- file.Watch(ctx, func(err error) { reloads <- err }) }()
+
+	// Wait until the watch has started and seen a change.
+	deadline := time.After(5 * time.Second)
+	for reloaded := false; !reloaded; {
+		write(`blocked_domains: ["evil.com", "worse.com"]`)
+		select {
+		case err := <-reloads:
+			if err != nil {
+				t.Fatalf("reload error = %v", err)
+			}
+			reloaded = true
+		case err := <-watching:
+			t.Fatalf("Watch() error = %v", err)
+		case <-deadline:
+			t.Fatal("policy not reloaded")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if err := file.Check("https://worse.com/"); !errors.Is(err, urlpolicy.ErrBlocked) {
+		t.Errorf("after reload Check() error = %v, want ErrBlocked", err)
+	}
+
+	write(`blocked_patterns: ['(']`)
+	select {
+	case err := <-reloads:
+		for err == nil {
+			err = <-reloads
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("invalid policy not reported")
+	}
+	if err := file.Check("https://worse.com/"); !errors.Is(err, urlpolicy.ErrBlocked) {
+		t.Errorf("after invalid reload Check() error = %v, want the previous policy kept", err)
+	}
+}
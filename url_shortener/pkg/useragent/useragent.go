@@ -0,0 +1,120 @@
+package useragent
+
+import "strings"
+
+const (
+	OS_IOS      = "ios"
+	OS_ANDROID  = "android"
+	OS_WINDOWS  = "windows"
+	OS_MACOS    = "macos"
+	OS_LINUX    = "linux"
+	OS_CHROMEOS = "chromeos"
+
+	DEVICE_MOBILE  = "mobile"
+	DEVICE_TABLET  = "tablet"
+	DEVICE_DESKTOP = "desktop"
+	DEVICE_BOT     = "bot"
+
+	BROWSER_CHROME  = "chrome"
+	BROWSER_FIREFOX = "firefox"
+	BROWSER_SAFARI  = "safari"
+	BROWSER_EDGE    = "edge"
+	BROWSER_OPERA   = "opera"
+	BROWSER_SAMSUNG = "samsung"
+)
+
+// Agent describes the client behind a User-Agent header.
+//
+// Fields:
+// - OS: the operating system, or empty if unknown.
+// - Device: the device class, desktop if unknown.
+// - Browser: the browser, or empty if unknown.
+type Agent struct {
+	OS      string
+	Device  string
+	Browser string
+}
+
+// botMarkers identify crawlers and link preview fetchers.
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit", "curl/", "wget/"}
+
+// Parse extracts the operating system, device class and browser from a
+// User-Agent header.
+//
+// Only the families used for targeting are recognized, versions are
+// ignored. Tokens are checked from the most to the least specific, since
+// most browsers also announce the engines of others.
+//
+// Parameters:
+// - header: the User-Agent header.
+//
+// Returns:
+// - Agent: the parsed client.
+func Parse(header string) Agent {
+	ua := strings.ToLower(header)
+
+	return Agent{
+		OS:      parseOS(ua),
+		Device:  parseDevice(ua),
+		Browser: parseBrowser(ua),
+	}
+}
+
+// parseOS returns the operating system of a lowercase User-Agent.
+func parseOS(ua string) string {
+	switch {
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return OS_IOS
+	case strings.Contains(ua, "android"):
+		return OS_ANDROID
+	case strings.Contains(ua, "cros"):
+		return OS_CHROMEOS
+	case strings.Contains(ua, "windows"):
+		return OS_WINDOWS
+	case strings.Contains(ua, "mac os x"), strings.Contains(ua, "macintosh"):
+		return OS_MACOS
+	case strings.Contains(ua, "linux"):
+		return OS_LINUX
+	default:
+		return ""
+	}
+}
+
+// parseDevice returns the device class of a lowercase User-Agent.
+func parseDevice(ua string) string {
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return DEVICE_BOT
+		}
+	}
+
+	switch {
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return DEVICE_TABLET
+	case strings.Contains(ua, "mobile"), strings.Contains(ua, "iphone"), strings.Contains(ua, "ipod"):
+		return DEVICE_MOBILE
+	default:
+		return DEVICE_DESKTOP
+	}
+}
+
+// parseBrowser returns the browser of a lowercase User-Agent.
+func parseBrowser(ua string) string {
+	switch {
+	case strings.Contains(ua, "edg/"), strings.Contains(ua, "edga/"), strings.Contains(ua, "edgios/"):
+		return BROWSER_EDGE
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		return BROWSER_OPERA
+	case strings.Contains(ua, "samsungbrowser/"):
+		return BROWSER_SAMSUNG
+	case strings.Contains(ua, "firefox/"), strings.Contains(ua, "fxios/"):
+		return BROWSER_FIREFOX
+	case strings.Contains(ua, "chrome/"), strings.Contains(ua, "crios/"):
+		return BROWSER_CHROME
+	case strings.Contains(ua, "safari/"):
+		return BROWSER_SAFARI
+	default:
+		return ""
+	}
+}
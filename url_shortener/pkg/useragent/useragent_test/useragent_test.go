@@ -0,0 +1,49 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/pkg/useragent"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   useragent.Agent
+	}{
+		{
+			name:   "iphone safari",
+			header: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			want:   useragent.Agent{OS: useragent.OS_IOS, Device: useragent.DEVICE_MOBILE, Browser: useragent.BROWSER_SAFARI},
+		},
+		{
+			name:   "android chrome",
+			header: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+			want:   useragent.Agent{OS: useragent.OS_ANDROID, Device: useragent.DEVICE_MOBILE, Browser: useragent.BROWSER_CHROME},
+		},
+		{
+			name:   "windows edge",
+			header: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.0.0",
+			want:   useragent.Agent{OS: useragent.OS_WINDOWS, Device: useragent.DEVICE_DESKTOP, Browser: useragent.BROWSER_EDGE},
+		},
+		{
+			name:   "ipad",
+			header: "Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0 Mobile/15E148 Safari/604.1",
+			want:   useragent.Agent{OS: useragent.OS_IOS, Device: useragent.DEVICE_TABLET, Browser: useragent.BROWSER_CHROME},
+		},
+		{
+			name:   "crawler",
+			header: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want:   useragent.Agent{Device: useragent.DEVICE_BOT},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := useragent.Parse(tt.header); got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
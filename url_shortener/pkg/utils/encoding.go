@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AcceptsEncoding reports whether an Accept-Encoding header accepts a
+// content coding, as RFC 9110 defines it.
+//
+// A coding listed with q=0 is refused. A coding that is not listed is
+// accepted with the weight of "*", if present. Codings are compared
+// without regard to case, and malformed weights count as q=0.
+//
+// Parameters:
+// - acceptEncoding: the Accept-Encoding header value.
+// - coding: the content coding, e.g. "gzip".
+//
+// Returns:
+// - bool: true if the coding is accepted with a weight above zero.
+func AcceptsEncoding(acceptEncoding, coding string) bool {
+	listed, wildcard := -1.0, -1.0
+
+	for _, entry := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(entry, ";")
+		name = strings.TrimSpace(name)
+
+		weight := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			weight = parsed
+		}
+
+		switch {
+		case strings.EqualFold(name, coding):
+			listed = weight
+		case name == "*":
+			wildcard = weight
+		}
+	}
+
+	if listed >= 0 {
+		return listed > 0
+	}
+
+	return wildcard > 0
+}
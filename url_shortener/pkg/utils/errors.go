@@ -3,5 +3,6 @@ package utils
 import "errors"
 
 var (
-	ErrNotValidURL = errors.New("not valid URL")
+	ErrNotValidURL         = errors.New("not valid URL")
+	ErrNotValidQueryPolicy = errors.New("query policy must be drop, append or merge and precedence origin or request")
 )
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// AppendPath appends the remainder of a visited prefix link to its origin.
+//
+// The remainder is cleaned first, so that it cannot climb above the path
+// of the origin. The query and fragment of the origin are kept.
+//
+// Parameters:
+// - origin: the original URL.
+// - rest: the visited path after the code of the link.
+//
+// Returns:
+// - string: the URL to redirect to.
+// - error: an error if the origin cannot be parsed.
+func AppendPath(origin, rest string) (string, error) {
+	if rest == "" || rest == "/" {
+		return origin, nil
+	}
+
+	destination, err := url.Parse(origin)
+	if err != nil {
+		return "", err
+	}
+
+	cleaned := path.Clean("/" + rest)
+	if strings.HasSuffix(rest, "/") {
+		cleaned += "/"
+	}
+
+	destination.Path = strings.TrimSuffix(destination.Path, "/") + cleaned
+	destination.RawPath = ""
+
+	return destination.String(), nil
+}
+
+// SplitCodePath returns the codes a visited path may start with, longest
+// first, along with the remainder of the path after each of them.
+//
+// Parameters:
+// - visited: the visited path after the short link prefix.
+// - maxDepth: the maximum number of segments of a code.
+//
+// Returns:
+// - []string: the candidate codes, longest first.
+// - []string: the remainder of the path after each candidate.
+func SplitCodePath(visited string, maxDepth int) (codes []string, rests []string) {
+	segments := strings.Split(strings.Trim(visited, "/"), "/")
+	if segments[0] == "" {
+		return nil, nil
+	}
+
+	depth := min(len(segments), maxDepth)
+	for i := depth; i > 0; i-- {
+		codes = append(codes, strings.Join(segments[:i], "/"))
+
+		rest := ""
+		if i < len(segments) {
+			rest = "/" + strings.Join(segments[i:], "/")
+		}
+		if strings.HasSuffix(visited, "/") && rest != "" {
+			rest += "/"
+		}
+		rests = append(rests, rest)
+	}
+
+	return codes, rests
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"net/url"
+	"slices"
+)
+
+const (
+	QUERY_POLICY_DROP   = "drop"
+	QUERY_POLICY_APPEND = "append"
+	QUERY_POLICY_MERGE  = "merge"
+
+	QUERY_PRECEDENCE_ORIGIN  = "origin"
+	QUERY_PRECEDENCE_REQUEST = "request"
+)
+
+// PassQuery passes the query of a visited short URL to its origin.
+//
+// Policies:
+// - "drop" or empty: the origin is returned unchanged.
+// - "append": the visited parameters are added after the origin parameters,
+// keeping both values of a parameter present on both sides.
+// - "merge": parameters present on both sides keep the values of the side
+// named by the precedence, "origin" (the default) or "request".
+//
+// Only visited parameters named in the allowlist are passed, an empty
+// allowlist passes all of them. Parameters are re-encoded, so the result
+// is always correctly escaped.
+//
+// Parameters:
+// - origin: the original URL.
+// - visited: the query of the visited short URL.
+// - policy: the query policy of the link.
+// - precedence: which side wins merge conflicts.
+// - allowlist: the parameter names passed to the origin.
+//
+// Returns:
+// - string: the URL to redirect to.
+// - error: an error if the origin cannot be parsed.
+func PassQuery(origin string, visited url.Values, policy, precedence string, allowlist []string) (string, error) {
+	if policy == "" || policy == QUERY_POLICY_DROP || len(visited) == 0 {
+		return origin, nil
+	}
+
+	passed := url.Values{}
+	for name, values := range visited {
+		if len(allowlist) == 0 || slices.Contains(allowlist, name) {
+			passed[name] = values
+		}
+	}
+
+	if len(passed) == 0 {
+		return origin, nil
+	}
+
+	destination, err := url.Parse(origin)
+	if err != nil {
+		return "", err
+	}
+
+	switch policy {
+	case QUERY_POLICY_APPEND:
+		if destination.RawQuery == "" {
+			destination.RawQuery = passed.Encode()
+		} else {
+			destination.RawQuery += "&" + passed.Encode()
+		}
+	case QUERY_POLICY_MERGE:
+		merged := destination.Query()
+		for name, values := range passed {
+			if _, conflict := merged[name]; conflict && precedence != QUERY_PRECEDENCE_REQUEST {
+				continue
+			}
+			merged[name] = values
+		}
+		destination.RawQuery = merged.Encode()
+	default:
+		return origin, nil
+	}
+
+	return destination.String(), nil
+}
+
+// ValidateQueryPolicy checks a query policy and merge precedence.
+//
+// Parameters:
+// - policy: the query policy, empty meaning drop.
+// - precedence: the merge precedence, empty meaning origin.
+//
+// Returns:
+// - error: ErrNotValidQueryPolicy if either value is unknown.
+func ValidateQueryPolicy(policy, precedence string) error {
+	switch policy {
+	case "", QUERY_POLICY_DROP, QUERY_POLICY_APPEND, QUERY_POLICY_MERGE:
+	default:
+		return ErrNotValidQueryPolicy
+	}
+
+	switch precedence {
+	case "", QUERY_PRECEDENCE_ORIGIN, QUERY_PRECEDENCE_REQUEST:
+	default:
+		return ErrNotValidQueryPolicy
+	}
+
+	return nil
+}
+
+// SetQuery sets query parameters of a URL, replacing the values it already
+// has for them.
+//
+// Parameters:
+// - destination: the URL to change.
+// - values: the parameters to set.
+//
+// Returns:
+// - string: the changed URL.
+// - error: an error if the URL cannot be parsed.
+func SetQuery(destination string, values url.Values) (string, error) {
+	if len(values) == 0 {
+		return destination, nil
+	}
+
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+
+	query := parsed.Query()
+	for name, value := range values {
+		query[name] = value
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+func TestAcceptsEncoding(t *testing.T) {
+	tests := map[string]bool{
+		"":                        false,
+		"gzip":                    true,
+		"GZIP":                    true,
+		"deflate, gzip;q=1.0":     true,
+		"gzip;q=0.5":              true,
+		"gzip;q=0":                false,
+		"gzip; q=0.000":           false,
+		"gzip;q=abc":              false,
+		"br, deflate":             false,
+		"*":                       true,
+		"*;q=0":                   false,
+		"gzip;q=0, *":             false,
+		"*;q=0, gzip":             true,
+		"br;q=1.0, gzip;q=0.8, *": true,
+	}
+
+	for header, want := range tests {
+		t.Run(header, func(t *testing.T) {
+			if got := utils.AcceptsEncoding(header, "gzip"); got != want {
+				t.Errorf("AcceptsEncoding(%q, gzip) = %v, want %v", header, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+func TestSplitCodePath(t *testing.T) {
+	codes, rests := utils.SplitCodePath("/docs/getting-started/install", 2)
+
+	if want := []string{"docs/getting-started", "docs"}; !slices.Equal(codes, want) {
+		t.Errorf("codes = %q, want %q", codes, want)
+	}
+	if want := []string{"/install", "/getting-started/install"}; !slices.Equal(rests, want) {
+		t.Errorf("rests = %q, want %q", rests, want)
+	}
+}
+
+func TestAppendPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin string
+		rest   string
+		want   string
+	}{
+		{name: "no rest", origin: "https://example.com/docs?v=1", rest: "", want: "https://example.com/docs?v=1"},
+		{name: "rest", origin: "https://example.com/docs/?v=1", rest: "/getting-started/install", want: "https://example.com/docs/getting-started/install?v=1"},
+		{name: "dot segments", origin: "https://example.com/docs", rest: "/../../admin", want: "https://example.com/docs/admin"},
+		{name: "escaped", origin: "https://example.com", rest: "/a b", want: "https://example.com/a%20b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.AppendPath(tt.origin, tt.rest)
+			if err != nil {
+				t.Fatalf("AppendPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AppendPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
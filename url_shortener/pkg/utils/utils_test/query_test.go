@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/flew1x/url_shortener_ms/pkg/utils"
+)
+
+func TestPassQuery(t *testing.T) {
+	visited := url.Values{"utm_source": {"mail"}, "ref": {"a b"}}
+
+	tests := []struct {
+		name       string
+		origin     string
+		policy     string
+		precedence string
+		allowlist  []string
+		want       string
+	}{
+		{name: "drop", origin: "https://example.com/?ref=x", policy: utils.QUERY_POLICY_DROP, want: "https://example.com/?ref=x"},
+		{name: "append", origin: "https://example.com/?ref=x", policy: utils.QUERY_POLICY_APPEND, want: "https://example.com/?ref=x&ref=a+b&utm_source=mail"},
+		{name: "merge origin", origin: "https://example.com/?ref=x", policy: utils.QUERY_POLICY_MERGE, want: "https://example.com/?ref=x&utm_source=mail"},
+		{name: "merge request", origin: "https://example.com/?ref=x", policy: utils.QUERY_POLICY_MERGE, precedence: utils.QUERY_PRECEDENCE_REQUEST, want: "https://example.com/?ref=a+b&utm_source=mail"},
+		{name: "allowlist", origin: "https://example.com/", policy: utils.QUERY_POLICY_APPEND, allowlist: []string{"utm_source"}, want: "https://example.com/?utm_source=mail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.PassQuery(tt.origin, visited, tt.policy, tt.precedence, tt.allowlist)
+			if err != nil {
+				t.Fatalf("PassQuery() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PassQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}